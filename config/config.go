@@ -3,7 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var AppConfig Config
@@ -45,10 +47,19 @@ type Config struct {
 	ElasticConfig
 	RedisConfig
 	TwilioConfig
+	SMSConfig
+	MailConfig
 	GoogleConfig
+	AppleConfig
 	SentryConfig
 	AWSConfig
 	BaseURL
+	JWTConfig
+	TOTPConfig
+	AccountDeletionConfig
+	SwipeConfig
+	MatchExpiryConfig
+	FirstMoveConfig
 }
 
 type ElasticConfig struct {
@@ -69,11 +80,41 @@ type TwilioConfig struct {
 	PhoneNumber string
 }
 
+// SMSConfig selects how text messages are sent. Provider is "twilio", or "log" to write messages
+// to LogFile (or the application log when LogFile is empty) instead of sending them.
+type SMSConfig struct {
+	Provider string
+	LogFile  string
+	AppName  string
+}
+
+// MailConfig selects how email is sent. Provider is "ses", "smtp", or "dir" to write every email
+// as an .eml file to Dir instead of sending it. Emails are sent as FromName <From>.
+// PasswordResetURL is the web page or app deep link password reset emails link to, with the reset
+// token added as the token query parameter.
+type MailConfig struct {
+	Provider         string
+	From             string
+	FromName         string
+	Dir              string
+	SMTPHost         string
+	SMTPPort         string
+	SMTPUsername     string
+	SMTPPassword     string
+	AppName          string
+	PasswordResetURL string
+}
+
 type GoogleConfig struct {
 	ClientID     string
 	ClientSecret string
 }
 
+// AppleConfig lists the bundle and services ids accepted as audience of Apple identity tokens.
+type AppleConfig struct {
+	ClientIDs []string
+}
+
 type SentryConfig struct {
 	DSN string
 }
@@ -82,7 +123,62 @@ type BaseURL struct {
 	URL string
 }
 
-const SecretKey string = ""
+// JWTConfig holds the keys used to sign and verify auth tokens. Every key in Keys is accepted
+// when verifying, new tokens are signed with the key identified by SigningKeyID.
+type JWTConfig struct {
+	SigningKeyID string
+	Keys         []JWTKey
+}
+
+// JWTKey is a single signing key. Key is the shared secret for HS256, and a PEM file path or
+// inline PEM for RS256 and ES256. A public key PEM makes the key verify-only.
+type JWTKey struct {
+	ID        string
+	Algorithm string
+	Key       string
+}
+
+// TOTPConfig configures two factor authentication. Issuer is the account name shown in
+// authenticator apps and EncryptionKey the base64 encoded 32 byte AES key TOTP secrets are
+// encrypted with at rest.
+type TOTPConfig struct {
+	Issuer        string
+	EncryptionKey string
+}
+
+// AccountDeletionConfig sets how long a deleted account can still be restored before all of its data is erased.
+type AccountDeletionConfig struct {
+	GracePeriod time.Duration
+}
+
+// SwipeConfig sets the daily swipe quotas of free and premium users. A likes quota of 0 means
+// likes are unlimited.
+type SwipeConfig struct {
+	LikesPerDay             int
+	PremiumLikesPerDay      int
+	SuperLikesPerDay        int
+	PremiumSuperLikesPerDay int
+}
+
+// MatchExpiryConfig sets how long a match lasts without a first message, how long before that users
+// are reminded, and how much longer a premium extension gives it.
+type MatchExpiryConfig struct {
+	Window         time.Duration
+	ReminderBefore time.Duration
+	Extension      time.Duration
+}
+
+// First move modes. With FirstMoveWomen, in a match between a woman and a man only the woman can send
+// the first message.
+const (
+	FirstMoveAnyone = "anyone"
+	FirstMoveWomen  = "women"
+)
+
+// FirstMoveConfig sets who of a match can send the first message.
+type FirstMoveConfig struct {
+	Mode string
+}
 
 var ConfigValue Config
 
@@ -120,10 +216,30 @@ func Load(appEnv string) (Config, error) {
 			AuthToken:   twilioAuthToken(),
 			PhoneNumber: twilioPhoneNumber(),
 		},
+		SMSConfig: SMSConfig{
+			Provider: smsProvider(),
+			LogFile:  os.Getenv("SMS_LOG_FILE"),
+			AppName:  appName(),
+		},
+		MailConfig: MailConfig{
+			Provider:         mailProvider(),
+			From:             mailFrom(),
+			FromName:         mailFromName(),
+			Dir:              mailDir(),
+			SMTPHost:         smtpHost(),
+			SMTPPort:         smtpPort(),
+			SMTPUsername:     os.Getenv("SMTP_USERNAME"),
+			SMTPPassword:     os.Getenv("SMTP_PASSWORD"),
+			AppName:          appName(),
+			PasswordResetURL: passwordResetURL(appEnv),
+		},
 		GoogleConfig: GoogleConfig{
 			ClientID:     googleClientID(),
 			ClientSecret: googleClientSecret(),
 		},
+		AppleConfig: AppleConfig{
+			ClientIDs: appleClientIDs(),
+		},
 		SentryConfig: SentryConfig{
 			DSN: sentryDSN(),
 		},
@@ -137,6 +253,31 @@ func Load(appEnv string) (Config, error) {
 		BaseURL: BaseURL{
 			URL: getBaseURL(appEnv),
 		},
+		JWTConfig: JWTConfig{
+			SigningKeyID: jwtSigningKeyID(),
+			Keys:         jwtKeys(),
+		},
+		TOTPConfig: TOTPConfig{
+			Issuer:        appName(),
+			EncryptionKey: totpEncryptionKey(),
+		},
+		AccountDeletionConfig: AccountDeletionConfig{
+			GracePeriod: accountDeletionGracePeriod(),
+		},
+		SwipeConfig: SwipeConfig{
+			LikesPerDay:             dailyLimit("LIKES_PER_DAY", 100),
+			PremiumLikesPerDay:      dailyLimit("PREMIUM_LIKES_PER_DAY", 0),
+			SuperLikesPerDay:        dailyLimit("SUPER_LIKES_PER_DAY", 1),
+			PremiumSuperLikesPerDay: dailyLimit("PREMIUM_SUPER_LIKES_PER_DAY", 5),
+		},
+		MatchExpiryConfig: MatchExpiryConfig{
+			Window:         matchExpiryDuration("MATCH_EXPIRY_WINDOW", 72*time.Hour),
+			ReminderBefore: matchExpiryDuration("MATCH_EXPIRY_REMINDER", 6*time.Hour),
+			Extension:      matchExpiryDuration("MATCH_EXTENSION", 24*time.Hour),
+		},
+		FirstMoveConfig: FirstMoveConfig{
+			Mode: firstMoveMode(),
+		},
 	}
 
 	AppConfig = ConfigValue
@@ -257,10 +398,157 @@ func databaseName() string {
 	return db
 }
 
+func smsProvider() string {
+	provider := strings.ToLower(os.Getenv("SMS_PROVIDER"))
+
+	if provider == "" {
+		return "twilio"
+	}
+	return provider
+}
+
+func appName() string {
+	name := os.Getenv("APP_NAME")
+
+	if name == "" {
+		return "Pluto"
+	}
+	return name
+}
+
+func mailProvider() string {
+	provider := strings.ToLower(os.Getenv("MAIL_PROVIDER"))
+
+	if provider == "" {
+		return "ses"
+	}
+	return provider
+}
+
+func mailFrom() string {
+	from := os.Getenv("MAIL_FROM")
+
+	if from == "" && mailProvider() != "dir" {
+		log.Fatalln("MAIL_FROM not found.")
+	} else if from == "" {
+		return "no-reply@localhost"
+	}
+	return from
+}
+
+func mailFromName() string {
+	name := os.Getenv("MAIL_FROM_NAME")
+
+	if name == "" {
+		return appName()
+	}
+	return name
+}
+
+func passwordResetURL(appEnv string) string {
+	resetURL := os.Getenv("PASSWORD_RESET_URL")
+
+	if resetURL == "" {
+		return getBaseURL(appEnv) + "/reset-password"
+	}
+	return resetURL
+}
+
+func mailDir() string {
+	dir := os.Getenv("MAIL_DIR")
+
+	if dir == "" {
+		return "mail"
+	}
+	return dir
+}
+
+func smtpHost() string {
+	host := os.Getenv("SMTP_HOST")
+
+	if host == "" && mailProvider() == "smtp" {
+		log.Fatalln("SMTP_HOST not found.")
+	}
+	return host
+}
+
+func smtpPort() string {
+	port := os.Getenv("SMTP_PORT")
+
+	if port == "" {
+		return "587"
+	}
+	return port
+}
+
+func totpEncryptionKey() string {
+	key := os.Getenv("TOTP_ENCRYPTION_KEY")
+
+	if key == "" {
+		log.Fatalln("TOTP_ENCRYPTION_KEY not found.")
+	}
+	return key
+}
+
+func accountDeletionGracePeriod() time.Duration {
+	gracePeriod := os.Getenv("ACCOUNT_DELETION_GRACE_PERIOD")
+
+	if gracePeriod == "" {
+		return 30 * 24 * time.Hour
+	}
+
+	duration, err := time.ParseDuration(gracePeriod)
+	if err != nil || duration < 0 {
+		log.Fatalln("ACCOUNT_DELETION_GRACE_PERIOD must be a duration like 720h.")
+	}
+	return duration
+}
+
+func matchExpiryDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Fatalln(name + " must be a duration like 72h.")
+	}
+	return duration
+}
+
+func firstMoveMode() string {
+	mode := os.Getenv("FIRST_MOVE_MODE")
+
+	switch mode {
+	case "":
+		return FirstMoveAnyone
+	case FirstMoveAnyone, FirstMoveWomen:
+		return mode
+	}
+	log.Fatalln("FIRST_MOVE_MODE must be anyone or women.")
+	return ""
+}
+
+func dailyLimit(name string, fallback int) int {
+	value := os.Getenv(name)
+
+	if value == "" {
+		return fallback
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		log.Fatalln(name + " must be a number of swipes per day.")
+	}
+	return limit
+}
+
 func twilioAccountSID() string {
 	accountSID := os.Getenv("TWILIO_ACCOUNT_SID")
 
-	if accountSID == "" {
+	if accountSID == "" && smsProvider() == "twilio" {
 		log.Fatalln("TWILIO_ACCOUNT_SID not found.")
 	}
 
@@ -270,7 +558,7 @@ func twilioAccountSID() string {
 func twilioAuthToken() string {
 	authToken := os.Getenv("TWILIO_AUTH_TOKEN")
 
-	if authToken == "" {
+	if authToken == "" && smsProvider() == "twilio" {
 		log.Fatalln("TWILIO_AUTH_TOKEN not found.")
 	}
 
@@ -280,7 +568,7 @@ func twilioAuthToken() string {
 func twilioPhoneNumber() string {
 	phoneNumber := os.Getenv("TWILIO_PHONE_NUMBER")
 
-	if phoneNumber == "" {
+	if phoneNumber == "" && smsProvider() == "twilio" {
 		log.Fatalln("TWILIO_PHONE_NUMBER not found.")
 	}
 
@@ -307,6 +595,15 @@ func googleClientSecret() string {
 	return clientSecret
 }
 
+func appleClientIDs() []string {
+	val := os.Getenv("APPLE_CLIENT_IDS")
+
+	if val == "" {
+		return nil
+	}
+	return strings.Split(val, ",")
+}
+
 func sentryDSN() string {
 	dsn := os.Getenv("SENTRY_DSN")
 
@@ -316,6 +613,33 @@ func sentryDSN() string {
 	return dsn
 }
 
+func jwtSigningKeyID() string {
+	return os.Getenv("JWT_SIGNING_KEY_ID")
+}
+
+// jwtKeys reads JWT_KEYS, a comma separated list of kid:algorithm:key entries.
+func jwtKeys() []JWTKey {
+	val := os.Getenv("JWT_KEYS")
+
+	if val == "" {
+		log.Fatalln("JWT_KEYS not found.")
+	}
+
+	var keys []JWTKey
+	for _, entry := range strings.Split(val, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) != 3 {
+			log.Fatalln("JWT_KEYS entry must be in kid:algorithm:key format.")
+		}
+		keys = append(keys, JWTKey{
+			ID:        parts[0],
+			Algorithm: strings.ToUpper(parts[1]),
+			Key:       parts[2],
+		})
+	}
+	return keys
+}
+
 func getBaseURL(appENV string) string {
 	if appENV == "dev" {
 		return "http://localhost:8080"
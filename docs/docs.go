@@ -16,50 +16,25 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/chat/last/messages": {
+        "/.well-known/jwks.json": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get Last Messages of Chats",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Public keys for verifying auth tokens, in JSON Web Key Set format",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
-                ],
-                "summary": "Get Last Messages",
-                "parameters": [
-                    {
-                        "description": "Chat IDs",
-                        "name": "chatIDs",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ChatIDs"
-                        }
-                    }
+                    "Authentication"
                 ],
+                "summary": "JWKS",
                 "responses": {
                     "200": {
-                        "description": "last messages retrieved successfully",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.JWKS"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -67,69 +42,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/message": {
-            "post": {
+        "/admin/account-deletions/{deletion_id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Save Message and Media",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Get an account deletion with the receipt of what was erased once it is completed. Requires the admin role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
+                    "Admin"
                 ],
-                "summary": "SaveMessage",
+                "summary": "Get account deletion receipt",
                 "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Media",
-                        "name": "media",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Message",
-                        "name": "message",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Sender ID",
-                        "name": "sender_id",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "Receiver ID",
-                        "name": "receiver_id",
-                        "in": "header",
+                        "description": "deletion id",
+                        "name": "deletion_id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "chat saved successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.AccountDeletion"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "no account deletion found",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -137,14 +100,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/messages/status": {
-            "put": {
+        "/admin/create/user_profile/index": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update Messages Status",
+                "description": "Create User Profile Index. Requires the admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -152,23 +115,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
-                ],
-                "summary": "UpdateMessagesStatus",
-                "parameters": [
-                    {
-                        "description": "Message IDs",
-                        "name": "messageIDs",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.MessagesIDs"
-                        }
-                    }
+                    "Profile"
                 ],
+                "summary": "CreateProfileIndex",
                 "responses": {
                     "200": {
-                        "description": "messages status updated successfully",
+                        "description": "index created successfully",
                         "schema": {
                             "type": "string"
                         }
@@ -188,14 +140,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/user/chats": {
-            "get": {
+        "/admin/create/user_stories/index": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve User Chats",
+                "description": "Create User Stories Index. Requires the admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -203,21 +155,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
-                ],
-                "summary": "RetrieveUserChats",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Chat ID",
-                        "name": "chat_id",
-                        "in": "header",
-                        "required": true
-                    }
+                    "Stories"
                 ],
+                "summary": "CreateStoriesIndex",
                 "responses": {
                     "200": {
-                        "description": "chats retrieved successfully",
+                        "description": "index created successfully",
                         "schema": {
                             "type": "string"
                         }
@@ -237,14 +180,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/user/list": {
-            "get": {
+        "/admin/event/index": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get User Chats List",
+                "description": "Create an event index. Requires the admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -252,27 +195,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
-                ],
-                "summary": "GetUserChatsList",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
-                    }
+                    "Events"
                 ],
+                "summary": "CreateEventIndexHandler",
                 "responses": {
                     "200": {
-                        "description": "chats retrieved successfully",
+                        "description": "ok",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "bad request",
                         "schema": {
                             "type": "string"
                         }
@@ -286,39 +220,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/create/user_profile/index": {
+        "/admin/reports": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create User Profile Index",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a page of 50 reports with the status, oldest first. Requires the moderator role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Admin"
+                ],
+                "summary": "Get moderation queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "open",
+                        "description": "open, actioned or dismissed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    }
                 ],
-                "summary": "CreateProfileIndex",
                 "responses": {
                     "200": {
-                        "description": "index created successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Report"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -326,39 +282,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/create/user_stories/index": {
+        "/admin/reports/{report_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create User Stories Index",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a report with the reported content in context: the photos and nudges of a reported profile, the conversation around a reported message, and the profile of the reported user with their number of open reports. The content is empty when it was removed since it was reported. Requires the moderator role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stories"
+                    "Admin"
+                ],
+                "summary": "Review report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "report id",
+                        "name": "report_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "CreateStoriesIndex",
                 "responses": {
                     "200": {
-                        "description": "index created successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ReportReview"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "report not found",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -366,14 +340,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/event/index": {
+        "/admin/reports/{report_id}/resolve": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create an event index",
+                "description": "Take an action on a report: dismiss, hide_media for photos, delete_nudge, delete_story, delete_event, or suspend_account for any content. Actions other than dismiss close every open report of the same content. Reporters are notified of the outcome. Requires the moderator role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -381,24 +355,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Admin"
+                ],
+                "summary": "Resolve report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "report id",
+                        "name": "report_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "action",
+                        "name": "resolution",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResolveReport"
+                        }
+                    }
                 ],
-                "summary": "CreateEventIndexHandler",
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.Report"
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "report not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "report is already resolved",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -406,14 +416,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/search": {
-            "get": {
+        "/admin/users/{user_id}/role": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Search events",
+                "description": "Change the role of a user to user, moderator or admin. All sessions of the user are signed out so the new role applies from their next sign in. The admin role is refused until the user has enabled two factor authentication. Requires the admin role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -421,57 +431,1154 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Admin"
                 ],
-                "summary": "SearchEventsHandler",
+                "summary": "Update user role",
                 "parameters": [
                     {
-                        "description": "Event Filter",
-                        "name": "eventFilter",
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "role",
+                        "name": "updateRole",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.EventFilterDTO"
+                            "$ref": "#/definitions/dto.UpdateRoleRequest"
                         }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "role updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.EventResponseDTO"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "two factor authentication not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/last/messages": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get Last Messages of Chats",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "Get Last Messages",
+                "parameters": [
+                    {
+                        "description": "Chat IDs",
+                        "name": "chatIDs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatIDs"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "last messages retrieved successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/message": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Save Message and Media",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "SaveMessage",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Media",
+                        "name": "media",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Message",
+                        "name": "message",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receiver ID",
+                        "name": "receiver_id",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "chat saved successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "user is blocked, the match expired or the other user makes the first move",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/messages/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update Messages Status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "UpdateMessagesStatus",
+                "parameters": [
+                    {
+                        "description": "Message IDs",
+                        "name": "messageIDs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MessagesIDs"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "messages status updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/user/chats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve User Chats",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "RetrieveUserChats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Chat ID",
+                        "name": "chat_id",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "chats retrieved successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/user/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get User Chats List",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "GetUserChatsList",
+                "responses": {
+                    "200": {
+                        "description": "chats retrieved successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Search events",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "SearchEventsHandler",
+                "parameters": [
+                    {
+                        "description": "Event Filter",
+                        "name": "eventFilter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EventFilterDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EventResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/filters": {
+            "get": {
+                "security": [
+                    {
+                        "None": []
+                    }
+                ],
+                "description": "Get filters list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "GetFiltersHandler",
+                "responses": {
+                    "200": {
+                        "description": "filters list",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/interests": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API to get interest list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Get Interests_category List",
+                "responses": {
+                    "200": {
+                        "description": "Fetched interests list",
+                        "schema": {
+                            "$ref": "#/definitions/model.InterestsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/nudges": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API to get nudges list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Get Nudges List",
+                "responses": {
+                    "200": {
+                        "description": "Fetched nudges list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Nudge"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/searchProfile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API to get user search profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "UserSearchProfile",
+                "responses": {
+                    "200": {
+                        "description": "successfully received profiles.",
+                        "schema": {
+                            "$ref": "#/definitions/elasticsearchPkg.UserProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the recovery codes of the account. The old codes stop working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Regenerate recovery codes",
+                "parameters": [
+                    {
+                        "description": "authenticator or recovery code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TOTPCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecoveryCodes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/totp": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Disable two factor authentication with a code of the authenticator app or a recovery code. Staff accounts cannot disable it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Disable two factor authentication",
+                "parameters": [
+                    {
+                        "description": "authenticator or recovery code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TOTPCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "two factor authentication disabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/totp/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Enable two factor authentication with a first code of the authenticator app. The recovery codes are only shown in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Confirm authenticator app",
+                "parameters": [
+                    {
+                        "description": "authenticator code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TOTPCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecoveryCodes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/totp/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a secret for an authenticator app. Show provisioning_uri as a QR code, then confirm with a first code.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Enroll authenticator app",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TOTPEnrollment"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/verify": {
+            "post": {
+                "description": "Complete a sign in that responded with mfa_required, using its mfa_token and a code of the authenticator app or a recovery code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify two factor authentication",
+                "parameters": [
+                    {
+                        "description": "mfa token and code",
+                        "name": "verifyMFA",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerifyMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/account/deletion": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the scheduled deletion of the account and when it will be erased",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get account deletion",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountDeletion"
+                        }
+                    },
+                    "404": {
+                        "description": "no account deletion found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Schedule the account and all of its data to be erased after the grace period and sign it out everywhere. Sign in again to restore it before then. The session must have signed in within the last 10 minutes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Delete account",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountDeletion"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel the scheduled deletion of the account during its grace period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Restore account",
+                "responses": {
+                    "200": {
+                        "description": "account restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "no account deletion found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/account/pause": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hide the user from search results, the likes of other users and stories by location. Without until the account stays paused until it is resumed. Matches and chats are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Pause account",
+                "parameters": [
+                    {
+                        "description": "when to resume",
+                        "name": "pauseAccount",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PauseAccount"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserSearchProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Show a paused user again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Resume account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserSearchProfile"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/advancedFilter": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API to update the advanced filter for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "updateAdvancedFilter",
+                "parameters": [
+                    {
+                        "description": "advancedFilter",
+                        "name": "advancedFilter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdvancedFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "advanced filters updated.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdvancedFilter"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/apple/login": {
+            "post": {
+                "description": "Sign in with Apple API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "AppleLoginHandler",
+                "parameters": [
+                    {
+                        "description": "Apple identity token",
+                        "name": "appleLogin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AppleLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
             }
         },
-        "/filters": {
+        "/user/blocks": {
             "get": {
                 "security": [
                     {
-                        "None": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get filters list",
+                "description": "List the users blocked by the user, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Blocked users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserBlock"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Block a user. Any match with them is removed, neither can message the other and they no longer see each other in search results, likes or stories.",
                 "consumes": [
                     "application/json"
                 ],
@@ -479,14 +1586,260 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "user"
+                ],
+                "summary": "Block user",
+                "parameters": [
+                    {
+                        "description": "user to block",
+                        "name": "blockUser",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BlockUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "user blocked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/blocks/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lift a block. A match removed by the block is not restored.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Unblock user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "blocked user id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "user unblocked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid user id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "user is not blocked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/data-exports": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Request an archive of all data held about the user, built in the background. Poll the export until it is ready to get its download link. While an export is being built it is returned instead of requesting another one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Export my data",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DataExport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/data-exports/{export_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the status of a data export. Once ready it has a download_url valid for 15 minutes, until the archive expires.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get data export",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "export id",
+                        "name": "export_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DataExport"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid export id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "no data export found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/event": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update event for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "UpdateUserEventHandler",
+                "parameters": [
+                    {
+                        "description": "Event Data",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateEventDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateEventDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create an event for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "CreateUserEventsHandler",
+                "parameters": [
+                    {
+                        "description": "Event Data",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateEventDTO"
+                        }
+                    }
                 ],
-                "summary": "GetFiltersHandler",
                 "responses": {
                     "200": {
-                        "description": "filters list",
+                        "description": "event created successfully.",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.EventResponseDTO"
                         }
                     },
                     "400": {
@@ -502,16 +1855,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/interests": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API to get interest list",
+                "description": "Delete event for a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -519,33 +1870,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Events"
                 ],
-                "summary": "Get Interests_category List",
+                "summary": "DeleteUserEventHandler",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
+                        "description": "Event Data",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateEventDTO"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "event_id",
                         "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Fetched interests list",
+                        "description": "event deleted successfully.",
                         "schema": {
-                            "$ref": "#/definitions/model.InterestsListResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "internal server error",
                         "schema": {
                             "type": "string"
                         }
@@ -553,14 +1913,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/nudges": {
+        "/user/events": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API to get nudges list",
+                "description": "Get all events for a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -568,24 +1928,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Events"
                 ],
-                "summary": "Get Nudges List",
+                "summary": "GetUserEventsHandler",
                 "responses": {
                     "200": {
-                        "description": "Fetched nudges list",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Nudge"
+                            "$ref": "#/definitions/dto.EventResponseDTO"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "internal server error",
                         "schema": {
                             "type": "string"
                         }
@@ -593,35 +1953,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/searchProfile": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
+        "/user/google/login": {
+            "post": {
+                "description": "google login API",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "API to get user search profile",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Authentication"
                 ],
-                "summary": "UserSearchProfile",
+                "summary": "GoogleLoginHandler",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
+                        "description": "ID token",
+                        "name": "idToken",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserIDToken"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "successfully received profiles.",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/elasticsearchPkg.UserProfile"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -639,53 +1999,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/advancedFilter": {
-            "post": {
+        "/user/identities": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API to update the advanced filter for a user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the sign in methods linked to the account",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
-                ],
-                "summary": "updateAdvancedFilter",
-                "parameters": [
-                    {
-                        "description": "advancedFilter",
-                        "name": "advancedFilter",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.AdvancedFilter"
-                        }
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
-                    }
+                    "Authentication"
                 ],
+                "summary": "Get sign in methods",
                 "responses": {
                     "200": {
-                        "description": "advanced filters updated.",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AdvancedFilter"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Identity"
+                            }
                         }
                     },
                     "500": {
@@ -697,9 +2033,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/delete": {
+        "/user/identities/apple": {
             "post": {
-                "description": "API for deleting user by updating the email. Just for testing purpose.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Link an Apple ID as sign in method. The session must have signed in within the last 10 minutes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -707,32 +2048,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "For Testing Purpose Only"
+                    "Authentication"
                 ],
-                "summary": "Delete user handler",
+                "summary": "Link Apple",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "new email which will replace the old email",
-                        "name": "email",
+                        "description": "Apple identity token",
+                        "name": "appleLogin",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserEmail"
+                            "$ref": "#/definitions/dto.AppleLoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "user deleted successfully.",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.Identity"
                         }
                     },
                     "400": {
@@ -741,6 +2075,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -750,14 +2096,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/event": {
-            "put": {
+        "/user/identities/google": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update event for a user",
+                "description": "Link a Google account as sign in method. The session must have signed in within the last 10 minutes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -765,55 +2111,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Authentication"
                 ],
-                "summary": "UpdateUserEventHandler",
+                "summary": "Link Google",
                 "parameters": [
                     {
-                        "description": "Event Data",
-                        "name": "event",
+                        "description": "ID token",
+                        "name": "idToken",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateEventDTO"
+                            "$ref": "#/definitions/dto.UserIDToken"
                         }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateEventDTO"
+                            "$ref": "#/definitions/dto.Identity"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/user/identities/password": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create an event for a user",
+                "description": "Add an email and password sign in method. The session must have signed in within the last 10 minutes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -821,105 +2174,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Authentication"
                 ],
-                "summary": "CreateUserEventsHandler",
+                "summary": "Link email and password",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Event Data",
-                        "name": "event",
+                        "description": "email and password",
+                        "name": "linkPassword",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateEventDTO"
+                            "$ref": "#/definitions/dto.LinkPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "event created successfully.",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.EventResponseDTO"
+                            "$ref": "#/definitions/dto.Identity"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "internal server error",
+                        "description": "Bad request",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Delete event for a user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Events"
-                ],
-                "summary": "DeleteUserEventHandler",
-                "parameters": [
-                    {
-                        "description": "Event Data",
-                        "name": "event",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateEventDTO"
-                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Event ID",
-                        "name": "event_id",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "event deleted successfully.",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -927,14 +2222,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/events": {
-            "get": {
+        "/user/identities/phone": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all events for a user",
+                "description": "Link a phone number as sign in method with an OTP sent by /user/send/otp. The session must have signed in within the last 10 minutes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -942,33 +2237,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Authentication"
                 ],
-                "summary": "GetUserEventsHandler",
+                "summary": "Link phone number",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
+                        "description": "phone number and OTP",
+                        "name": "linkPhone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LinkPhoneRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.EventResponseDTO"
+                            "$ref": "#/definitions/dto.Identity"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -976,33 +2291,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/google/login": {
-            "post": {
-                "description": "google login API",
-                "consumes": [
-                    "application/json"
+        "/user/identities/{identity_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Remove a sign in method from the account. The last one cannot be removed. The session must have signed in within the last 10 minutes.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Authentication"
                 ],
-                "summary": "GoogleLoginHandler",
+                "summary": "Unlink sign in method",
                 "parameters": [
                     {
-                        "description": "ID token",
-                        "name": "idToken",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserIDToken"
-                        }
+                        "type": "integer",
+                        "description": "identity id",
+                        "name": "identity_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "sign in method unlinked successfully",
                         "schema": {
                             "type": "string"
                         }
@@ -1013,6 +2328,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "sign in method not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1040,15 +2367,6 @@ const docTemplate = `{
                     "Profile"
                 ],
                 "summary": "Get User Interests_category List",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
                 "responses": {
                     "200": {
                         "description": "Fetched user interests list",
@@ -1096,13 +2414,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.InterestData"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1152,13 +2463,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.InterestsCategory"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1198,15 +2502,6 @@ const docTemplate = `{
                     "user"
                 ],
                 "summary": "Get user likes",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user_",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
                 "responses": {
                     "200": {
                         "description": "successfully received user likes",
@@ -1285,7 +2580,7 @@ const docTemplate = `{
         },
         "/user/login": {
             "post": {
-                "description": "User Username and Password login API",
+                "description": "User email and password login API. Too many failed attempts lock the account for a while.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1320,6 +2615,80 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Log out the current session and unregister its device push token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "logged out successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/logout/all": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Log out every session of the user and unregister all device push tokens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout everywhere",
+                "responses": {
+                    "200": {
+                        "description": "logged out of all sessions successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1344,15 +2713,6 @@ const docTemplate = `{
                     "user"
                 ],
                 "summary": "GetUserMatch",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user_",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
                 "responses": {
                     "200": {
                         "description": "successfully received user match",
@@ -1378,6 +2738,122 @@ const docTemplate = `{
                 }
             }
         },
+        "/user/matches/{match_id}/extend": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Give a match nobody has messaged yet more time before it expires. A match can be extended once. Premium only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Extend match",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "matched user id",
+                        "name": "match_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "match extended",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid match id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "match not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/matches/{match_id}/first-move": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Let either user of a match send the first message. Only the user who has the first move can, before anyone has messaged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Open first move",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "matched user id",
+                        "name": "match_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "either user can send the first message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid match id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/user/nudge": {
             "put": {
                 "security": [
@@ -1413,13 +2889,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.NudgeRequest"
                         }
                     },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "nudge_id",
@@ -1474,14 +2943,7 @@ const docTemplate = `{
                         "required": true,
                         "schema": {
                             "$ref": "#/definitions/model.NudgeDetail"
-                        }
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
+                        }
                     }
                 ],
                 "responses": {
@@ -1587,13 +3049,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.NudgeRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1636,20 +3091,103 @@ const docTemplate = `{
                     "Profile"
                 ],
                 "summary": "Get User nudges List",
+                "responses": {
+                    "200": {
+                        "description": "Fetched user nudges list",
+                        "schema": {
+                            "$ref": "#/definitions/model.NudgeDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password/forgot": {
+            "post": {
+                "description": "API for mailing a password reset link. It responds the same whether or not the email belongs to an account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Forgot Password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
+                        "description": "email",
+                        "name": "forgotPassword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Fetched user nudges list",
+                        "description": "password reset email sent",
                         "schema": {
-                            "$ref": "#/definitions/model.NudgeDetail"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password/reset": {
+            "post": {
+                "description": "API for setting a new password with the token from a password reset email. All sessions of the account are signed out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset Password",
+                "parameters": [
+                    {
+                        "description": "reset token and new password",
+                        "name": "resetPassword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "password reset",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1682,15 +3220,6 @@ const docTemplate = `{
                     "Profile"
                 ],
                 "summary": "getUserProfile",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
                 "responses": {
                     "200": {
                         "description": "successfully received profile.",
@@ -1701,10 +3230,6 @@ const docTemplate = `{
                             "token": {
                                 "type": "string",
                                 "description": "token"
-                            },
-                            "userID": {
-                                "type": "string",
-                                "description": "user_id"
                             }
                         }
                     },
@@ -1717,10 +3242,6 @@ const docTemplate = `{
                             "token": {
                                 "type": "string",
                                 "description": "token"
-                            },
-                            "userID": {
-                                "type": "string",
-                                "description": "user_id"
                             }
                         }
                     },
@@ -1733,10 +3254,6 @@ const docTemplate = `{
                             "token": {
                                 "type": "string",
                                 "description": "token"
-                            },
-                            "userID": {
-                                "type": "string",
-                                "description": "user_id"
                             }
                         }
                     }
@@ -1768,13 +3285,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.UserProfile"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1814,15 +3324,6 @@ const docTemplate = `{
                     "Media"
                 ],
                 "summary": "GetUserProfileMedia",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
                 "responses": {
                     "200": {
                         "description": "successfully received profile media.",
@@ -1868,13 +3369,6 @@ const docTemplate = `{
                         "name": "images",
                         "in": "formData",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1922,13 +3416,6 @@ const docTemplate = `{
                         "name": "mediaId",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1955,7 +3442,7 @@ const docTemplate = `{
         },
         "/user/register": {
             "post": {
-                "description": "User register API",
+                "description": "User register API. Passwords need 8 to 72 characters with at least one letter and one digit.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1978,7 +3465,7 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "201": {
                         "description": "user created.",
                         "schema": {
                             "type": "string"
@@ -1990,6 +3477,69 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "409": {
+                        "description": "user already exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/reports": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Report a profile, photo, nudge answer, chat message, story or event of another user for moderation. Reasons are spam, inappropriate, harassment, fake_profile, underage, scam and other. Reporting the same content again while the report is open returns the open report.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Report content",
+                "parameters": [
+                    {
+                        "description": "report",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "reported content not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2014,29 +3564,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
-                ],
-                "summary": "getUserSearchProfile",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
-                    }
+                    "Profile"
+                ],
+                "summary": "getUserSearchProfile",
+                "responses": {
+                    "200": {
+                        "description": "user Search Profile found.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/send/otp": {
+            "post": {
+                "description": "API for sending verification sms to user with OTP",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Send OTP",
+                "parameters": [
+                    {
+                        "description": "userData",
+                        "name": "userData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OTP sent successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the active sessions of the user with their device and last used time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
                 ],
+                "summary": "Get user sessions",
                 "responses": {
                     "200": {
-                        "description": "user Search Profile found.",
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserProfile"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Session"
+                            }
                         }
                     },
                     "500": {
@@ -2048,39 +3675,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/send/otp": {
-            "post": {
-                "description": "API for sending verification sms to user with OTP",
-                "consumes": [
-                    "application/json"
+        "/user/sessions/{session_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Log out one session of the user and unregister its device push token",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Authentication"
                 ],
-                "summary": "Send OTP",
+                "summary": "Revoke user session",
                 "parameters": [
                     {
-                        "description": "userData",
-                        "name": "userData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserData"
-                        }
+                        "type": "string",
+                        "description": "session id",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP sent successfully",
+                        "description": "session revoked successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "404": {
+                        "description": "session not found",
                         "schema": {
                             "type": "string"
                         }
@@ -2133,20 +3760,6 @@ const docTemplate = `{
                         "type": "string",
                         "name": "text",
                         "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "User Profile ID",
-                        "name": "user_profile_id",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -2278,7 +3891,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "swipe",
+                "description": "Like (type 1), super like (type 2) or dislike (any other type) a user. Likes and super likes count against daily quotas that depend on the plan and reset at midnight in the time zone of the user. The response has the likes left today and when they reset, unless likes are unlimited.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2298,21 +3911,121 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.UserLikeDTO"
                         }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "user is blocked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "daily limit reached",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/swipe/undo": {
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "user_id",
-                        "in": "header",
-                        "required": true
+                        "ApiKeyAuth": []
                     }
                 ],
+                "description": "Undo the last swipe of the user within a few minutes of it, unless it made a match. Premium only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Undo swipe",
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "the swipe that was undone",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserLikeDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "no swipe to undo",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            }
+        },
+        "/user/token/refresh": {
+            "post": {
+                "description": "API for exchanging a refresh token for a new access and refresh token pair. Every refresh token can be used once; reusing one revokes all tokens issued from the same login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh Token",
+                "parameters": [
+                    {
+                        "description": "refresh token",
+                        "name": "refreshToken",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthToken"
+                        }
                     },
                     "400": {
                         "description": "Bad request",
@@ -2320,6 +4033,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2356,13 +4075,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.MediaOrderId"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -2414,13 +4126,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.UserSearchProfile"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -2463,15 +4168,6 @@ const docTemplate = `{
                     "Profile"
                 ],
                 "summary": "PremiumUpgrade",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user_id",
-                        "name": "userID",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
                 "responses": {
                     "200": {
                         "description": "successfully received profile.",
@@ -2616,6 +4312,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2627,6 +4329,40 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "dto.AccountDeletion": {
+            "type": "object",
+            "properties": {
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "purge_after": {
+                    "type": "string"
+                },
+                "receipt": {
+                    "description": "Receipt lists what was erased once the deletion is completed.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ErasureReceipt"
+                        }
+                    ]
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.AdvancedFilter": {
             "type": "object",
             "properties": {
@@ -2713,16 +4449,109 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.CreateEventDTO": {
+        "dto.AppleLoginRequest": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "description": "Apple only shares the user's name with the app on the first sign in.",
+                    "type": "string"
+                },
+                "identity_token": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "description": "Nonce is the raw nonce whose sha256 hash was sent in the Apple authorization request.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuthToken": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BlockUser": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateEventDTO": {
+            "type": "object",
+            "properties": {
+                "attendee": {
+                    "type": "string"
+                },
+                "date_time": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "$ref": "#/definitions/dto.Location"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateReport": {
             "type": "object",
+            "required": [
+                "content_id",
+                "content_type",
+                "reason"
+            ],
             "properties": {
-                "attendee": {
+                "content_id": {
                     "type": "string"
                 },
-                "date_time": {
+                "content_type": {
                     "type": "string"
                 },
-                "description": {
+                "details": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DataExport": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "download_url": {
                     "type": "string"
                 },
                 "expires_at": {
@@ -2731,10 +4560,10 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "location": {
-                    "$ref": "#/definitions/dto.Location"
+                "requested_at": {
+                    "type": "string"
                 },
-                "type": {
+                "status": {
                     "type": "string"
                 }
             }
@@ -2785,6 +4614,99 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Identity": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "linked_at": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JWK"
+                    }
+                }
+            }
+        },
+        "dto.LinkPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LinkPhoneRequest": {
+            "type": "object",
+            "properties": {
+                "otp": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.Location": {
             "type": "object",
             "properties": {
@@ -2825,11 +4747,175 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PauseAccount": {
+            "type": "object",
+            "properties": {
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Report": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "content_id": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "moderator_id": {
+                    "type": "integer"
+                },
+                "moderator_note": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reported_at": {
+                    "type": "string"
+                },
+                "reported_id": {
+                    "type": "integer"
+                },
+                "reporter_id": {
+                    "type": "integer"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReportReview": {
+            "type": "object",
+            "properties": {
+                "content": {},
+                "context": {},
+                "open_reports": {
+                    "description": "OpenReports is the number of open reports about the reported user.",
+                    "type": "integer"
+                },
+                "report": {
+                    "$ref": "#/definitions/dto.Report"
+                },
+                "reported_user": {}
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ResolveReport": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "dto.Session": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "boolean"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TOTPCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is a code of the authenticator app or, where accepted, a recovery code.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TOTPEnrollment": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.UserData": {
             "type": "object",
             "properties": {
                 "phoneNumber": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "+919876543210"
                 }
             }
         },
@@ -2859,6 +4945,7 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "type": {
+                    "description": "Type is 1 for a like, 2 for a super like and anything else for a dislike.",
                     "type": "integer"
                 }
             }
@@ -2878,6 +4965,14 @@ const docTemplate = `{
                 "deleted_at": {
                     "type": "string"
                 },
+                "expires_at": {
+                    "description": "ExpiresAt is when the match goes away unless someone messages first, nil once they have.",
+                    "type": "string"
+                },
+                "extended": {
+                    "description": "Extended tells whether a premium user already extended the match, which can be done once.",
+                    "type": "boolean"
+                },
                 "match_id": {
                     "type": "integer"
                 },
@@ -2887,6 +4982,15 @@ const docTemplate = `{
                 "order_id": {
                     "type": "integer"
                 },
+                "turn": {
+                    "description": "Turn tells who can send the first message: you, them or anyone. It is empty once the conversation has started.",
+                    "type": "string",
+                    "enum": [
+                        "you",
+                        "them",
+                        "anyone"
+                    ]
+                },
                 "url": {
                     "type": "string"
                 },
@@ -2961,6 +5065,10 @@ const docTemplate = `{
                 "smoke": {
                     "$ref": "#/definitions/elasticsearchPkg.Smoke"
                 },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Asia/Kolkata"
+                },
                 "user_id": {
                     "type": "integer"
                 },
@@ -3025,11 +5133,26 @@ const docTemplate = `{
                 "min_age": {
                     "type": "integer"
                 },
+                "paused": {
+                    "description": "Paused is read only, change it through /user/account/pause.",
+                    "type": "boolean"
+                },
                 "snooze": {
                     "type": "string"
                 }
             }
         },
+        "dto.VerifyMFARequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.VerifyUser": {
             "type": "object",
             "properties": {
@@ -3465,6 +5588,9 @@ const docTemplate = `{
                 "min_age": {
                     "type": "integer"
                 },
+                "paused": {
+                    "type": "boolean"
+                },
                 "snooze": {
                     "type": "string"
                 }
@@ -3513,6 +5639,44 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ErasureReceipt": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "deletion_id": {
+                    "type": "integer"
+                },
+                "redis_keys": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "description": "Rows is the number of rows deleted per MySQL table.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "s3_objects": {
+                    "description": "S3Objects is the number of objects deleted per bucket.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "search_documents": {
+                    "description": "SearchDocuments is the number of documents deleted per search index.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.InterestData": {
             "type": "object",
             "properties": {
@@ -3782,12 +5946,26 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UserBlock": {
+            "type": "object",
+            "properties": {
+                "blocked_at": {
+                    "type": "string"
+                },
+                "blocked_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.UserLikers": {
             "type": "object",
             "properties": {
                 "image": {
                     "type": "string"
                 },
+                "super_like": {
+                    "type": "boolean"
+                },
                 "user_id": {
                     "type": "integer"
                 }
@@ -8,6 +8,9 @@
       "id": {
         "type": "integer"
       },
+      "user_id": {
+        "type": "integer"
+      },
       "first_name": {
         "type": "text"
       },
@@ -57,6 +60,16 @@
       },
       "looking_for": {
         "type": "keyword"
+      },
+      "userSearchProfile": {
+        "properties": {
+          "paused": {
+            "type": "boolean"
+          },
+          "snooze": {
+            "type": "date"
+          }
+        }
       }
     }
   }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"github.com/SuperMatch/zapLogger"
 	"log"
 	"time"
@@ -14,7 +15,10 @@ import (
 	"github.com/SuperMatch/env"
 	pkgdb "github.com/SuperMatch/pkg/db"
 	"github.com/SuperMatch/pkg/elasticSeach"
+	"github.com/SuperMatch/pkg/mail"
+	"github.com/SuperMatch/pkg/sms"
 	"github.com/SuperMatch/server"
+	"github.com/SuperMatch/service"
 	"go.uber.org/zap"
 )
 
@@ -34,6 +38,9 @@ func main() {
 	//cmd := flag.String("env", "dev", "")
 	//flag.Parse()
 	//appEnv := *cmd
+	backfillSwipes := flag.Bool("backfill-swipes", false, "copy the swipes cached in redis into the user_swipes table and exit")
+	flag.Parse()
+
 	appEnv, err := env.GetAppEnv()
 
 	if err != nil {
@@ -80,6 +87,37 @@ func main() {
 		logger.Log(zap.InfoLevel, "redis connection successful !")
 	}
 
+	if *backfillSwipes {
+		added, err := service.NewSwipeService().BackfillSwipeLedger()
+		if err != nil {
+			logger.Fatal("error in backfilling swipes", zap.Error(err))
+		}
+		logger.Info("backfilled swipes", zap.Int64("added", added))
+		return
+	}
+
+	err = sms.CreateSMSSender(config)
+
+	if err != nil {
+		logger.Fatal("error in creating sms sender", zap.Error(err))
+	}
+
+	err = mail.CreateMailer(config)
+
+	if err != nil {
+		logger.Fatal("error in creating mailer", zap.Error(err))
+	}
+
+	err = service.LoadSigningKeys(config.JWTConfig)
+
+	if err != nil {
+		logger.Fatal("error in loading jwt signing keys", zap.Error(err))
+	}
+
+	service.StartAccountDeletionWorker()
+	service.StartDataExportWorker()
+	service.StartMatchExpiryWorker()
+
 	if config.Env == "staging" || config.Env == "prod" {
 		//create sentry client
 		err = sentry.Init(sentry.ClientOptions{
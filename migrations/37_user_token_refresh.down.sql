@@ -0,0 +1,6 @@
+DROP INDEX user_token_family_id ON user_token;
+DROP INDEX user_token_refresh_token ON user_token;
+
+ALTER TABLE user_token DROP COLUMN family_id;
+ALTER TABLE user_token DROP COLUMN refresh_expires_at;
+ALTER TABLE user_token DROP COLUMN refresh_token;
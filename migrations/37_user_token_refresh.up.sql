@@ -0,0 +1,8 @@
+ALTER TABLE user_token ADD COLUMN refresh_token VARCHAR(64) DEFAULT NULL;
+ALTER TABLE user_token ADD COLUMN refresh_expires_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE user_token ADD COLUMN family_id VARCHAR(36) DEFAULT NULL;
+
+CREATE INDEX user_token_refresh_token ON user_token (refresh_token);
+CREATE INDEX user_token_family_id ON user_token (family_id,is_active);
+
+UPDATE user_token SET is_active = false WHERE is_active = true;
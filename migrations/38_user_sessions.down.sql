@@ -0,0 +1,6 @@
+DROP INDEX user_device_tokens_session_id ON user_device_tokens;
+ALTER TABLE user_device_tokens DROP COLUMN session_id;
+
+ALTER TABLE user_token DROP COLUMN last_used_at;
+ALTER TABLE user_token DROP COLUMN ip_address;
+ALTER TABLE user_token DROP COLUMN user_agent;
@@ -0,0 +1,6 @@
+ALTER TABLE user_token ADD COLUMN user_agent VARCHAR(255) DEFAULT NULL;
+ALTER TABLE user_token ADD COLUMN ip_address VARCHAR(45) DEFAULT NULL;
+ALTER TABLE user_token ADD COLUMN last_used_at TIMESTAMP NULL DEFAULT NULL;
+
+ALTER TABLE user_device_tokens ADD COLUMN session_id VARCHAR(36) DEFAULT NULL;
+CREATE INDEX user_device_tokens_session_id ON user_device_tokens (user_id,session_id);
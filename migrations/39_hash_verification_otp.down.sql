@@ -0,0 +1,5 @@
+DROP INDEX idx_phone_number_consumed_at ON user_verification_otp;
+ALTER TABLE user_verification_otp DROP COLUMN consumed_at;
+DELETE FROM user_verification_otp;
+ALTER TABLE user_verification_otp MODIFY COLUMN otp INT NOT NULL;
+CREATE INDEX idx_phone_number_otp ON user_verification_otp (phone_number,otp);
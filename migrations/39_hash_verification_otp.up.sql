@@ -0,0 +1,6 @@
+ALTER TABLE user_verification_otp DROP INDEX idx_phone_number_otp;
+ALTER TABLE user_verification_otp MODIFY COLUMN otp VARCHAR(60) NOT NULL;
+ALTER TABLE user_verification_otp ADD COLUMN consumed_at TIMESTAMP NULL DEFAULT NULL;
+CREATE INDEX idx_phone_number_consumed_at ON user_verification_otp (phone_number,consumed_at);
+
+UPDATE user_verification_otp SET consumed_at = CURRENT_TIMESTAMP WHERE consumed_at IS NULL;
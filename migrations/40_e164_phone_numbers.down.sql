@@ -0,0 +1,4 @@
+UPDATE users SET mobile = SUBSTRING(mobile, 4) WHERE sign_up_type = 'otp' AND mobile LIKE '+91%';
+
+ALTER TABLE user_verification_otp MODIFY COLUMN phone_number VARCHAR(13) NOT NULL;
+ALTER TABLE users MODIFY COLUMN mobile VARCHAR(10) NOT NULL;
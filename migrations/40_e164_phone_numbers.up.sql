@@ -0,0 +1,5 @@
+ALTER TABLE users MODIFY COLUMN mobile VARCHAR(16) NOT NULL;
+ALTER TABLE user_verification_otp MODIFY COLUMN phone_number VARCHAR(16) NOT NULL;
+
+-- otp sign ups were only possible with indian numbers, which were stored without the country code
+UPDATE users SET mobile = CONCAT('+91', mobile) WHERE sign_up_type = 'otp' AND mobile <> '' AND mobile NOT LIKE '+%';
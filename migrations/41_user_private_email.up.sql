@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN is_private_email BOOLEAN DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE user_token DROP COLUMN authenticated_at;
+DROP TABLE IF EXISTS user_identity;
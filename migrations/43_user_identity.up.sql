@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS user_identity (
+    ID INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    provider VARCHAR(16) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NULL DEFAULT NULL,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    FOREIGN KEY (user_id) REFERENCES users(ID),
+    UNIQUE KEY user_identity_provider_subject (provider, subject),
+    INDEX user_identity_user_id (user_id)
+);
+
+-- every existing account keeps the sign in method it signed up with. google and apple accounts
+-- did not store the provider's user id, so their email stands in as subject until the next login
+INSERT IGNORE INTO user_identity (user_id, provider, subject, email)
+SELECT ID, 'phone', mobile, NULL FROM users WHERE sign_up_type = 'otp' AND mobile <> '' AND is_active = TRUE;
+
+INSERT IGNORE INTO user_identity (user_id, provider, subject, email)
+SELECT ID, 'google', email, email FROM users WHERE sign_up_type = 'social' AND email <> '' AND is_active = TRUE;
+
+INSERT IGNORE INTO user_identity (user_id, provider, subject, email)
+SELECT ID, 'apple', email, email FROM users WHERE sign_up_type = 'apple' AND email <> '' AND is_active = TRUE;
+
+INSERT IGNORE INTO user_identity (user_id, provider, subject, email)
+SELECT ID, 'password', LOWER(email), LOWER(email) FROM users WHERE sign_up_type = 'password' AND email <> '' AND is_active = TRUE;
+
+ALTER TABLE user_token ADD COLUMN authenticated_at TIMESTAMP NULL DEFAULT NULL;
+UPDATE user_token SET authenticated_at = created_at;
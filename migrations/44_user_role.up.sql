@@ -0,0 +1,17 @@
+ALTER TABLE users ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'user';
+
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    ID INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    method VARCHAR(8) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    status INT NOT NULL,
+    ip_address VARCHAR(45) NULL DEFAULT NULL,
+    user_agent VARCHAR(255) NULL DEFAULT NULL,
+    request_body TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    FOREIGN KEY (user_id) REFERENCES users(ID),
+    INDEX admin_audit_log_user_id (user_id, created_at)
+);
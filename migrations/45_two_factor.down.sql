@@ -0,0 +1,3 @@
+ALTER TABLE user_token DROP COLUMN mfa_verified;
+DROP TABLE IF EXISTS user_recovery_code;
+DROP TABLE IF EXISTS user_totp;
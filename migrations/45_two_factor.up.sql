@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS user_totp (
+    ID INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL UNIQUE,
+    secret VARCHAR(255) NOT NULL,
+    confirmed_at TIMESTAMP NULL DEFAULT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    FOREIGN KEY (user_id) REFERENCES users(ID)
+);
+
+CREATE TABLE IF NOT EXISTS user_recovery_code (
+    ID INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    FOREIGN KEY (user_id) REFERENCES users(ID),
+    INDEX user_recovery_code_user_id (user_id, code_hash)
+);
+
+ALTER TABLE user_token ADD COLUMN mfa_verified BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS account_deletion (
+    ID INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    purge_after TIMESTAMP NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    receipt TEXT,
+    cancelled_at TIMESTAMP NULL DEFAULT NULL,
+    completed_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    INDEX account_deletion_user_id (user_id),
+    INDEX account_deletion_status_purge_after (status, purge_after)
+);
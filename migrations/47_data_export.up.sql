@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS data_export (
+    ID INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    s3_key VARCHAR(255) DEFAULT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    completed_at TIMESTAMP NULL DEFAULT NULL,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    INDEX data_export_user_id (user_id),
+    INDEX data_export_status (status)
+);
@@ -0,0 +1 @@
+ALTER TABLE user_search_profile DROP COLUMN paused;
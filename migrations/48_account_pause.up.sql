@@ -0,0 +1 @@
+ALTER TABLE user_search_profile ADD COLUMN paused BOOLEAN NOT NULL DEFAULT FALSE;
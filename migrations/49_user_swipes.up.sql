@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS user_swipes (
+    ID INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    target_id INT NOT NULL,
+    swipe_type TINYINT NOT NULL,
+    swiped_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    UNIQUE INDEX user_swipes_user_id_target_id (user_id, target_id),
+    INDEX user_swipes_target_id_swipe_type (target_id, swipe_type)
+);
@@ -0,0 +1 @@
+ALTER TABLE user_match DROP INDEX user_match_user_id_match_id_unique;
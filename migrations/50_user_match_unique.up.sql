@@ -0,0 +1,4 @@
+DELETE duplicate FROM user_match duplicate
+JOIN user_match original ON original.user_id = duplicate.user_id AND original.match_id = duplicate.match_id AND original.ID < duplicate.ID;
+
+ALTER TABLE user_match ADD UNIQUE INDEX user_match_user_id_match_id_unique (user_id, match_id);
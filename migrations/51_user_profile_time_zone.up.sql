@@ -0,0 +1 @@
+ALTER TABLE user_profile ADD COLUMN time_zone VARCHAR(64) NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_blocks (
+    ID INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    blocked_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    UNIQUE INDEX user_blocks_user_id_blocked_id (user_id, blocked_id),
+    INDEX user_blocks_blocked_id (blocked_id)
+);
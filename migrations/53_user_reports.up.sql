@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS user_reports (
+    ID INT NOT NULL AUTO_INCREMENT,
+    reporter_id INT NOT NULL,
+    reported_id INT NOT NULL,
+    content_type VARCHAR(16) NOT NULL,
+    content_id VARCHAR(64) NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    details TEXT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    action VARCHAR(32) NULL,
+    moderator_id INT NULL,
+    moderator_note TEXT NULL,
+    resolved_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    INDEX user_reports_status_created_at (status, created_at),
+    INDEX user_reports_content (content_type, content_id),
+    INDEX user_reports_reported_id (reported_id)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE user_match
+    DROP INDEX user_match_expires_at,
+    DROP COLUMN expires_at,
+    DROP COLUMN expiry_notified_at,
+    DROP COLUMN extended_by;
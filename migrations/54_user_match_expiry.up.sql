@@ -0,0 +1,5 @@
+ALTER TABLE user_match
+    ADD COLUMN expires_at TIMESTAMP NULL DEFAULT NULL,
+    ADD COLUMN expiry_notified_at TIMESTAMP NULL DEFAULT NULL,
+    ADD COLUMN extended_by INT NULL,
+    ADD INDEX user_match_expires_at (expires_at);
@@ -0,0 +1,3 @@
+ALTER TABLE user_match
+    DROP COLUMN first_move_by,
+    DROP COLUMN first_message_at;
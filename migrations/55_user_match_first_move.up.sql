@@ -0,0 +1,8 @@
+ALTER TABLE user_match
+    ADD COLUMN first_move_by INT NULL,
+    ADD COLUMN first_message_at TIMESTAMP NULL DEFAULT NULL;
+
+UPDATE user_match um SET um.first_message_at = (
+    SELECT MIN(uc.created_at) FROM user_chats uc
+    WHERE (uc.sender_id = um.user_id AND uc.receiver_id = um.match_id) OR (uc.sender_id = um.match_id AND uc.receiver_id = um.user_id)
+);
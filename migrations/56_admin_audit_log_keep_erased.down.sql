@@ -0,0 +1,3 @@
+-- rows of erased accounts carry a negative pseudonym and cannot reference users again
+DELETE FROM admin_audit_log WHERE user_id < 0;
+ALTER TABLE admin_audit_log ADD CONSTRAINT admin_audit_log_ibfk_1 FOREIGN KEY (user_id) REFERENCES users(ID);
@@ -0,0 +1,2 @@
+-- audit rows outlive the account they name, erased accounts are replaced by a pseudonym
+ALTER TABLE admin_audit_log DROP FOREIGN KEY admin_audit_log_ibfk_1;
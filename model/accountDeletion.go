@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Statuses of an account deletion. A scheduled deletion is processing while its data is being erased.
+const (
+	AccountDeletionScheduled  = "scheduled"
+	AccountDeletionProcessing = "processing"
+	AccountDeletionCancelled  = "cancelled"
+	AccountDeletionCompleted  = "completed"
+)
+
+func (AccountDeletion) TableName() string {
+	return "account_deletion"
+}
+
+// AccountDeletion is a request to erase an account. It outlives the account, so the completed row
+// with its receipt is the record that the data was erased.
+type AccountDeletion struct {
+	ID          uint       `gorm:"primarykey"`
+	UserId      int        `gorm:"column:user_id"`
+	Status      string     `gorm:"column:status"`
+	PurgeAfter  time.Time  `gorm:"column:purge_after"`
+	Attempts    int        `gorm:"column:attempts"`
+	LastError   string     `gorm:"column:last_error"`
+	Receipt     string     `gorm:"column:receipt"`
+	CancelledAt *time.Time `gorm:"column:cancelled_at"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+}
+
+// ErasureReceipt lists what was erased for an account deletion, per store.
+type ErasureReceipt struct {
+	DeletionID  uint      `json:"deletion_id"`
+	UserID      int       `json:"user_id"`
+	CompletedAt time.Time `json:"completed_at"`
+	// Rows is the number of rows deleted per MySQL table.
+	Rows map[string]int64 `json:"rows"`
+	// SearchDocuments is the number of documents deleted per search index.
+	SearchDocuments map[string]int64 `json:"search_documents"`
+	// S3Objects is the number of objects deleted per bucket.
+	S3Objects map[string]int64 `json:"s3_objects"`
+	RedisKeys int64            `json:"redis_keys"`
+}
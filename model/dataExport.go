@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Statuses of a data export. A ready export can be downloaded until it expires.
+const (
+	DataExportPending    = "pending"
+	DataExportProcessing = "processing"
+	DataExportReady      = "ready"
+	DataExportFailed     = "failed"
+	DataExportExpired    = "expired"
+)
+
+func (DataExport) TableName() string {
+	return "data_export"
+}
+
+// DataExport is a request of a user for an archive of all of their data.
+type DataExport struct {
+	ID          uint       `gorm:"primarykey"`
+	UserId      int        `gorm:"column:user_id"`
+	Status      string     `gorm:"column:status"`
+	S3Key       string     `gorm:"column:s3_key"`
+	Attempts    int        `gorm:"column:attempts"`
+	LastError   string     `gorm:"column:last_error"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+}
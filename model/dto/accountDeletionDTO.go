@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/SuperMatch/model"
+)
+
+// AccountDeletion is a scheduled account deletion. The account can be restored until PurgeAfter.
+type AccountDeletion struct {
+	ID          uint       `json:"id"`
+	UserID      int        `json:"user_id"`
+	Status      string     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	PurgeAfter  time.Time  `json:"purge_after"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// Receipt lists what was erased once the deletion is completed.
+	Receipt *model.ErasureReceipt `json:"receipt,omitempty"`
+}
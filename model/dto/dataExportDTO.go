@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// DataExport is a requested archive of the data of a user. DownloadURL is set while the export is
+// ready and is only valid for a few minutes, fetch the export again for a new one.
+type DataExport struct {
+	ID          uint       `json:"id"`
+	Status      string     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+}
@@ -1,6 +1,12 @@
 package dto
 
-import "github.com/golang-jwt/jwt"
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
 
 type UserRegistrationDTO struct {
 	ID        string `json:"id"`
@@ -12,13 +18,22 @@ type UserRegistrationDTO struct {
 	Password  string `json:"password"`
 }
 
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
 type Login struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
 
 type UserData struct {
-	PhoneNumber string `json:"phoneNumber,omitempty"`
+	PhoneNumber string `json:"phoneNumber,omitempty" example:"+919876543210"`
 }
 
 type VerifyUser struct {
@@ -41,6 +56,75 @@ type UserEmail struct {
 	Email string `json:"email"`
 }
 
+type AuthToken struct {
+	Token            string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// SessionDevice describes the client a session was opened from.
+type SessionDevice struct {
+	UserAgent string
+	IPAddress string
+}
+
+type Session struct {
+	SessionID        string     `json:"session_id"`
+	UserAgent        string     `json:"user_agent"`
+	IPAddress        string     `json:"ip_address"`
+	LastUsedAt       *time.Time `json:"last_used_at"`
+	RefreshExpiresAt time.Time  `json:"refresh_expires_at"`
+	Current          bool       `json:"current"`
+}
+
+type Identity struct {
+	ID          uint      `json:"id"`
+	Provider    string    `json:"provider"`
+	Email       string    `json:"email,omitempty"`
+	PhoneNumber string    `json:"phone_number,omitempty"`
+	LinkedAt    time.Time `json:"linked_at"`
+}
+
+type LinkPhoneRequest struct {
+	PhoneNumber string `json:"phone_number"`
+	OTP         string `json:"otp"`
+}
+
+type LinkPasswordRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type UpdateRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// TOTPEnrollment is shown to the user to add the account to an authenticator app, as a QR code of
+// ProvisioningURI or by typing in Secret.
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TOTPCodeRequest struct {
+	// Code is a code of the authenticator app or, where accepted, a recovery code.
+	Code string `json:"code"`
+}
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 type UserIDToken struct {
 	IDToken string `json:"id_token"`
 }
@@ -62,3 +146,42 @@ type TokenInfo struct {
 	Local         string `json:"locale"`
 	jwt.StandardClaims
 }
+
+type AppleLoginRequest struct {
+	IdentityToken string `json:"identity_token"`
+	// Nonce is the raw nonce whose sha256 hash was sent in the Apple authorization request.
+	Nonce string `json:"nonce,omitempty"`
+	// Apple only shares the user's name with the app on the first sign in.
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+}
+
+type AppleTokenInfo struct {
+	Email          string     `json:"email"`
+	EmailVerified  StringBool `json:"email_verified"`
+	IsPrivateEmail StringBool `json:"is_private_email"`
+	Nonce          string     `json:"nonce"`
+	jwt.StandardClaims
+}
+
+// StringBool decodes booleans that are sent either as JSON booleans or as "true"/"false" strings.
+type StringBool bool
+
+func (b *StringBool) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case bool:
+		*b = StringBool(v)
+	case string:
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		*b = StringBool(parsed)
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+package dto
+
+type BlockUser struct {
+	UserID int `json:"user_id" binding:"required"`
+}
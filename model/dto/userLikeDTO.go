@@ -1,7 +1,18 @@
 package dto
 
+import "time"
+
 type UserLikeDTO struct {
 	LikeeID int `json:"likeeID"`
 	LikerID int `json:"likerID"`
-	Type    int `json:"type"`
+	// Type is 1 for a like, 2 for a super like and anything else for a dislike.
+	Type int `json:"type"`
+}
+
+// SwipeResult is the outcome of a swipe. RemainingLikes and LikesResetAt are only set for likes
+// when the plan of the user limits them.
+type SwipeResult struct {
+	IsMatch        bool
+	RemainingLikes *int
+	LikesResetAt   *time.Time
 }
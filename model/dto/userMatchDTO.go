@@ -12,4 +12,10 @@ type UserMatchDTO struct {
 	ChatId    *string    `json:"chat_id"`
 	CreatedAt time.Time  `json:"created_at"`
 	DeletedAt *time.Time `json:"deleted_at"`
+	// ExpiresAt is when the match goes away unless someone messages first, nil once they have.
+	ExpiresAt *time.Time `json:"expires_at"`
+	// Extended tells whether a premium user already extended the match, which can be done once.
+	Extended bool `json:"extended"`
+	// Turn tells who can send the first message: you, them or anyone. It is empty once the conversation has started.
+	Turn string `json:"turn,omitempty" enums:"you,them,anyone"`
 }
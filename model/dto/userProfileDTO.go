@@ -30,6 +30,7 @@ type UserProfile struct {
 	Smoke             *elasticsearchPkg.Smoke             `json:"smoke,omitempty"`
 	About             *string                             `json:"about,omitempty"`
 	Pronoun           *string                             `json:"pronoun,omitempty"`
+	TimeZone          *string                             `json:"time_zone,omitempty" example:"Asia/Kolkata"`
 	//Images            []UserImages                       `json:"images,omitempty"`
 	//Nudges            []UserNudgeProfile                 `json:"questions,omitempty"`
 	//SearchProfile     UserSearchProfile                  `json:"userSearchProfile,omitempty"`
@@ -57,6 +58,13 @@ type UserSearchProfile struct {
 	Language   []string                  `json:"language,omitempty"`
 	Snooze     *time.Time                `json:"snooze,omitempty"`
 	HideMyName *bool                     `json:"hide_my_name,omitempty"`
+	// Paused is read only, change it through /user/account/pause.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// PauseAccount pauses the account until Until, or until it is resumed when Until is not set.
+type PauseAccount struct {
+	Until *time.Time `json:"until,omitempty"`
 }
 
 type AdvancedFilter struct {
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// CreateReport is a report of content. ContentType is one of profile, photo, nudge, message, story
+// and event, and ContentID the id of the content, which is the user id for a profile.
+type CreateReport struct {
+	ContentType string `json:"content_type" binding:"required"`
+	ContentID   string `json:"content_id" binding:"required"`
+	Reason      string `json:"reason" binding:"required"`
+	Details     string `json:"details" binding:"max=1000"`
+}
+
+// ResolveReport is the decision of a moderator on a report.
+type ResolveReport struct {
+	Action string `json:"action" binding:"required"`
+	Note   string `json:"note" binding:"max=1000"`
+}
+
+type Report struct {
+	ID            uint       `json:"id"`
+	ReporterID    int        `json:"reporter_id"`
+	ReportedID    int        `json:"reported_id"`
+	ContentType   string     `json:"content_type"`
+	ContentID     string     `json:"content_id"`
+	Reason        string     `json:"reason"`
+	Details       string     `json:"details,omitempty"`
+	Status        string     `json:"status"`
+	Action        *string    `json:"action,omitempty"`
+	ModeratorID   *int       `json:"moderator_id,omitempty"`
+	ModeratorNote *string    `json:"moderator_note,omitempty"`
+	ReportedAt    time.Time  `json:"reported_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ReportReview is a report with the reported content in context, for a moderator to decide on.
+// Content is nil when the content no longer exists.
+type ReportReview struct {
+	Report       Report      `json:"report"`
+	Content      interface{} `json:"content"`
+	Context      interface{} `json:"context,omitempty"`
+	ReportedUser interface{} `json:"reported_user,omitempty"`
+	// OpenReports is the number of open reports about the reported user.
+	OpenReports int64 `json:"open_reports"`
+}
@@ -355,6 +355,7 @@ type UserSearchProfile struct {
 	Distance       int        `json:"distance,omitempty" default:"10"`
 	Language       []string   `json:"language,omitempty"`
 	Snooze         *time.Time `json:"snooze,omitempty"`
+	Paused         bool       `json:"paused,omitempty"`
 	HideMyName     *bool      `json:"hide_my_name,omitempty"`
 	AdvancedFilter `json:"advanced_filter,omitempty"`
 }
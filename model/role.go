@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Roles a user can have. Every role includes the permissions of the roles before it.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+var roleRanks = map[string]int{
+	RoleUser:      1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+// IsValidRole reports whether role is one of the known roles.
+func IsValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// HasRole reports whether role grants the permissions of required. Unknown roles grant nothing.
+func HasRole(role string, required string) bool {
+	return roleRanks[role] > 0 && roleRanks[role] >= roleRanks[required]
+}
+
+func (AdminAuditLog) TableName() string {
+	return "admin_audit_log"
+}
+
+// AdminAuditLog records one call to an admin API.
+type AdminAuditLog struct {
+	ID          uint      `gorm:"primarykey"`
+	UserId      int       `gorm:"column:user_id"`
+	Role        string    `gorm:"column:role"`
+	Method      string    `gorm:"column:method"`
+	Path        string    `gorm:"column:path"`
+	Status      int       `gorm:"column:status"`
+	IPAddress   string    `gorm:"column:ip_address"`
+	UserAgent   string    `gorm:"column:user_agent"`
+	RequestBody string    `gorm:"column:request_body"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (UserTOTP) TableName() string {
+	return "user_totp"
+}
+
+// UserTOTP is the authenticator app of a user. Two factor authentication is enabled once the
+// user has confirmed it with a first code.
+type UserTOTP struct {
+	gorm.Model
+	UserId int `gorm:"column:user_id"`
+	// Secret holds the AES-GCM encrypted base32 secret.
+	Secret      string     `gorm:"column:secret"`
+	ConfirmedAt *time.Time `gorm:"column:confirmed_at"`
+	// LastUsedStep is the time step of the last accepted code, a code is never accepted twice.
+	LastUsedStep int64 `gorm:"column:last_used_step"`
+}
+
+func (UserRecoveryCode) TableName() string {
+	return "user_recovery_code"
+}
+
+type UserRecoveryCode struct {
+	gorm.Model
+	UserId int `gorm:"column:user_id"`
+	// CodeHash holds the sha256 hash of the normalized recovery code.
+	CodeHash string     `gorm:"column:code_hash"`
+	UsedAt   *time.Time `gorm:"column:used_at"`
+}
@@ -20,7 +20,9 @@ type User struct {
 	Mobile        string     `json:"mobile" gorm:"column:mobile"`
 	IsActive      bool       `json:"is_active" gorm:"column:is_active"`
 	EmailVerified bool       `json:"email_verified" gorm:"column:email_verified"`
+	PrivateEmail  bool       `json:"is_private_email" gorm:"column:is_private_email"`
 	SignUpType    string     `json:"sign_up_type" gorm:"column:sign_up_type"`
+	Role          string     `json:"role" gorm:"column:role;default:user"`
 	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at"`
 	DeletedAt     *time.Time `json:"deleted_at" gorm:"column:deleted_at;default:null"`
 	UpdatedAt     time.Time  `json:"updated_at" gorm:"column:updated_at"`
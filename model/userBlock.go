@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+func (UserBlock) TableName() string {
+	return "user_blocks"
+}
+
+// UserBlock is a user blocking another user. Blocked users no longer see or reach each other.
+type UserBlock struct {
+	ID        uint      `json:"-" gorm:"primarykey"`
+	UserId    int       `json:"-" gorm:"column:user_id"`
+	BlockedId int       `json:"blocked_id" gorm:"column:blocked_id"`
+	CreatedAt time.Time `json:"blocked_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"-" gorm:"column:updated_at"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import "gorm.io/gorm"
+
+// Sign in methods an account can have linked.
+const (
+	IdentityProviderPhone    = "phone"
+	IdentityProviderGoogle   = "google"
+	IdentityProviderApple    = "apple"
+	IdentityProviderPassword = "password"
+)
+
+func (UserIdentity) TableName() string {
+	return "user_identity"
+}
+
+// UserIdentity is one sign in method of a user. Subject identifies the user at the provider: the
+// E.164 phone number, the google or apple user id, or the email of a password login.
+type UserIdentity struct {
+	gorm.Model
+	UserId   int    `gorm:"column:user_id"`
+	Provider string `gorm:"column:provider"`
+	Subject  string `gorm:"column:subject"`
+	Email    string `gorm:"column:email"`
+}
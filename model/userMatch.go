@@ -2,6 +2,12 @@ package model
 
 import "time"
 
+// Match types, telling how the match came about.
+const (
+	MatchTypeLike      = 1
+	MatchTypeSuperLike = 2
+)
+
 func (UserMatch) TableName() string {
 	return "user_match"
 }
@@ -14,6 +20,15 @@ type UserMatch struct {
 	ChatID     *string    `json:"chat_id" gorm:"chat_id"`
 	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt  *time.Time `json:"updated_at" gorm:"column:updated_at"`
+	// ExpiresAt is when the match is removed unless either user messages first. It is nil once they have.
+	ExpiresAt        *time.Time `json:"expires_at" gorm:"column:expires_at"`
+	ExpiryNotifiedAt *time.Time `json:"-" gorm:"column:expiry_notified_at"`
+	// ExtendedBy is the premium user who extended the match, which can only be done once.
+	ExtendedBy *int `json:"extended_by" gorm:"column:extended_by"`
+	// FirstMoveBy is the user who has to send the first message, nil when either can.
+	FirstMoveBy    *int       `json:"first_move_by" gorm:"column:first_move_by"`
+	FirstMessageAt *time.Time `json:"first_message_at" gorm:"column:first_message_at"`
+	DeletedAt      *time.Time `json:"-" gorm:"column:deleted_at"`
 }
 
 func (UserMatchSchema) TableName() string {
@@ -31,6 +46,7 @@ type UserMatchSchema struct {
 }
 
 type UserLikers struct {
-	UserID int    `json:"user_id"`
-	Image  string `json:"image"`
+	UserID    int    `json:"user_id"`
+	Image     string `json:"image"`
+	SuperLike bool   `json:"super_like"`
 }
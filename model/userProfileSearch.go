@@ -40,6 +40,7 @@ type UserProfile struct {
 	Smoke             *elasticsearchPkg.Smoke             `json:"smoke" gorm:"column:smoke"`
 	About             *string                             `json:"about" gorm:"column:about"`
 	Pronoun           *string                             `json:"pronoun" gorm:"column:pronoun"`
+	TimeZone          *string                             `json:"time_zone" gorm:"column:time_zone"`
 
 	//Images            []UserMedia        `json:"images" gorm:"foreignKey:user_profile_id;references:id"`
 	//Nudges            []UserNudgeProfile `json:"questions" gorm:"foreignKey:user_profile_id;references:id"`
@@ -71,6 +72,7 @@ type UserSearchProfile struct {
 	MaxAge        int        `json:"max_age" gorm:"column:max_age"`
 	Distance      int        `json:"distance" gorm:"column:distance"`
 	Snooze        *time.Time `json:"snooze" gorm:"column:snooze"`
+	Paused        bool       `json:"paused" gorm:"column:paused"`
 	HideMyName    *bool      `json:"hide_my_name" gorm:"column:hide_my_name"`
 	// Language      Language `json:"language" gorm:"column:language"`
 	//AdvancedFilter AdvancedFilter `json:"advanced_filter" gorm:"foreignKey:UserSearchProfileId;references:ID"`
@@ -0,0 +1,119 @@
+package model
+
+import "time"
+
+// Kinds of content a user can report. The content id is the id of the row, or of the document for stories.
+const (
+	ReportContentProfile = "profile"
+	ReportContentPhoto   = "photo"
+	ReportContentNudge   = "nudge"
+	ReportContentMessage = "message"
+	ReportContentStory   = "story"
+	ReportContentEvent   = "event"
+)
+
+// Reasons a user can give for a report.
+const (
+	ReportReasonSpam          = "spam"
+	ReportReasonInappropriate = "inappropriate"
+	ReportReasonHarassment    = "harassment"
+	ReportReasonFakeProfile   = "fake_profile"
+	ReportReasonUnderage      = "underage"
+	ReportReasonScam          = "scam"
+	ReportReasonOther         = "other"
+)
+
+// Statuses of a report in the moderation queue.
+const (
+	ReportOpen      = "open"
+	ReportActioned  = "actioned"
+	ReportDismissed = "dismissed"
+)
+
+// Actions a moderator can take on a report. Dismiss closes the report without acting on the content.
+const (
+	ModerationDismiss        = "dismiss"
+	ModerationHideMedia      = "hide_media"
+	ModerationDeleteNudge    = "delete_nudge"
+	ModerationDeleteStory    = "delete_story"
+	ModerationDeleteEvent    = "delete_event"
+	ModerationSuspendAccount = "suspend_account"
+)
+
+var reportContentTypes = map[string]bool{
+	ReportContentProfile: true,
+	ReportContentPhoto:   true,
+	ReportContentNudge:   true,
+	ReportContentMessage: true,
+	ReportContentStory:   true,
+	ReportContentEvent:   true,
+}
+
+var reportReasons = map[string]bool{
+	ReportReasonSpam:          true,
+	ReportReasonInappropriate: true,
+	ReportReasonHarassment:    true,
+	ReportReasonFakeProfile:   true,
+	ReportReasonUnderage:      true,
+	ReportReasonScam:          true,
+	ReportReasonOther:         true,
+}
+
+// moderationActions lists the content each action applies to. Nil means any content.
+var moderationActions = map[string][]string{
+	ModerationDismiss:        nil,
+	ModerationHideMedia:      {ReportContentPhoto},
+	ModerationDeleteNudge:    {ReportContentNudge},
+	ModerationDeleteStory:    {ReportContentStory},
+	ModerationDeleteEvent:    {ReportContentEvent},
+	ModerationSuspendAccount: nil,
+}
+
+// IsValidReportContentType reports whether contentType is a kind of content that can be reported.
+func IsValidReportContentType(contentType string) bool {
+	return reportContentTypes[contentType]
+}
+
+// IsValidReportReason reports whether reason is one of the known report reasons.
+func IsValidReportReason(reason string) bool {
+	return reportReasons[reason]
+}
+
+// IsValidModerationAction reports whether the action can be taken on the kind of content.
+func IsValidModerationAction(action string, contentType string) bool {
+	contentTypes, ok := moderationActions[action]
+	if !ok {
+		return false
+	}
+	if contentTypes == nil {
+		return true
+	}
+	for _, t := range contentTypes {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+func (UserReport) TableName() string {
+	return "user_reports"
+}
+
+// UserReport is a report of a user about content of another user, queued for moderation.
+type UserReport struct {
+	ID            uint       `gorm:"primarykey"`
+	ReporterId    int        `gorm:"column:reporter_id"`
+	ReportedId    int        `gorm:"column:reported_id"`
+	ContentType   string     `gorm:"column:content_type"`
+	ContentId     string     `gorm:"column:content_id"`
+	Reason        string     `gorm:"column:reason"`
+	Details       string     `gorm:"column:details"`
+	Status        string     `gorm:"column:status"`
+	Action        *string    `gorm:"column:action"`
+	ModeratorId   *int       `gorm:"column:moderator_id"`
+	ModeratorNote *string    `gorm:"column:moderator_note"`
+	ResolvedAt    *time.Time `gorm:"column:resolved_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Swipe types, as stored in the ledger and cached in redis.
+const (
+	SwipeDislike   = 0
+	SwipeLike      = 1
+	SwipeSuperLike = 2
+)
+
+func (UserSwipe) TableName() string {
+	return "user_swipes"
+}
+
+// UserSwipe is the latest swipe of a user on another user.
+type UserSwipe struct {
+	ID        uint      `gorm:"primarykey"`
+	UserId    int       `gorm:"column:user_id"`
+	TargetId  int       `gorm:"column:target_id"`
+	SwipeType int       `gorm:"column:swipe_type"`
+	SwipedAt  time.Time `gorm:"column:swiped_at"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// IsLike reports whether the swipe is a like of any kind.
+func (u UserSwipe) IsLike() bool {
+	return u.SwipeType == SwipeLike || u.SwipeType == SwipeSuperLike
+}
@@ -20,6 +20,18 @@ type UserToken struct {
 	Token     string    `gorm:"column:token;size:300;index:user_id_token_is_active;index:token_is_active"`
 	IsActive  bool      `gorm:"column:is_active;default:true;index:user_id_token_is_active;index:token_is_active"`
 	ExpiresAt time.Time `gorm:"column:expires_at"`
+	// RefreshToken holds the sha256 hash of the refresh token issued alongside Token.
+	RefreshToken     string    `gorm:"column:refresh_token;size:64;index:user_token_refresh_token"`
+	RefreshExpiresAt time.Time `gorm:"column:refresh_expires_at"`
+	// FamilyID is shared by every token pair obtained by rotating the same login's refresh token.
+	FamilyID   string     `gorm:"column:family_id;size:36;index:user_token_family_id"`
+	UserAgent  string     `gorm:"column:user_agent;size:255"`
+	IPAddress  string     `gorm:"column:ip_address;size:45"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	// AuthenticatedAt is when the user signed in to the session, refreshing does not change it.
+	AuthenticatedAt *time.Time `gorm:"column:authenticated_at"`
+	// MFAVerified is set when the session passed two factor authentication at sign in.
+	MFAVerified bool `gorm:"column:mfa_verified"`
 }
 
 type UserDeviceToken struct {
@@ -27,4 +39,6 @@ type UserDeviceToken struct {
 	UserID      int    `json:"user_id"`
 	DeviceToken string `json:"device_token"`
 	EndpointARN string `json:"endpoint_arn"`
+	// SessionID is the token family of the login that registered the device.
+	SessionID string `json:"-" gorm:"column:session_id"`
 }
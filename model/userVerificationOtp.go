@@ -7,9 +7,11 @@ import (
 
 type UserVerificationOTP struct {
 	gorm.Model
-	PhoneNumber string    `json:"phoneNumber" gorm:"column:phone_number"`
-	OTP         string    `json:"otp" gorm:"column:otp"`
-	ExpiresAt   time.Time `gorm:"column:expires_at"`
+	PhoneNumber string `json:"phoneNumber" gorm:"column:phone_number"`
+	// OTP holds the bcrypt hash of the code sent to the phone number.
+	OTP        string     `json:"-" gorm:"column:otp"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at"`
+	ConsumedAt *time.Time `gorm:"column:consumed_at"`
 }
 
 type EmailVerification struct {
@@ -19,3 +21,12 @@ type EmailVerification struct {
 	IsVerified       bool      `json:"isVerified" gorm:"column:is_verified"`
 	ExpiresAt        time.Time `json:"expires_at" gorm:"column:expires_at"`
 }
+
+type PasswordReset struct {
+	gorm.Model
+	UserId int `gorm:"column:user_id"`
+	// TokenHash holds the sha256 hash of the reset token mailed to the user.
+	TokenHash string     `gorm:"column:token_hash"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+}
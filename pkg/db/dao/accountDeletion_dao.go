@@ -0,0 +1,228 @@
+package dao
+
+import (
+	"crypto/rand"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/utilities"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// staleProcessingTimeout is after how long a deletion left processing, by a worker that stopped, is picked up again.
+const staleProcessingTimeout = time.Hour
+
+//go:generate mockgen -package mocks -destination mocks/account_deletion_dao_mock.go github.com/SuperMatch/pkg/db/dao AccountDeletionRepository
+type AccountDeletionRepository interface {
+	Insert(deletion model.AccountDeletion) (model.AccountDeletion, error)
+	FindById(id uint) (model.AccountDeletion, error)
+	FindScheduledByUserId(userId int) (model.AccountDeletion, error)
+	FindDue(now time.Time, limit int) ([]model.AccountDeletion, error)
+	Claim(id uint) (bool, error)
+	Cancel(id uint) (bool, error)
+	Complete(id uint, receipt string) error
+	Fail(id uint, lastError string) error
+	FindProfileIds(userId int) ([]int, error)
+	EraseUserData(userId int) (map[string]int64, error)
+}
+
+type AccountDeletionDao struct {
+	Connection gorm.DB
+}
+
+func (a *AccountDeletionDao) Insert(deletion model.AccountDeletion) (model.AccountDeletion, error) {
+	tx := a.Connection.Create(&deletion)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in inserting account deletion", zap.Error(tx.Error))
+	}
+	return deletion, tx.Error
+}
+
+func (a *AccountDeletionDao) FindById(id uint) (model.AccountDeletion, error) {
+	var deletion model.AccountDeletion
+	tx := a.Connection.Where("ID = ?", id).First(&deletion)
+	return deletion, tx.Error
+}
+
+// FindScheduledByUserId returns the deletion of the user that has not been cancelled or completed.
+func (a *AccountDeletionDao) FindScheduledByUserId(userId int) (model.AccountDeletion, error) {
+	var deletion model.AccountDeletion
+	tx := a.Connection.Where("user_id = ? and status in ?", userId, []string{model.AccountDeletionScheduled, model.AccountDeletionProcessing}).
+		Order("ID desc").First(&deletion)
+	return deletion, tx.Error
+}
+
+// FindDue returns deletions whose grace period is over, including ones left processing by a worker that stopped.
+func (a *AccountDeletionDao) FindDue(now time.Time, limit int) ([]model.AccountDeletion, error) {
+	var deletions []model.AccountDeletion
+	tx := a.Connection.Where("purge_after <= ?", now).
+		Where("status = ? or (status = ? and updated_at < ?)", model.AccountDeletionScheduled, model.AccountDeletionProcessing, now.Add(-staleProcessingTimeout)).
+		Order("purge_after").Limit(limit).Find(&deletions)
+	return deletions, tx.Error
+}
+
+// Claim marks a due deletion as processing and reports whether this call claimed it, so only one worker erases an account.
+func (a *AccountDeletionDao) Claim(id uint) (bool, error) {
+	tx := a.Connection.Model(&model.AccountDeletion{}).Where("ID = ?", id).
+		Where("status = ? or (status = ? and updated_at < ?)", model.AccountDeletionScheduled, model.AccountDeletionProcessing, time.Now().Add(-staleProcessingTimeout)).
+		Updates(map[string]interface{}{"status": model.AccountDeletionProcessing, "attempts": gorm.Expr("attempts + 1")})
+	return tx.RowsAffected == 1, tx.Error
+}
+
+// Cancel cancels a deletion that is still in its grace period and reports whether it was.
+func (a *AccountDeletionDao) Cancel(id uint) (bool, error) {
+	tx := a.Connection.Model(&model.AccountDeletion{}).Where("ID = ? and status = ?", id, model.AccountDeletionScheduled).
+		Updates(map[string]interface{}{"status": model.AccountDeletionCancelled, "cancelled_at": time.Now()})
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (a *AccountDeletionDao) Complete(id uint, receipt string) error {
+	tx := a.Connection.Model(&model.AccountDeletion{}).Where("ID = ?", id).
+		Updates(map[string]interface{}{"status": model.AccountDeletionCompleted, "completed_at": time.Now(), "receipt": receipt, "last_error": ""})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in completing account deletion", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
+
+// Fail puts a deletion back in the queue to be retried.
+func (a *AccountDeletionDao) Fail(id uint, lastError string) error {
+	tx := a.Connection.Model(&model.AccountDeletion{}).Where("ID = ?", id).
+		Updates(map[string]interface{}{"status": model.AccountDeletionScheduled, "last_error": utilities.TruncateString(lastError, 1000)})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in failing account deletion", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
+
+func (a *AccountDeletionDao) FindProfileIds(userId int) ([]int, error) {
+	var profileIds []int
+	tx := a.Connection.Unscoped().Model(&model.UserProfile{}).Where("user_id = ?", userId).Pluck("ID", &profileIds)
+	return profileIds, tx.Error
+}
+
+// erasure is a delete of the rows of one table that belong to the user being erased.
+type erasure struct {
+	table string
+	where string
+	args  func(userId int, profileIds []int, mobile string) []interface{}
+}
+
+func byUserId(userId int, _ []int, _ string) []interface{} {
+	return []interface{}{userId}
+}
+
+func byUserIdTwice(userId int, _ []int, _ string) []interface{} {
+	return []interface{}{userId, userId}
+}
+
+// userErasures lists every table holding user data, children before the tables they reference.
+var userErasures = []erasure{
+	{"user_nudge_profile", "user_profile_id in (?)", func(_ int, profileIds []int, _ string) []interface{} { return []interface{}{profileIds} }},
+	{"profile_media", "user_id = ? or user_profile_id in (?)", func(userId int, profileIds []int, _ string) []interface{} { return []interface{}{userId, profileIds} }},
+	{"user_search_profile", "user_id = ?", byUserId},
+	{"advanced_filters", "user_id = ?", byUserId},
+	{"events", "user_id = ?", byUserId},
+	{"user_interests", "user_id = ?", byUserId},
+	{"user_nudges", "user_id = ?", byUserId},
+	{"user_chats", "sender_id = ? or receiver_id = ?", byUserIdTwice},
+	{"user_match", "user_id = ? or match_id = ?", byUserIdTwice},
+	{"user_swipes", "user_id = ? or target_id = ?", byUserIdTwice},
+	{"user_blocks", "user_id = ? or blocked_id = ?", byUserIdTwice},
+	{"user_device_tokens", "user_id = ?", byUserId},
+	{"email_verification", "user_id = ?", byUserId},
+	{"user_verification_otp", "phone_number = ?", func(_ int, _ []int, mobile string) []interface{} { return []interface{}{mobile} }},
+	{"user_token", "user_id = ?", byUserId},
+	{"password_reset", "user_id = ?", byUserId},
+	{"user_identity", "user_id = ?", byUserId},
+	{"user_recovery_code", "user_id = ?", byUserId},
+	{"user_totp", "user_id = ?", byUserId},
+	{"data_export", "user_id = ?", byUserId},
+	{"user_profile", "user_id = ?", byUserId},
+	{"users", "ID = ?", byUserId},
+}
+
+// pseudonymization replaces the user with a pseudonym in rows that are kept after the account is
+// erased, and clears what else would identify them.
+type pseudonymization struct {
+	table string
+	set   string
+	where string
+}
+
+// userPseudonymizations lists the rows kept after an account is erased. The pseudonym is a random
+// negative id, the same for every row of one erasure.
+var userPseudonymizations = []pseudonymization{
+	{"admin_audit_log", "user_id = @pseudonym, ip_address = NULL, user_agent = NULL", "user_id = @user"},
+	// reports stay for the moderation history; a reported profile is named by its user id
+	{"user_reports", "reporter_id = @pseudonym, details = NULL", "reporter_id = @user"},
+	{"user_reports", "reported_id = @pseudonym, content_id = IF(content_type = 'profile', CAST(@pseudonym AS CHAR), content_id)", "reported_id = @user"},
+	{"user_reports", "moderator_id = @pseudonym", "moderator_id = @user"},
+}
+
+// newPseudonym returns a random negative id, which no user has.
+func newPseudonym() (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt32))
+	if err != nil {
+		return 0, err
+	}
+	return -int(n.Int64()) - 1, nil
+}
+
+// EraseUserData hard deletes every row of the user in one transaction and returns the number of
+// rows deleted per table. The admin audit log and the reports are kept, with the user replaced by a
+// pseudonym.
+func (a *AccountDeletionDao) EraseUserData(userId int) (map[string]int64, error) {
+	rows := make(map[string]int64, len(userErasures))
+
+	pseudonym, err := newPseudonym()
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.Connection.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.Unscoped().Where("ID = ?", userId).Limit(1).Find(&user).Error; err != nil {
+			return err
+		}
+
+		var profileIds []int
+		if err := tx.Unscoped().Model(&model.UserProfile{}).Where("user_id = ?", userId).Pluck("ID", &profileIds).Error; err != nil {
+			return err
+		}
+		// "in (?)" needs at least one value, no profile has id 0
+		if len(profileIds) == 0 {
+			profileIds = []int{0}
+		}
+
+		for _, p := range userPseudonymizations {
+			result := tx.Exec("UPDATE "+p.table+" SET "+p.set+" WHERE "+p.where, map[string]interface{}{"user": userId, "pseudonym": pseudonym})
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		for _, e := range userErasures {
+			if e.table == "user_verification_otp" && user.Mobile == "" {
+				continue
+			}
+
+			result := tx.Exec("DELETE FROM "+e.table+" WHERE "+e.where, e.args(userId, profileIds, user.Mobile)...)
+			if result.Error != nil {
+				return result.Error
+			}
+			rows[e.table] = result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in erasing user data", zap.Error(err))
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,27 @@
+package dao
+
+import (
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package mocks -destination mocks/admin_audit_log_dao_mock.go github.com/SuperMatch/pkg/db/dao AdminAuditLogRepository
+type AdminAuditLogRepository interface {
+	Insert(auditLog model.AdminAuditLog) error
+}
+
+type AdminAuditLogDao struct {
+	Connection gorm.DB
+}
+
+func (a *AdminAuditLogDao) Insert(auditLog model.AdminAuditLog) error {
+	tx := a.Connection.Create(&auditLog)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in inserting admin audit log", zap.Error(tx.Error))
+		return tx.Error
+	}
+
+	return nil
+}
@@ -12,8 +12,10 @@ type ChatDao interface {
 	Insert(chatDetails model.ChatDetails) error
 	RetrieveUserChats(chatID string) ([]model.ChatDetails, error)
 	GetUserChatsList(userID int) ([]model.ChatDetails, error)
-	UpdateMessageReadStatus(messageIDs []int) error
-	RetrieveLastMessages(chatIDs []string) ([]model.ChatDetails, error)
+	UpdateMessageReadStatus(receiverID int, messageIDs []int) error
+	RetrieveLastMessages(userID int, chatIDs []string) ([]model.ChatDetails, error)
+	FindById(id int) (model.ChatDetails, error)
+	RetrieveMessagesAround(message model.ChatDetails, limit int) ([]model.ChatDetails, error)
 }
 
 type ChatDaoImpl struct {
@@ -62,8 +64,8 @@ func (c *ChatDaoImpl) GetUserChatsList(userID int) ([]model.ChatDetails, error)
 	return chats, nil
 }
 
-func (c *ChatDaoImpl) UpdateMessageReadStatus(messageIDs []int) error {
-	err := c.Connection.Debug().Table("user_chats").Where("ID in ?", messageIDs).Update("is_read", true)
+func (c *ChatDaoImpl) UpdateMessageReadStatus(receiverID int, messageIDs []int) error {
+	err := c.Connection.Debug().Table("user_chats").Where("ID in ?", messageIDs).Where("receiver_id = ?", receiverID).Update("is_read", true)
 	if err.Error != nil {
 		zapLogger.Logger.Error("error in updating message read status")
 		return err.Error
@@ -72,12 +74,12 @@ func (c *ChatDaoImpl) UpdateMessageReadStatus(messageIDs []int) error {
 	return nil
 }
 
-func (c *ChatDaoImpl) RetrieveLastMessages(chatIDs []string) ([]model.ChatDetails, error) {
+func (c *ChatDaoImpl) RetrieveLastMessages(userID int, chatIDs []string) ([]model.ChatDetails, error) {
 	var chats []model.ChatDetails
 
-	query := "select * from user_chats uc where chat_id in ? and created_at  = (select max(created_at) from user_chats uc2 where uc.chat_id = uc2.chat_id);"
+	query := "select * from user_chats uc where chat_id in ? and (sender_id = ? or receiver_id = ?) and created_at  = (select max(created_at) from user_chats uc2 where uc.chat_id = uc2.chat_id);"
 
-	err := c.Connection.Debug().Raw(query, chatIDs).Find(&chats)
+	err := c.Connection.Debug().Raw(query, chatIDs, userID, userID).Find(&chats)
 	if errors.Is(err.Error, gorm.ErrRecordNotFound) {
 		zapLogger.Logger.Error("no chats found for the user")
 		return nil, err.Error
@@ -88,3 +90,31 @@ func (c *ChatDaoImpl) RetrieveLastMessages(chatIDs []string) ([]model.ChatDetail
 
 	return chats, nil
 }
+
+func (c *ChatDaoImpl) FindById(id int) (model.ChatDetails, error) {
+	var chat model.ChatDetails
+	err := c.Connection.Table("user_chats").Where("ID = ?", id).First(&chat)
+	return chat, err.Error
+}
+
+// RetrieveMessagesAround returns up to limit messages on each side of the message in its chat, oldest first.
+func (c *ChatDaoImpl) RetrieveMessagesAround(message model.ChatDetails, limit int) ([]model.ChatDetails, error) {
+	var before, after []model.ChatDetails
+	err := c.Connection.Table("user_chats").Where("chat_id = ? and ID < ?", message.ChatID, message.ID).Order("ID DESC").Limit(limit).Find(&before)
+	if err.Error != nil {
+		zapLogger.Logger.Error("error in retrieving messages before the message")
+		return nil, err.Error
+	}
+	err = c.Connection.Table("user_chats").Where("chat_id = ? and ID > ?", message.ChatID, message.ID).Order("ID").Limit(limit).Find(&after)
+	if err.Error != nil {
+		zapLogger.Logger.Error("error in retrieving messages after the message")
+		return nil, err.Error
+	}
+
+	chats := make([]model.ChatDetails, 0, len(before)+1+len(after))
+	for i := len(before) - 1; i >= 0; i-- {
+		chats = append(chats, before[i])
+	}
+	chats = append(chats, message)
+	return append(chats, after...), nil
+}
@@ -0,0 +1,172 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/utilities"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package mocks -destination mocks/data_export_dao_mock.go github.com/SuperMatch/pkg/db/dao DataExportRepository
+type DataExportRepository interface {
+	Insert(export model.DataExport) (model.DataExport, error)
+	FindById(userId int, id uint) (model.DataExport, error)
+	FindActiveByUserId(userId int) (model.DataExport, error)
+	FindPending(limit int) ([]model.DataExport, error)
+	Claim(id uint) (bool, error)
+	Complete(id uint, s3Key string, expiresAt time.Time) error
+	Fail(id uint, lastError string, retry bool) error
+	FindExpired(now time.Time, limit int) ([]model.DataExport, error)
+	MarkExpired(id uint) error
+	FindProfileIds(userId int) ([]int, error)
+	FindUserData(userId int) (map[string][]map[string]interface{}, error)
+}
+
+type DataExportDao struct {
+	Connection gorm.DB
+}
+
+func (d *DataExportDao) Insert(export model.DataExport) (model.DataExport, error) {
+	tx := d.Connection.Create(&export)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in inserting data export", zap.Error(tx.Error))
+	}
+	return export, tx.Error
+}
+
+func (d *DataExportDao) FindById(userId int, id uint) (model.DataExport, error) {
+	var export model.DataExport
+	tx := d.Connection.Where("ID = ? and user_id = ?", id, userId).First(&export)
+	return export, tx.Error
+}
+
+// FindActiveByUserId returns the export of the user that is still being built.
+func (d *DataExportDao) FindActiveByUserId(userId int) (model.DataExport, error) {
+	var export model.DataExport
+	tx := d.Connection.Where("user_id = ? and status in ?", userId, []string{model.DataExportPending, model.DataExportProcessing}).
+		Order("ID desc").First(&export)
+	return export, tx.Error
+}
+
+// FindPending returns exports waiting to be built, including ones left processing by a worker that stopped.
+func (d *DataExportDao) FindPending(limit int) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	tx := d.Connection.Where("status = ? or (status = ? and updated_at < ?)", model.DataExportPending, model.DataExportProcessing, time.Now().Add(-staleProcessingTimeout)).
+		Order("ID").Limit(limit).Find(&exports)
+	return exports, tx.Error
+}
+
+// Claim marks a pending export as processing and reports whether this call claimed it.
+func (d *DataExportDao) Claim(id uint) (bool, error) {
+	tx := d.Connection.Model(&model.DataExport{}).Where("ID = ?", id).
+		Where("status = ? or (status = ? and updated_at < ?)", model.DataExportPending, model.DataExportProcessing, time.Now().Add(-staleProcessingTimeout)).
+		Updates(map[string]interface{}{"status": model.DataExportProcessing, "attempts": gorm.Expr("attempts + 1")})
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (d *DataExportDao) Complete(id uint, s3Key string, expiresAt time.Time) error {
+	tx := d.Connection.Model(&model.DataExport{}).Where("ID = ?", id).
+		Updates(map[string]interface{}{"status": model.DataExportReady, "s3_key": s3Key, "completed_at": time.Now(), "expires_at": expiresAt, "last_error": ""})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in completing data export", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
+
+// Fail records why building an export failed and either puts it back in the queue or gives up on it.
+func (d *DataExportDao) Fail(id uint, lastError string, retry bool) error {
+	status := model.DataExportFailed
+	if retry {
+		status = model.DataExportPending
+	}
+
+	tx := d.Connection.Model(&model.DataExport{}).Where("ID = ?", id).
+		Updates(map[string]interface{}{"status": status, "last_error": utilities.TruncateString(lastError, 1000)})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in failing data export", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
+
+// FindExpired returns ready exports whose archive should be deleted.
+func (d *DataExportDao) FindExpired(now time.Time, limit int) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	tx := d.Connection.Where("status = ? and expires_at <= ?", model.DataExportReady, now).Order("ID").Limit(limit).Find(&exports)
+	return exports, tx.Error
+}
+
+func (d *DataExportDao) MarkExpired(id uint) error {
+	tx := d.Connection.Model(&model.DataExport{}).Where("ID = ?", id).Update("status", model.DataExportExpired)
+	return tx.Error
+}
+
+func (d *DataExportDao) FindProfileIds(userId int) ([]int, error) {
+	var profileIds []int
+	tx := d.Connection.Model(&model.UserProfile{}).Where("user_id = ?", userId).Pluck("ID", &profileIds)
+	return profileIds, tx.Error
+}
+
+// hiddenExportColumns lists the columns of a table that are credentials rather than data about the user.
+var hiddenExportColumns = map[string][]string{
+	"users":      {"password"},
+	"user_token": {"token", "refresh_token"},
+}
+
+// userExports lists the tables exported for a user, each with the condition selecting the rows of the user.
+var userExports = []erasure{
+	{"users", "ID = ?", byUserId},
+	{"user_profile", "user_id = ?", byUserId},
+	{"user_search_profile", "user_id = ?", byUserId},
+	{"advanced_filters", "user_id = ?", byUserId},
+	{"user_interests", "user_id = ?", byUserId},
+	{"user_nudges", "user_id = ?", byUserId},
+	{"user_nudge_profile", "user_profile_id in (?)", func(_ int, profileIds []int, _ string) []interface{} { return []interface{}{profileIds} }},
+	{"profile_media", "user_id = ? or user_profile_id in (?)", func(userId int, profileIds []int, _ string) []interface{} { return []interface{}{userId, profileIds} }},
+	{"user_match", "user_id = ?", byUserId},
+	{"user_swipes", "user_id = ?", byUserId},
+	{"user_blocks", "user_id = ?", byUserId},
+	{"user_reports", "reporter_id = ?", byUserId},
+	{"user_chats", "sender_id = ? or receiver_id = ?", byUserIdTwice},
+	{"events", "user_id = ?", byUserId},
+	{"user_token", "user_id = ?", byUserId},
+	{"user_identity", "user_id = ?", byUserId},
+}
+
+// FindUserData returns the rows of the user in every exported table, keyed by table.
+func (d *DataExportDao) FindUserData(userId int) (map[string][]map[string]interface{}, error) {
+	profileIds, err := d.FindProfileIds(userId)
+	if err != nil {
+		return nil, err
+	}
+	// "in (?)" needs at least one value, no profile has id 0
+	if len(profileIds) == 0 {
+		profileIds = []int{0}
+	}
+
+	data := make(map[string][]map[string]interface{}, len(userExports))
+	for _, e := range userExports {
+		rows := make([]map[string]interface{}, 0)
+		tx := d.Connection.Table(e.table).Where(e.where, e.args(userId, profileIds, "")...).Find(&rows)
+		if tx.Error != nil {
+			zapLogger.Logger.Error("error in finding user data", zap.String("table", e.table), zap.Error(tx.Error))
+			return nil, tx.Error
+		}
+
+		for _, row := range rows {
+			for _, column := range hiddenExportColumns[e.table] {
+				delete(row, column)
+			}
+			for column, value := range row {
+				if b, ok := value.([]byte); ok {
+					row[column] = string(b)
+				}
+			}
+		}
+		data[e.table] = rows
+	}
+
+	return data, nil
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/db/dao (interfaces: AdminAuditLogRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/SuperMatch/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAdminAuditLogRepository is a mock of AdminAuditLogRepository interface.
+type MockAdminAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminAuditLogRepositoryMockRecorder
+}
+
+// MockAdminAuditLogRepositoryMockRecorder is the mock recorder for MockAdminAuditLogRepository.
+type MockAdminAuditLogRepositoryMockRecorder struct {
+	mock *MockAdminAuditLogRepository
+}
+
+// NewMockAdminAuditLogRepository creates a new mock instance.
+func NewMockAdminAuditLogRepository(ctrl *gomock.Controller) *MockAdminAuditLogRepository {
+	mock := &MockAdminAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockAdminAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminAuditLogRepository) EXPECT() *MockAdminAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockAdminAuditLogRepository) Insert(arg0 model.AdminAuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAdminAuditLogRepositoryMockRecorder) Insert(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAdminAuditLogRepository)(nil).Insert), arg0)
+}
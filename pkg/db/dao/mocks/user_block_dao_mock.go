@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/db/dao (interfaces: UserBlockRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/SuperMatch/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserBlockRepository is a mock of UserBlockRepository interface.
+type MockUserBlockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserBlockRepositoryMockRecorder
+}
+
+// MockUserBlockRepositoryMockRecorder is the mock recorder for MockUserBlockRepository.
+type MockUserBlockRepositoryMockRecorder struct {
+	mock *MockUserBlockRepository
+}
+
+// NewMockUserBlockRepository creates a new mock instance.
+func NewMockUserBlockRepository(ctrl *gomock.Controller) *MockUserBlockRepository {
+	mock := &MockUserBlockRepository{ctrl: ctrl}
+	mock.recorder = &MockUserBlockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserBlockRepository) EXPECT() *MockUserBlockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockUserBlockRepository) Delete(arg0, arg1 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserBlockRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserBlockRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindBlockedProfileIds mocks base method.
+func (m *MockUserBlockRepository) FindBlockedProfileIds(arg0 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBlockedProfileIds", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBlockedProfileIds indicates an expected call of FindBlockedProfileIds.
+func (mr *MockUserBlockRepositoryMockRecorder) FindBlockedProfileIds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBlockedProfileIds", reflect.TypeOf((*MockUserBlockRepository)(nil).FindBlockedProfileIds), arg0)
+}
+
+// FindBlockedUserIds mocks base method.
+func (m *MockUserBlockRepository) FindBlockedUserIds(arg0 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBlockedUserIds", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBlockedUserIds indicates an expected call of FindBlockedUserIds.
+func (mr *MockUserBlockRepositoryMockRecorder) FindBlockedUserIds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBlockedUserIds", reflect.TypeOf((*MockUserBlockRepository)(nil).FindBlockedUserIds), arg0)
+}
+
+// FindByUserId mocks base method.
+func (m *MockUserBlockRepository) FindByUserId(arg0 int) ([]model.UserBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserId", arg0)
+	ret0, _ := ret[0].([]model.UserBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserId indicates an expected call of FindByUserId.
+func (mr *MockUserBlockRepositoryMockRecorder) FindByUserId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockUserBlockRepository)(nil).FindByUserId), arg0)
+}
+
+// Insert mocks base method.
+func (m *MockUserBlockRepository) Insert(arg0 model.UserBlock) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockUserBlockRepositoryMockRecorder) Insert(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserBlockRepository)(nil).Insert), arg0)
+}
+
+// IsBlocked mocks base method.
+func (m *MockUserBlockRepository) IsBlocked(arg0, arg1 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockUserBlockRepositoryMockRecorder) IsBlocked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockUserBlockRepository)(nil).IsBlocked), arg0, arg1)
+}
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/db/dao (interfaces: UserDeviceTokenDao)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/SuperMatch/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserDeviceTokenDao is a mock of UserDeviceTokenDao interface.
+type MockUserDeviceTokenDao struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserDeviceTokenDaoMockRecorder
+}
+
+// MockUserDeviceTokenDaoMockRecorder is the mock recorder for MockUserDeviceTokenDao.
+type MockUserDeviceTokenDaoMockRecorder struct {
+	mock *MockUserDeviceTokenDao
+}
+
+// NewMockUserDeviceTokenDao creates a new mock instance.
+func NewMockUserDeviceTokenDao(ctrl *gomock.Controller) *MockUserDeviceTokenDao {
+	mock := &MockUserDeviceTokenDao{ctrl: ctrl}
+	mock.recorder = &MockUserDeviceTokenDaoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserDeviceTokenDao) EXPECT() *MockUserDeviceTokenDaoMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockUserDeviceTokenDao) Delete(arg0 []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserDeviceTokenDaoMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserDeviceTokenDao)(nil).Delete), arg0)
+}
+
+// GetDeviceTokensBySessionId mocks base method.
+func (m *MockUserDeviceTokenDao) GetDeviceTokensBySessionId(arg0 int, arg1 string) ([]model.UserDeviceToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceTokensBySessionId", arg0, arg1)
+	ret0, _ := ret[0].([]model.UserDeviceToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceTokensBySessionId indicates an expected call of GetDeviceTokensBySessionId.
+func (mr *MockUserDeviceTokenDaoMockRecorder) GetDeviceTokensBySessionId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceTokensBySessionId", reflect.TypeOf((*MockUserDeviceTokenDao)(nil).GetDeviceTokensBySessionId), arg0, arg1)
+}
+
+// GetDeviceTokensByUserId mocks base method.
+func (m *MockUserDeviceTokenDao) GetDeviceTokensByUserId(arg0 int) ([]model.UserDeviceToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceTokensByUserId", arg0)
+	ret0, _ := ret[0].([]model.UserDeviceToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceTokensByUserId indicates an expected call of GetDeviceTokensByUserId.
+func (mr *MockUserDeviceTokenDaoMockRecorder) GetDeviceTokensByUserId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceTokensByUserId", reflect.TypeOf((*MockUserDeviceTokenDao)(nil).GetDeviceTokensByUserId), arg0)
+}
+
+// Insert mocks base method.
+func (m *MockUserDeviceTokenDao) Insert(arg0 model.UserDeviceToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockUserDeviceTokenDaoMockRecorder) Insert(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserDeviceTokenDao)(nil).Insert), arg0)
+}
+
+// UpdateSessionId mocks base method.
+func (m *MockUserDeviceTokenDao) UpdateSessionId(arg0 uint, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSessionId", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSessionId indicates an expected call of UpdateSessionId.
+func (mr *MockUserDeviceTokenDaoMockRecorder) UpdateSessionId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSessionId", reflect.TypeOf((*MockUserDeviceTokenDao)(nil).UpdateSessionId), arg0, arg1)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/SuperMatch/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,21 @@ func (m *MockUserMatchDao) EXPECT() *MockUserMatchDaoMockRecorder {
 	return m.recorder
 }
 
+// BackfillExpiry mocks base method.
+func (m *MockUserMatchDao) BackfillExpiry(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillExpiry", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillExpiry indicates an expected call of BackfillExpiry.
+func (mr *MockUserMatchDaoMockRecorder) BackfillExpiry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillExpiry", reflect.TypeOf((*MockUserMatchDao)(nil).BackfillExpiry), arg0, arg1)
+}
+
 // DeleteByUserID mocks base method.
 func (m *MockUserMatchDao) DeleteByUserID(arg0 context.Context, arg1, arg2 int) error {
 	m.ctrl.T.Helper()
@@ -49,6 +65,36 @@ func (mr *MockUserMatchDaoMockRecorder) DeleteByUserID(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockUserMatchDao)(nil).DeleteByUserID), arg0, arg1, arg2)
 }
 
+// ExpireDue mocks base method.
+func (m *MockUserMatchDao) ExpireDue(arg0 context.Context, arg1 time.Time, arg2 int) ([]model.UserMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireDue", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.UserMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireDue indicates an expected call of ExpireDue.
+func (mr *MockUserMatchDaoMockRecorder) ExpireDue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireDue", reflect.TypeOf((*MockUserMatchDao)(nil).ExpireDue), arg0, arg1, arg2)
+}
+
+// Extend mocks base method.
+func (m *MockUserMatchDao) Extend(arg0 context.Context, arg1, arg2 int, arg3 time.Duration, arg4 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Extend indicates an expected call of Extend.
+func (mr *MockUserMatchDaoMockRecorder) Extend(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockUserMatchDao)(nil).Extend), arg0, arg1, arg2, arg3, arg4)
+}
+
 // FindByUserId mocks base method.
 func (m *MockUserMatchDao) FindByUserId(arg0 context.Context, arg1 int) ([]model.UserMatch, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +125,21 @@ func (mr *MockUserMatchDaoMockRecorder) FindByUserIdMatchId(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIdMatchId", reflect.TypeOf((*MockUserMatchDao)(nil).FindByUserIdMatchId), arg0, arg1, arg2)
 }
 
+// FindExpiringUnnotified mocks base method.
+func (m *MockUserMatchDao) FindExpiringUnnotified(arg0 context.Context, arg1, arg2 time.Time, arg3 int) ([]model.UserMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiringUnnotified", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.UserMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiringUnnotified indicates an expected call of FindExpiringUnnotified.
+func (mr *MockUserMatchDaoMockRecorder) FindExpiringUnnotified(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiringUnnotified", reflect.TypeOf((*MockUserMatchDao)(nil).FindExpiringUnnotified), arg0, arg1, arg2, arg3)
+}
+
 // Insert mocks base method.
 func (m *MockUserMatchDao) Insert(arg0 context.Context, arg1 model.UserMatch) (model.UserMatch, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +169,62 @@ func (mr *MockUserMatchDaoMockRecorder) InsertMany(arg0, arg1 interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMany", reflect.TypeOf((*MockUserMatchDao)(nil).InsertMany), arg0, arg1)
 }
+
+// InsertPair mocks base method.
+func (m *MockUserMatchDao) InsertPair(arg0 context.Context, arg1, arg2 model.UserMatch) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPair", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertPair indicates an expected call of InsertPair.
+func (mr *MockUserMatchDaoMockRecorder) InsertPair(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPair", reflect.TypeOf((*MockUserMatchDao)(nil).InsertPair), arg0, arg1, arg2)
+}
+
+// MarkExpiryNotified mocks base method.
+func (m *MockUserMatchDao) MarkExpiryNotified(arg0 context.Context, arg1 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkExpiryNotified", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkExpiryNotified indicates an expected call of MarkExpiryNotified.
+func (mr *MockUserMatchDaoMockRecorder) MarkExpiryNotified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExpiryNotified", reflect.TypeOf((*MockUserMatchDao)(nil).MarkExpiryNotified), arg0, arg1)
+}
+
+// MarkFirstMessage mocks base method.
+func (m *MockUserMatchDao) MarkFirstMessage(arg0 context.Context, arg1, arg2 int, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFirstMessage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFirstMessage indicates an expected call of MarkFirstMessage.
+func (mr *MockUserMatchDaoMockRecorder) MarkFirstMessage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFirstMessage", reflect.TypeOf((*MockUserMatchDao)(nil).MarkFirstMessage), arg0, arg1, arg2, arg3)
+}
+
+// OpenFirstMove mocks base method.
+func (m *MockUserMatchDao) OpenFirstMove(arg0 context.Context, arg1, arg2 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFirstMove", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenFirstMove indicates an expected call of OpenFirstMove.
+func (mr *MockUserMatchDaoMockRecorder) OpenFirstMove(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFirstMove", reflect.TypeOf((*MockUserMatchDao)(nil).OpenFirstMove), arg0, arg1, arg2)
+}
@@ -80,6 +80,36 @@ func (mr *MockUserMediaRepositoryMockRecorder) FindByIdAndUserId(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdAndUserId", reflect.TypeOf((*MockUserMediaRepository)(nil).FindByIdAndUserId), arg0, arg1, arg2)
 }
 
+// FindByUserIDOrderID mocks base method.
+func (m *MockUserMediaRepository) FindByUserIDOrderID(arg0, arg1 int) (model.UserMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserIDOrderID", arg0, arg1)
+	ret0, _ := ret[0].(model.UserMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserIDOrderID indicates an expected call of FindByUserIDOrderID.
+func (mr *MockUserMediaRepositoryMockRecorder) FindByUserIDOrderID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDOrderID", reflect.TypeOf((*MockUserMediaRepository)(nil).FindByUserIDOrderID), arg0, arg1)
+}
+
+// FindByUserIDs mocks base method.
+func (m *MockUserMediaRepository) FindByUserIDs(arg0 []int) ([]model.UserMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserIDs", arg0)
+	ret0, _ := ret[0].([]model.UserMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserIDs indicates an expected call of FindByUserIDs.
+func (mr *MockUserMediaRepositoryMockRecorder) FindByUserIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDs", reflect.TypeOf((*MockUserMediaRepository)(nil).FindByUserIDs), arg0)
+}
+
 // FindByUserId mocks base method.
 func (m *MockUserMediaRepository) FindByUserId(arg0 context.Context, arg1 int) ([]model.UserMedia, error) {
 	m.ctrl.T.Helper()
@@ -96,26 +126,27 @@ func (mr *MockUserMediaRepositoryMockRecorder) FindByUserId(arg0, arg1 interface
 }
 
 // FindFirstGroupByUserID mocks base method.
-func (m *MockUserMediaRepository) FindFirstGroupByUserID(arg0 context.Context, arg1 []int) ([]dao.UserMatchUserMediaDTO, error) {
+func (m *MockUserMediaRepository) FindFirstGroupByUserID(arg0 context.Context, arg1 int, arg2 []int) ([]dao.UserMatchUserMediaDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindFirstGroupByUserID", arg0, arg1)
+	ret := m.ctrl.Call(m, "FindFirstGroupByUserID", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]dao.UserMatchUserMediaDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindFirstGroupByUserID indicates an expected call of FindFirstGroupByUserID.
-func (mr *MockUserMediaRepositoryMockRecorder) FindFirstGroupByUserID(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserMediaRepositoryMockRecorder) FindFirstGroupByUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFirstGroupByUserID", reflect.TypeOf((*MockUserMediaRepository)(nil).FindFirstGroupByUserID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFirstGroupByUserID", reflect.TypeOf((*MockUserMediaRepository)(nil).FindFirstGroupByUserID), arg0, arg1, arg2)
 }
 
 // Insert mocks base method.
-func (m *MockUserMediaRepository) Insert(arg0 context.Context, arg1 model.UserMedia) error {
+func (m *MockUserMediaRepository) Insert(arg0 context.Context, arg1 model.UserMedia) (model.UserMedia, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Insert", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(model.UserMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Insert indicates an expected call of Insert.
@@ -123,3 +154,18 @@ func (mr *MockUserMediaRepositoryMockRecorder) Insert(arg0, arg1 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserMediaRepository)(nil).Insert), arg0, arg1)
 }
+
+// UpdateProfileMedia mocks base method.
+func (m *MockUserMediaRepository) UpdateProfileMedia(arg0 context.Context, arg1 model.UserMedia, arg2 int) (model.UserMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfileMedia", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.UserMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfileMedia indicates an expected call of UpdateProfileMedia.
+func (mr *MockUserMediaRepositoryMockRecorder) UpdateProfileMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfileMedia", reflect.TypeOf((*MockUserMediaRepository)(nil).UpdateProfileMedia), arg0, arg1, arg2)
+}
@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/db/dao (interfaces: UserRecoveryCodeRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserRecoveryCodeRepository is a mock of UserRecoveryCodeRepository interface.
+type MockUserRecoveryCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRecoveryCodeRepositoryMockRecorder
+}
+
+// MockUserRecoveryCodeRepositoryMockRecorder is the mock recorder for MockUserRecoveryCodeRepository.
+type MockUserRecoveryCodeRepositoryMockRecorder struct {
+	mock *MockUserRecoveryCodeRepository
+}
+
+// NewMockUserRecoveryCodeRepository creates a new mock instance.
+func NewMockUserRecoveryCodeRepository(ctrl *gomock.Controller) *MockUserRecoveryCodeRepository {
+	mock := &MockUserRecoveryCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockUserRecoveryCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRecoveryCodeRepository) EXPECT() *MockUserRecoveryCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockUserRecoveryCodeRepository) Consume(arg0 int, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockUserRecoveryCodeRepositoryMockRecorder) Consume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockUserRecoveryCodeRepository)(nil).Consume), arg0, arg1)
+}
+
+// DeleteByUserId mocks base method.
+func (m *MockUserRecoveryCodeRepository) DeleteByUserId(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserId", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserId indicates an expected call of DeleteByUserId.
+func (mr *MockUserRecoveryCodeRepositoryMockRecorder) DeleteByUserId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserId", reflect.TypeOf((*MockUserRecoveryCodeRepository)(nil).DeleteByUserId), arg0)
+}
+
+// ReplaceAll mocks base method.
+func (m *MockUserRecoveryCodeRepository) ReplaceAll(arg0 int, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceAll indicates an expected call of ReplaceAll.
+func (mr *MockUserRecoveryCodeRepositoryMockRecorder) ReplaceAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAll", reflect.TypeOf((*MockUserRecoveryCodeRepository)(nil).ReplaceAll), arg0, arg1)
+}
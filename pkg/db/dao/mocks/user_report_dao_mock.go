@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/db/dao (interfaces: UserReportRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/SuperMatch/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserReportRepository is a mock of UserReportRepository interface.
+type MockUserReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserReportRepositoryMockRecorder
+}
+
+// MockUserReportRepositoryMockRecorder is the mock recorder for MockUserReportRepository.
+type MockUserReportRepositoryMockRecorder struct {
+	mock *MockUserReportRepository
+}
+
+// NewMockUserReportRepository creates a new mock instance.
+func NewMockUserReportRepository(ctrl *gomock.Controller) *MockUserReportRepository {
+	mock := &MockUserReportRepository{ctrl: ctrl}
+	mock.recorder = &MockUserReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserReportRepository) EXPECT() *MockUserReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountOpenByReportedId mocks base method.
+func (m *MockUserReportRepository) CountOpenByReportedId(arg0 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByReportedId", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByReportedId indicates an expected call of CountOpenByReportedId.
+func (mr *MockUserReportRepositoryMockRecorder) CountOpenByReportedId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByReportedId", reflect.TypeOf((*MockUserReportRepository)(nil).CountOpenByReportedId), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockUserReportRepository) FindById(arg0 uint) (model.UserReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0)
+	ret0, _ := ret[0].(model.UserReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockUserReportRepositoryMockRecorder) FindById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserReportRepository)(nil).FindById), arg0)
+}
+
+// FindByStatus mocks base method.
+func (m *MockUserReportRepository) FindByStatus(arg0 string, arg1, arg2 int) ([]model.UserReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.UserReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByStatus indicates an expected call of FindByStatus.
+func (mr *MockUserReportRepositoryMockRecorder) FindByStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockUserReportRepository)(nil).FindByStatus), arg0, arg1, arg2)
+}
+
+// FindOpenByContent mocks base method.
+func (m *MockUserReportRepository) FindOpenByContent(arg0, arg1 string) ([]model.UserReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenByContent", arg0, arg1)
+	ret0, _ := ret[0].([]model.UserReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenByContent indicates an expected call of FindOpenByContent.
+func (mr *MockUserReportRepositoryMockRecorder) FindOpenByContent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenByContent", reflect.TypeOf((*MockUserReportRepository)(nil).FindOpenByContent), arg0, arg1)
+}
+
+// FindOpenByReporter mocks base method.
+func (m *MockUserReportRepository) FindOpenByReporter(arg0 int, arg1, arg2 string) (model.UserReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenByReporter", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.UserReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenByReporter indicates an expected call of FindOpenByReporter.
+func (mr *MockUserReportRepositoryMockRecorder) FindOpenByReporter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenByReporter", reflect.TypeOf((*MockUserReportRepository)(nil).FindOpenByReporter), arg0, arg1, arg2)
+}
+
+// Insert mocks base method.
+func (m *MockUserReportRepository) Insert(arg0 model.UserReport) (model.UserReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", arg0)
+	ret0, _ := ret[0].(model.UserReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockUserReportRepositoryMockRecorder) Insert(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserReportRepository)(nil).Insert), arg0)
+}
+
+// Resolve mocks base method.
+func (m *MockUserReportRepository) Resolve(arg0 []uint, arg1, arg2 string, arg3 int, arg4 string) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockUserReportRepositoryMockRecorder) Resolve(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockUserReportRepository)(nil).Resolve), arg0, arg1, arg2, arg3, arg4)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/SuperMatch/model"
 	gomock "github.com/golang/mock/gomock"
@@ -80,6 +81,50 @@ func (mr *MockUserSearchProfileRepositoryMockRecorder) FindByUserId(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockUserSearchProfileRepository)(nil).FindByUserId), arg0, arg1)
 }
 
+// FindPausedProfileIds mocks base method.
+func (m *MockUserSearchProfileRepository) FindPausedProfileIds(arg0 context.Context, arg1 []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPausedProfileIds", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPausedProfileIds indicates an expected call of FindPausedProfileIds.
+func (mr *MockUserSearchProfileRepositoryMockRecorder) FindPausedProfileIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPausedProfileIds", reflect.TypeOf((*MockUserSearchProfileRepository)(nil).FindPausedProfileIds), arg0, arg1)
+}
+
+// FindPausedUserIds mocks base method.
+func (m *MockUserSearchProfileRepository) FindPausedUserIds(arg0 context.Context, arg1 []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPausedUserIds", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPausedUserIds indicates an expected call of FindPausedUserIds.
+func (mr *MockUserSearchProfileRepositoryMockRecorder) FindPausedUserIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPausedUserIds", reflect.TypeOf((*MockUserSearchProfileRepository)(nil).FindPausedUserIds), arg0, arg1)
+}
+
+// UpdatePause mocks base method.
+func (m *MockUserSearchProfileRepository) UpdatePause(arg0 context.Context, arg1 int, arg2 bool, arg3 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePause", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePause indicates an expected call of UpdatePause.
+func (mr *MockUserSearchProfileRepositoryMockRecorder) UpdatePause(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePause", reflect.TypeOf((*MockUserSearchProfileRepository)(nil).UpdatePause), arg0, arg1, arg2, arg3)
+}
+
 // UpdateUserSearchProfile mocks base method.
 func (m *MockUserSearchProfileRepository) UpdateUserSearchProfile(arg0 context.Context, arg1 map[string]interface{}) (model.UserSearchProfile, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/db/dao (interfaces: UserSwipeRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/SuperMatch/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserSwipeRepository is a mock of UserSwipeRepository interface.
+type MockUserSwipeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSwipeRepositoryMockRecorder
+}
+
+// MockUserSwipeRepositoryMockRecorder is the mock recorder for MockUserSwipeRepository.
+type MockUserSwipeRepositoryMockRecorder struct {
+	mock *MockUserSwipeRepository
+}
+
+// NewMockUserSwipeRepository creates a new mock instance.
+func NewMockUserSwipeRepository(ctrl *gomock.Controller) *MockUserSwipeRepository {
+	mock := &MockUserSwipeRepository{ctrl: ctrl}
+	mock.recorder = &MockUserSwipeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSwipeRepository) EXPECT() *MockUserSwipeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockUserSwipeRepository) Delete(arg0 model.UserSwipe) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserSwipeRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserSwipeRepository)(nil).Delete), arg0)
+}
+
+// FindByUserIdTargetId mocks base method.
+func (m *MockUserSwipeRepository) FindByUserIdTargetId(arg0, arg1 int) (model.UserSwipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserIdTargetId", arg0, arg1)
+	ret0, _ := ret[0].(model.UserSwipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserIdTargetId indicates an expected call of FindByUserIdTargetId.
+func (mr *MockUserSwipeRepositoryMockRecorder) FindByUserIdTargetId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIdTargetId", reflect.TypeOf((*MockUserSwipeRepository)(nil).FindByUserIdTargetId), arg0, arg1)
+}
+
+// FindLatestByUserId mocks base method.
+func (m *MockUserSwipeRepository) FindLatestByUserId(arg0 int) (model.UserSwipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestByUserId", arg0)
+	ret0, _ := ret[0].(model.UserSwipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestByUserId indicates an expected call of FindLatestByUserId.
+func (mr *MockUserSwipeRepositoryMockRecorder) FindLatestByUserId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestByUserId", reflect.TypeOf((*MockUserSwipeRepository)(nil).FindLatestByUserId), arg0)
+}
+
+// FindPendingLikers mocks base method.
+func (m *MockUserSwipeRepository) FindPendingLikers(arg0 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingLikers", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingLikers indicates an expected call of FindPendingLikers.
+func (mr *MockUserSwipeRepositoryMockRecorder) FindPendingLikers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingLikers", reflect.TypeOf((*MockUserSwipeRepository)(nil).FindPendingLikers), arg0)
+}
+
+// FindSuperLikers mocks base method.
+func (m *MockUserSwipeRepository) FindSuperLikers(arg0 int, arg1 []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSuperLikers", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSuperLikers indicates an expected call of FindSuperLikers.
+func (mr *MockUserSwipeRepositoryMockRecorder) FindSuperLikers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSuperLikers", reflect.TypeOf((*MockUserSwipeRepository)(nil).FindSuperLikers), arg0, arg1)
+}
+
+// InsertMissing mocks base method.
+func (m *MockUserSwipeRepository) InsertMissing(arg0 []model.UserSwipe) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMissing", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertMissing indicates an expected call of InsertMissing.
+func (mr *MockUserSwipeRepositoryMockRecorder) InsertMissing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMissing", reflect.TypeOf((*MockUserSwipeRepository)(nil).InsertMissing), arg0)
+}
+
+// Upsert mocks base method.
+func (m *MockUserSwipeRepository) Upsert(arg0 model.UserSwipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockUserSwipeRepositoryMockRecorder) Upsert(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockUserSwipeRepository)(nil).Upsert), arg0)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/SuperMatch/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,36 @@ func (m *MockUserTokenRepository) EXPECT() *MockUserTokenRepositoryMockRecorder
 	return m.recorder
 }
 
+// DeactivateToken mocks base method.
+func (m *MockUserTokenRepository) DeactivateToken(arg0 context.Context, arg1 uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateToken", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateToken indicates an expected call of DeactivateToken.
+func (mr *MockUserTokenRepositoryMockRecorder) DeactivateToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateToken", reflect.TypeOf((*MockUserTokenRepository)(nil).DeactivateToken), arg0, arg1)
+}
+
+// FindByRefreshToken mocks base method.
+func (m *MockUserTokenRepository) FindByRefreshToken(arg0 context.Context, arg1 string) (model.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(model.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByRefreshToken indicates an expected call of FindByRefreshToken.
+func (mr *MockUserTokenRepositoryMockRecorder) FindByRefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByRefreshToken", reflect.TypeOf((*MockUserTokenRepository)(nil).FindByRefreshToken), arg0, arg1)
+}
+
 // FindByToken mocks base method.
 func (m *MockUserTokenRepository) FindByToken(arg0 context.Context, arg1 string) (model.UserToken, error) {
 	m.ctrl.T.Helper()
@@ -66,10 +97,10 @@ func (mr *MockUserTokenRepositoryMockRecorder) FindByTokenAndUserId(arg0, arg1,
 }
 
 // FindByUserId mocks base method.
-func (m *MockUserTokenRepository) FindByUserId(arg0 context.Context, arg1 int64) (model.UserToken, error) {
+func (m *MockUserTokenRepository) FindByUserId(arg0 context.Context, arg1 int) ([]model.UserToken, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindByUserId", arg0, arg1)
-	ret0, _ := ret[0].(model.UserToken)
+	ret0, _ := ret[0].([]model.UserToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -108,3 +139,46 @@ func (mr *MockUserTokenRepositoryMockRecorder) RemoveSession(arg0, arg1 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSession", reflect.TypeOf((*MockUserTokenRepository)(nil).RemoveSession), arg0, arg1)
 }
+
+// RemoveSessionByFamily mocks base method.
+func (m *MockUserTokenRepository) RemoveSessionByFamily(arg0 context.Context, arg1 int, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSessionByFamily", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveSessionByFamily indicates an expected call of RemoveSessionByFamily.
+func (mr *MockUserTokenRepositoryMockRecorder) RemoveSessionByFamily(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSessionByFamily", reflect.TypeOf((*MockUserTokenRepository)(nil).RemoveSessionByFamily), arg0, arg1, arg2)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockUserTokenRepository) RevokeFamily(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockUserTokenRepositoryMockRecorder) RevokeFamily(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockUserTokenRepository)(nil).RevokeFamily), arg0, arg1)
+}
+
+// TouchToken mocks base method.
+func (m *MockUserTokenRepository) TouchToken(arg0 context.Context, arg1 uint, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchToken indicates an expected call of TouchToken.
+func (mr *MockUserTokenRepositoryMockRecorder) TouchToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchToken", reflect.TypeOf((*MockUserTokenRepository)(nil).TouchToken), arg0, arg1, arg2)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/db/dao (interfaces: UserTOTPRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/SuperMatch/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserTOTPRepository is a mock of UserTOTPRepository interface.
+type MockUserTOTPRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserTOTPRepositoryMockRecorder
+}
+
+// MockUserTOTPRepositoryMockRecorder is the mock recorder for MockUserTOTPRepository.
+type MockUserTOTPRepositoryMockRecorder struct {
+	mock *MockUserTOTPRepository
+}
+
+// NewMockUserTOTPRepository creates a new mock instance.
+func NewMockUserTOTPRepository(ctrl *gomock.Controller) *MockUserTOTPRepository {
+	mock := &MockUserTOTPRepository{ctrl: ctrl}
+	mock.recorder = &MockUserTOTPRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserTOTPRepository) EXPECT() *MockUserTOTPRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockUserTOTPRepository) Confirm(arg0 uint, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockUserTOTPRepositoryMockRecorder) Confirm(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockUserTOTPRepository)(nil).Confirm), arg0, arg1)
+}
+
+// DeleteByUserId mocks base method.
+func (m *MockUserTOTPRepository) DeleteByUserId(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserId", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserId indicates an expected call of DeleteByUserId.
+func (mr *MockUserTOTPRepositoryMockRecorder) DeleteByUserId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserId", reflect.TypeOf((*MockUserTOTPRepository)(nil).DeleteByUserId), arg0)
+}
+
+// FindByUserId mocks base method.
+func (m *MockUserTOTPRepository) FindByUserId(arg0 int) (model.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserId", arg0)
+	ret0, _ := ret[0].(model.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserId indicates an expected call of FindByUserId.
+func (mr *MockUserTOTPRepositoryMockRecorder) FindByUserId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockUserTOTPRepository)(nil).FindByUserId), arg0)
+}
+
+// Replace mocks base method.
+func (m *MockUserTOTPRepository) Replace(arg0 model.UserTOTP) (model.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", arg0)
+	ret0, _ := ret[0].(model.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockUserTOTPRepositoryMockRecorder) Replace(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockUserTOTPRepository)(nil).Replace), arg0)
+}
+
+// UseStep mocks base method.
+func (m *MockUserTOTPRepository) UseStep(arg0 uint, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseStep", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseStep indicates an expected call of UseStep.
+func (mr *MockUserTOTPRepositoryMockRecorder) UseStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStep", reflect.TypeOf((*MockUserTOTPRepository)(nil).UseStep), arg0, arg1)
+}
@@ -34,19 +34,48 @@ func (m *MockUserVerificationOTPRepository) EXPECT() *MockUserVerificationOTPRep
 	return m.recorder
 }
 
-// FindByPhoneAndOTP mocks base method.
-func (m *MockUserVerificationOTPRepository) FindByPhoneAndOTP(arg0, arg1 string) (model.UserVerificationOTP, error) {
+// Consume mocks base method.
+func (m *MockUserVerificationOTPRepository) Consume(arg0 uint) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByPhoneAndOTP", arg0, arg1)
+	ret := m.ctrl.Call(m, "Consume", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockUserVerificationOTPRepositoryMockRecorder) Consume(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockUserVerificationOTPRepository)(nil).Consume), arg0)
+}
+
+// ConsumeAllByPhone mocks base method.
+func (m *MockUserVerificationOTPRepository) ConsumeAllByPhone(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeAllByPhone", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeAllByPhone indicates an expected call of ConsumeAllByPhone.
+func (mr *MockUserVerificationOTPRepositoryMockRecorder) ConsumeAllByPhone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeAllByPhone", reflect.TypeOf((*MockUserVerificationOTPRepository)(nil).ConsumeAllByPhone), arg0)
+}
+
+// FindLatestByPhone mocks base method.
+func (m *MockUserVerificationOTPRepository) FindLatestByPhone(arg0 string) (model.UserVerificationOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestByPhone", arg0)
 	ret0, _ := ret[0].(model.UserVerificationOTP)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByPhoneAndOTP indicates an expected call of FindByPhoneAndOTP.
-func (mr *MockUserVerificationOTPRepositoryMockRecorder) FindByPhoneAndOTP(arg0, arg1 interface{}) *gomock.Call {
+// FindLatestByPhone indicates an expected call of FindLatestByPhone.
+func (mr *MockUserVerificationOTPRepositoryMockRecorder) FindLatestByPhone(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPhoneAndOTP", reflect.TypeOf((*MockUserVerificationOTPRepository)(nil).FindByPhoneAndOTP), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestByPhone", reflect.TypeOf((*MockUserVerificationOTPRepository)(nil).FindLatestByPhone), arg0)
 }
 
 // Insert mocks base method.
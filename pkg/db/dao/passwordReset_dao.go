@@ -0,0 +1,48 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/zapLogger"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package mocks -destination mocks/password_reset_dao_mock.go github.com/SuperMatch/pkg/db/dao PasswordResetRepository
+type PasswordResetRepository interface {
+	Insert(passwordReset model.PasswordReset) error
+	FindByTokenHash(tokenHash string) (model.PasswordReset, error)
+	Consume(id uint) (bool, error)
+	ConsumeAllByUserId(userId int) error
+}
+
+type PasswordResetDao struct {
+	Connection gorm.DB
+}
+
+func (p *PasswordResetDao) Insert(passwordReset model.PasswordReset) error {
+	tx := p.Connection.Table("password_reset").Create(&passwordReset)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in inserting password reset in password_reset table")
+		return tx.Error
+	}
+
+	return nil
+}
+
+func (p *PasswordResetDao) FindByTokenHash(tokenHash string) (model.PasswordReset, error) {
+	var passwordReset model.PasswordReset
+	tx := p.Connection.Table("password_reset").Where("token_hash = ?", tokenHash).First(&passwordReset)
+	return passwordReset, tx.Error
+}
+
+// Consume marks a reset token as used and reports whether this call was the one that used it.
+func (p *PasswordResetDao) Consume(id uint) (bool, error) {
+	tx := p.Connection.Table("password_reset").Where("id = ? and used_at IS NULL", id).UpdateColumn("used_at", time.Now())
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (p *PasswordResetDao) ConsumeAllByUserId(userId int) error {
+	tx := p.Connection.Table("password_reset").Where("user_id = ? and used_at IS NULL", userId).UpdateColumn("used_at", time.Now())
+	return tx.Error
+}
@@ -0,0 +1,85 @@
+package dao
+
+import (
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -package mocks -destination mocks/user_block_dao_mock.go github.com/SuperMatch/pkg/db/dao UserBlockRepository
+type UserBlockRepository interface {
+	Insert(block model.UserBlock) (bool, error)
+	Delete(userId, blockedId int) (bool, error)
+	FindByUserId(userId int) ([]model.UserBlock, error)
+	FindBlockedUserIds(userId int) ([]int, error)
+	FindBlockedProfileIds(userId int) ([]int, error)
+	IsBlocked(userId, otherId int) (bool, error)
+}
+
+type UserBlockDao struct {
+	Connection gorm.DB
+}
+
+func NewUserBlockRepository() UserBlockRepository {
+	return &UserBlockDao{Connection: *db.GlobalOrm}
+}
+
+// Insert records the block and reports whether it is new.
+func (u *UserBlockDao) Insert(block model.UserBlock) (bool, error) {
+	tx := u.Connection.Clauses(clause.OnConflict{DoNothing: true}).Create(&block)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in inserting user block", zap.Error(tx.Error))
+	}
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// Delete removes the block of the user on blockedId and reports whether there was one.
+func (u *UserBlockDao) Delete(userId, blockedId int) (bool, error) {
+	tx := u.Connection.Where("user_id = ? and blocked_id = ?", userId, blockedId).Delete(&model.UserBlock{})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in deleting user block", zap.Error(tx.Error))
+	}
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// FindByUserId returns the blocks made by the user, latest first.
+func (u *UserBlockDao) FindByUserId(userId int) ([]model.UserBlock, error) {
+	blocks := make([]model.UserBlock, 0)
+	tx := u.Connection.Where("user_id = ?", userId).Order("created_at desc").Find(&blocks)
+	return blocks, tx.Error
+}
+
+// FindBlockedUserIds returns the users the user blocked or was blocked by.
+func (u *UserBlockDao) FindBlockedUserIds(userId int) ([]int, error) {
+	userIds := make([]int, 0)
+	tx := u.Connection.Raw("select blocked_id from user_blocks where user_id = ? union select user_id from user_blocks where blocked_id = ?", userId, userId).
+		Scan(&userIds)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in finding blocked users", zap.Error(tx.Error))
+	}
+	return userIds, tx.Error
+}
+
+// FindBlockedProfileIds returns the profiles of the users the user blocked or was blocked by.
+func (u *UserBlockDao) FindBlockedProfileIds(userId int) ([]int, error) {
+	profileIds := make([]int, 0)
+	tx := u.Connection.Model(&model.UserProfile{}).
+		Where("user_id in (select blocked_id from user_blocks where user_id = ?) or user_id in (select user_id from user_blocks where blocked_id = ?)", userId, userId).
+		Pluck("ID", &profileIds)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in finding blocked profiles", zap.Error(tx.Error))
+	}
+	return profileIds, tx.Error
+}
+
+// IsBlocked reports whether either user blocked the other.
+func (u *UserBlockDao) IsBlocked(userId, otherId int) (bool, error) {
+	var count int64
+	tx := u.Connection.Model(&model.UserBlock{}).
+		Where("(user_id = ? and blocked_id = ?) or (user_id = ? and blocked_id = ?)", userId, otherId, otherId, userId).
+		Count(&count)
+	return count > 0, tx.Error
+}
@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"errors"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -package mocks -destination mocks/user_identity_dao_mock.go github.com/SuperMatch/pkg/db/dao UserIdentityRepository
+type UserIdentityRepository interface {
+	Insert(identity model.UserIdentity) (model.UserIdentity, error)
+	InsertWithUser(user model.User, identity model.UserIdentity) (model.User, bool, error)
+	FindByProviderSubject(provider string, subject string) (model.UserIdentity, error)
+	FindByUserId(userId int) ([]model.UserIdentity, error)
+	UpdateSubject(id uint, subject string) error
+	Delete(userId int, id uint) (bool, error)
+	DeleteByUserId(userId int) error
+}
+
+var errIdentityLinked = errors.New("user identity is already linked")
+
+type UserIdentityDao struct {
+	Connection gorm.DB
+}
+
+func (u *UserIdentityDao) Insert(identity model.UserIdentity) (model.UserIdentity, error) {
+	tx := u.Connection.Create(&identity)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in inserting user identity", zap.Error(tx.Error))
+		return identity, tx.Error
+	}
+
+	return identity, nil
+}
+
+// InsertWithUser creates the user with the identity as its first sign in method, in one transaction
+// so no user is left without one. It reports whether they were created; when the identity was linked
+// to another account meanwhile nothing is created.
+func (u *UserIdentityDao) InsertWithUser(user model.User, identity model.UserIdentity) (model.User, bool, error) {
+	err := u.Connection.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+
+		identity.UserId = int(user.ID)
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&identity)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// rolls the user back
+			return errIdentityLinked
+		}
+
+		return nil
+	})
+	if errors.Is(err, errIdentityLinked) {
+		return model.User{}, false, nil
+	}
+	if err != nil {
+		zapLogger.Logger.Error("error in inserting user with identity", zap.Error(err))
+		return model.User{}, false, err
+	}
+
+	return user, true, nil
+}
+
+func (u *UserIdentityDao) FindByProviderSubject(provider string, subject string) (model.UserIdentity, error) {
+	var identity model.UserIdentity
+	tx := u.Connection.Where("provider = ? and subject = ?", provider, subject).First(&identity)
+	return identity, tx.Error
+}
+
+func (u *UserIdentityDao) FindByUserId(userId int) ([]model.UserIdentity, error) {
+	var identities []model.UserIdentity
+	tx := u.Connection.Where("user_id = ?", userId).Order("ID").Find(&identities)
+	return identities, tx.Error
+}
+
+func (u *UserIdentityDao) UpdateSubject(id uint, subject string) error {
+	tx := u.Connection.Model(&model.UserIdentity{}).Where("ID = ?", id).Update("subject", subject)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in updating user identity subject", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
+
+// Delete removes an identity of the user and reports whether it existed. The row is deleted for
+// good so the sign in method can be linked to another account afterwards.
+func (u *UserIdentityDao) Delete(userId int, id uint) (bool, error) {
+	tx := u.Connection.Unscoped().Where("user_id = ? and ID = ?", userId, id).Delete(&model.UserIdentity{})
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (u *UserIdentityDao) DeleteByUserId(userId int) error {
+	tx := u.Connection.Unscoped().Where("user_id = ?", userId).Delete(&model.UserIdentity{})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in deleting user identities", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
@@ -0,0 +1,55 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package mocks -destination mocks/user_recovery_code_dao_mock.go github.com/SuperMatch/pkg/db/dao UserRecoveryCodeRepository
+type UserRecoveryCodeRepository interface {
+	ReplaceAll(userId int, codeHashes []string) error
+	Consume(userId int, codeHash string) (bool, error)
+	DeleteByUserId(userId int) error
+}
+
+type UserRecoveryCodeDao struct {
+	Connection gorm.DB
+}
+
+// ReplaceAll swaps every recovery code of the user for a new set.
+func (u *UserRecoveryCodeDao) ReplaceAll(userId int, codeHashes []string) error {
+	codes := make([]model.UserRecoveryCode, 0, len(codeHashes))
+	for _, codeHash := range codeHashes {
+		codes = append(codes, model.UserRecoveryCode{UserId: userId, CodeHash: codeHash})
+	}
+
+	err := u.Connection.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userId).Delete(&model.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in replacing recovery codes", zap.Error(err))
+	}
+	return err
+}
+
+// Consume marks an unused recovery code as used and reports whether this call was the one that used it.
+func (u *UserRecoveryCodeDao) Consume(userId int, codeHash string) (bool, error) {
+	tx := u.Connection.Model(&model.UserRecoveryCode{}).Where("user_id = ? and code_hash = ? and used_at IS NULL", userId, codeHash).
+		Limit(1).UpdateColumn("used_at", time.Now())
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (u *UserRecoveryCodeDao) DeleteByUserId(userId int) error {
+	tx := u.Connection.Unscoped().Where("user_id = ?", userId).Delete(&model.UserRecoveryCode{})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in deleting recovery codes", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
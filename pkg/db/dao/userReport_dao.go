@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -package mocks -destination mocks/user_report_dao_mock.go github.com/SuperMatch/pkg/db/dao UserReportRepository
+type UserReportRepository interface {
+	Insert(report model.UserReport) (model.UserReport, error)
+	FindById(id uint) (model.UserReport, error)
+	FindOpenByReporter(reporterId int, contentType, contentId string) (model.UserReport, error)
+	FindOpenByContent(contentType, contentId string) ([]model.UserReport, error)
+	FindByStatus(status string, limit, offset int) ([]model.UserReport, error)
+	CountOpenByReportedId(reportedId int) (int64, error)
+	Resolve(ids []uint, status, action string, moderatorId int, note string) ([]uint, error)
+}
+
+type UserReportDao struct {
+	Connection gorm.DB
+}
+
+func NewUserReportRepository() UserReportRepository {
+	return &UserReportDao{Connection: *db.GlobalOrm}
+}
+
+func (u *UserReportDao) Insert(report model.UserReport) (model.UserReport, error) {
+	tx := u.Connection.Create(&report)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in inserting user report", zap.Error(tx.Error))
+	}
+	return report, tx.Error
+}
+
+func (u *UserReportDao) FindById(id uint) (model.UserReport, error) {
+	var report model.UserReport
+	tx := u.Connection.Where("ID = ?", id).First(&report)
+	return report, tx.Error
+}
+
+// FindOpenByReporter returns the open report of the reporter about the content.
+func (u *UserReportDao) FindOpenByReporter(reporterId int, contentType, contentId string) (model.UserReport, error) {
+	var report model.UserReport
+	tx := u.Connection.Where("reporter_id = ? and content_type = ? and content_id = ? and status = ?", reporterId, contentType, contentId, model.ReportOpen).
+		First(&report)
+	return report, tx.Error
+}
+
+// FindOpenByContent returns every open report about the content.
+func (u *UserReportDao) FindOpenByContent(contentType, contentId string) ([]model.UserReport, error) {
+	reports := make([]model.UserReport, 0)
+	tx := u.Connection.Where("content_type = ? and content_id = ? and status = ?", contentType, contentId, model.ReportOpen).
+		Order("ID").Find(&reports)
+	return reports, tx.Error
+}
+
+// FindByStatus returns a page of the reports with the status, oldest first.
+func (u *UserReportDao) FindByStatus(status string, limit, offset int) ([]model.UserReport, error) {
+	reports := make([]model.UserReport, 0)
+	tx := u.Connection.Where("status = ?", status).Order("created_at, ID").Limit(limit).Offset(offset).Find(&reports)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in finding user reports", zap.Error(tx.Error))
+	}
+	return reports, tx.Error
+}
+
+func (u *UserReportDao) CountOpenByReportedId(reportedId int) (int64, error) {
+	var count int64
+	tx := u.Connection.Model(&model.UserReport{}).Where("reported_id = ? and status = ?", reportedId, model.ReportOpen).Count(&count)
+	return count, tx.Error
+}
+
+// Resolve closes the reports that are still open and returns the ids of the reports it closed, so a
+// report resolved concurrently is not resolved twice.
+func (u *UserReportDao) Resolve(ids []uint, status, action string, moderatorId int, note string) ([]uint, error) {
+	resolved := make([]uint, 0, len(ids))
+	err := u.Connection.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.UserReport{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("ID in ? and status = ?", ids, model.ReportOpen).Pluck("ID", &resolved).Error; err != nil {
+			return err
+		}
+		if len(resolved) == 0 {
+			return nil
+		}
+
+		return tx.Model(&model.UserReport{}).Where("ID in ?", resolved).Updates(map[string]interface{}{
+			"status":         status,
+			"action":         action,
+			"moderator_id":   moderatorId,
+			"moderator_note": note,
+			"resolved_at":    time.Now(),
+		}).Error
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in resolving user reports", zap.Error(err))
+		return nil, err
+	}
+	return resolved, nil
+}
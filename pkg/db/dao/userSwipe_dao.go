@@ -0,0 +1,109 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -package mocks -destination mocks/user_swipe_dao_mock.go github.com/SuperMatch/pkg/db/dao UserSwipeRepository
+type UserSwipeRepository interface {
+	Upsert(swipe model.UserSwipe) error
+	FindByUserIdTargetId(userId, targetId int) (model.UserSwipe, error)
+	FindPendingLikers(targetId int) ([]int, error)
+	FindSuperLikers(targetId int, userIds []int) ([]int, error)
+	FindLatestByUserId(userId int) (model.UserSwipe, error)
+	Delete(swipe model.UserSwipe) (bool, error)
+	InsertMissing(swipes []model.UserSwipe) (int64, error)
+}
+
+type UserSwipeDao struct {
+	Connection gorm.DB
+}
+
+func NewUserSwipeRepository() UserSwipeRepository {
+	return &UserSwipeDao{Connection: *db.GlobalOrm}
+}
+
+// Upsert records the swipe, replacing an earlier swipe of the user on the same target.
+func (u *UserSwipeDao) Upsert(swipe model.UserSwipe) error {
+	if swipe.SwipedAt.IsZero() {
+		swipe.SwipedAt = time.Now()
+	}
+
+	tx := u.Connection.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"swipe_type", "swiped_at"}),
+	}).Create(&swipe)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in recording swipe", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
+
+func (u *UserSwipeDao) FindByUserIdTargetId(userId, targetId int) (model.UserSwipe, error) {
+	var swipe model.UserSwipe
+	tx := u.Connection.Where("user_id = ? and target_id = ?", userId, targetId).First(&swipe)
+	return swipe, tx.Error
+}
+
+// FindPendingLikers returns the users who liked the target and have not been swiped on by the
+// target yet, latest first.
+func (u *UserSwipeDao) FindPendingLikers(targetId int) ([]int, error) {
+	likers := make([]int, 0)
+	tx := u.Connection.Model(&model.UserSwipe{}).
+		Where("target_id = ? and swipe_type in ?", targetId, []int{model.SwipeLike, model.SwipeSuperLike}).
+		Where("not exists (select 1 from user_swipes response where response.user_id = user_swipes.target_id and response.target_id = user_swipes.user_id)").
+		Order("swiped_at desc").Pluck("user_id", &likers)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in finding pending likers", zap.Error(tx.Error))
+	}
+	return likers, tx.Error
+}
+
+// FindSuperLikers returns which of the users super liked the target.
+func (u *UserSwipeDao) FindSuperLikers(targetId int, userIds []int) ([]int, error) {
+	superLikers := make([]int, 0)
+	if len(userIds) == 0 {
+		return superLikers, nil
+	}
+
+	tx := u.Connection.Model(&model.UserSwipe{}).
+		Where("target_id = ? and user_id in ? and swipe_type = ?", targetId, userIds, model.SwipeSuperLike).
+		Pluck("user_id", &superLikers)
+	return superLikers, tx.Error
+}
+
+// FindLatestByUserId returns the most recent swipe of the user.
+func (u *UserSwipeDao) FindLatestByUserId(userId int) (model.UserSwipe, error) {
+	var swipe model.UserSwipe
+	tx := u.Connection.Where("user_id = ?", userId).Order("swiped_at desc, ID desc").First(&swipe)
+	return swipe, tx.Error
+}
+
+// Delete removes the swipe unless it was replaced by a newer swipe, and reports whether it was removed.
+func (u *UserSwipeDao) Delete(swipe model.UserSwipe) (bool, error) {
+	tx := u.Connection.Where("ID = ? and swiped_at = ?", swipe.ID, swipe.SwipedAt).Delete(&model.UserSwipe{})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in deleting swipe", zap.Error(tx.Error))
+	}
+	return tx.RowsAffected == 1, tx.Error
+}
+
+// InsertMissing records the swipes the ledger does not have yet, keeping the ones it has, and returns
+// how many were added.
+func (u *UserSwipeDao) InsertMissing(swipes []model.UserSwipe) (int64, error) {
+	if len(swipes) == 0 {
+		return 0, nil
+	}
+
+	tx := u.Connection.Clauses(clause.OnConflict{DoNothing: true}).Create(&swipes)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in inserting missing swipes", zap.Error(tx.Error))
+	}
+	return tx.RowsAffected, tx.Error
+}
@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package mocks -destination mocks/user_totp_dao_mock.go github.com/SuperMatch/pkg/db/dao UserTOTPRepository
+type UserTOTPRepository interface {
+	FindByUserId(userId int) (model.UserTOTP, error)
+	Replace(userTOTP model.UserTOTP) (model.UserTOTP, error)
+	Confirm(id uint, step int64) (bool, error)
+	UseStep(id uint, step int64) (bool, error)
+	DeleteByUserId(userId int) error
+}
+
+type UserTOTPDao struct {
+	Connection gorm.DB
+}
+
+func (u *UserTOTPDao) FindByUserId(userId int) (model.UserTOTP, error) {
+	var userTOTP model.UserTOTP
+	tx := u.Connection.Where("user_id = ?", userId).First(&userTOTP)
+	return userTOTP, tx.Error
+}
+
+// Replace stores a new secret for the user in place of any earlier one.
+func (u *UserTOTPDao) Replace(userTOTP model.UserTOTP) (model.UserTOTP, error) {
+	err := u.Connection.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userTOTP.UserId).Delete(&model.UserTOTP{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&userTOTP).Error
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in replacing user totp", zap.Error(err))
+	}
+	return userTOTP, err
+}
+
+// Confirm enables an unconfirmed secret with the step of its first code and reports whether it was unconfirmed.
+func (u *UserTOTPDao) Confirm(id uint, step int64) (bool, error) {
+	tx := u.Connection.Model(&model.UserTOTP{}).Where("ID = ? and confirmed_at IS NULL", id).
+		Updates(map[string]interface{}{"confirmed_at": time.Now(), "last_used_step": step})
+	return tx.RowsAffected == 1, tx.Error
+}
+
+// UseStep records step as used and reports whether it was newer than every step used before.
+func (u *UserTOTPDao) UseStep(id uint, step int64) (bool, error) {
+	tx := u.Connection.Model(&model.UserTOTP{}).Where("ID = ? and last_used_step < ?", id, step).Update("last_used_step", step)
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (u *UserTOTPDao) DeleteByUserId(userId int) error {
+	tx := u.Connection.Unscoped().Where("user_id = ?", userId).Delete(&model.UserTOTP{})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in deleting user totp", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
@@ -11,10 +11,15 @@ import (
 //go:generate mockgen -package mocks -destination mocks/user_token_dao_mock.go github.com/SuperMatch/pkg/db/dao UserTokenRepository
 type UserTokenRepository interface {
 	Insert(ctx context.Context, userToken model.UserToken) (model.UserToken, error)
-	FindByUserId(ctx context.Context, userID int64) (model.UserToken, error)
+	FindByUserId(ctx context.Context, userId int) ([]model.UserToken, error)
 	FindByToken(ctx context.Context, token string) (model.UserToken, error)
 	RemoveSession(ctx context.Context, userId int64) error
+	RemoveSessionByFamily(ctx context.Context, userId int, familyID string) (bool, error)
+	TouchToken(ctx context.Context, id uint, lastUsedAt time.Time) error
 	FindByTokenAndUserId(ctx context.Context, token string, userId int) (model.UserToken, error)
+	FindByRefreshToken(ctx context.Context, refreshToken string) (model.UserToken, error)
+	DeactivateToken(ctx context.Context, id uint) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
 }
 
 type UserTokenDao struct {
@@ -27,9 +32,10 @@ func (d *UserTokenDao) Insert(ctx context.Context, userToken model.UserToken) (m
 	return userToken, tx.Error
 }
 
+// FindByUserId returns the active token of every session of the user whose refresh token has not expired.
 func (d *UserTokenDao) FindByUserId(ctx context.Context, userId int) ([]model.UserToken, error) {
 	var userToken []model.UserToken
-	err := d.Connection.Where("is_active = ?", true).Where("user_id = ?", userId).First(&userToken)
+	err := d.Connection.Where("is_active = ?", true).Where("user_id = ?", userId).Where("refresh_expires_at > ?", time.Now()).Order("last_used_at desc").Find(&userToken)
 	return userToken, err.Error
 }
 
@@ -40,7 +46,18 @@ func (d *UserTokenDao) FindByToken(ctx context.Context, token string) (model.Use
 }
 
 func (d *UserTokenDao) RemoveSession(ctx context.Context, userId int64) error {
-	err := d.Connection.Where("user_id = ?", userId).Where("is_active = ?", true).UpdateColumn("is_active", false)
+	err := d.Connection.Model(&model.UserToken{}).Where("user_id = ?", userId).Where("is_active = ?", true).UpdateColumn("is_active", false)
+	return err.Error
+}
+
+// RemoveSessionByFamily deactivates the session of the user identified by familyID and reports whether it was active.
+func (d *UserTokenDao) RemoveSessionByFamily(ctx context.Context, userId int, familyID string) (bool, error) {
+	tx := d.Connection.Model(&model.UserToken{}).Where("user_id = ?", userId).Where("family_id = ?", familyID).Where("is_active = ?", true).UpdateColumn("is_active", false)
+	return tx.RowsAffected > 0, tx.Error
+}
+
+func (d *UserTokenDao) TouchToken(ctx context.Context, id uint, lastUsedAt time.Time) error {
+	err := d.Connection.Model(&model.UserToken{}).Where("id = ?", id).UpdateColumn("last_used_at", lastUsedAt)
 	return err.Error
 }
 
@@ -49,3 +66,21 @@ func (d *UserTokenDao) FindByTokenAndUserId(ctx context.Context, token string, u
 	err := d.Connection.Debug().Where("is_active = ?", true).Where("token = ?", token).Where("user_id = ?", userId).Where("expires_at > ?", time.Now()).First(&userToken)
 	return userToken, err.Error
 }
+
+// FindByRefreshToken returns the token row for a hashed refresh token, whether or not it is still active.
+func (d *UserTokenDao) FindByRefreshToken(ctx context.Context, refreshToken string) (model.UserToken, error) {
+	var userToken model.UserToken
+	err := d.Connection.Where("refresh_token = ?", refreshToken).First(&userToken)
+	return userToken, err.Error
+}
+
+// DeactivateToken marks a token row inactive and reports whether this call was the one that deactivated it.
+func (d *UserTokenDao) DeactivateToken(ctx context.Context, id uint) (bool, error) {
+	tx := d.Connection.Model(&model.UserToken{}).Where("id = ?", id).Where("is_active = ?", true).UpdateColumn("is_active", false)
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (d *UserTokenDao) RevokeFamily(ctx context.Context, familyID string) error {
+	err := d.Connection.Model(&model.UserToken{}).Where("family_id = ?", familyID).Where("is_active = ?", true).UpdateColumn("is_active", false)
+	return err.Error
+}
@@ -1,8 +1,9 @@
 package dao
 
 import (
-	"errors"
 	"fmt"
+	"time"
+
 	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/zapLogger"
 	"gorm.io/gorm"
@@ -11,7 +12,9 @@ import (
 //go:generate mockgen -package mocks -destination mocks/user_verification_otp_dao_mock.go github.com/SuperMatch/pkg/db/dao UserVerificationOTPRepository
 type UserVerificationOTPRepository interface {
 	Insert(userOtp model.UserVerificationOTP) error
-	FindByPhoneAndOTP(phoneNumber string, otp string) (model.UserVerificationOTP, error)
+	FindLatestByPhone(phoneNumber string) (model.UserVerificationOTP, error)
+	Consume(id uint) (bool, error)
+	ConsumeAllByPhone(phoneNumber string) error
 }
 
 type UserVerificationOTPDao struct {
@@ -29,16 +32,25 @@ func (o *UserVerificationOTPDao) Insert(userOtp model.UserVerificationOTP) error
 	return nil
 }
 
-func (o *UserVerificationOTPDao) FindByPhoneAndOTP(phoneNumber string, otp string) (model.UserVerificationOTP, error) {
+// FindLatestByPhone returns the most recent OTP of the phone number that has not been consumed yet.
+func (o *UserVerificationOTPDao) FindLatestByPhone(phoneNumber string) (model.UserVerificationOTP, error) {
 	var userOtpDetails model.UserVerificationOTP
-	err := o.Connection.Table("user_verification_otp").Where("phone_number = ? and otp = ?", phoneNumber, otp).Find(&userOtpDetails)
-	if errors.Is(err.Error, gorm.ErrRecordNotFound) {
-		zapLogger.Logger.Error(fmt.Sprintf("No record found for otp: %s", otp))
-		return userOtpDetails, err.Error
-	} else if err.Error != nil {
+	err := o.Connection.Table("user_verification_otp").Where("phone_number = ? and consumed_at IS NULL", phoneNumber).Order("id desc").First(&userOtpDetails)
+	if err.Error != nil {
 		zapLogger.Logger.Error(fmt.Sprintf("error in getting otp details for phone number: %s", phoneNumber))
 		return userOtpDetails, err.Error
 	}
 
 	return userOtpDetails, nil
 }
+
+// Consume marks an OTP as used and reports whether this call was the one that consumed it.
+func (o *UserVerificationOTPDao) Consume(id uint) (bool, error) {
+	tx := o.Connection.Table("user_verification_otp").Where("id = ? and consumed_at IS NULL", id).UpdateColumn("consumed_at", time.Now())
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (o *UserVerificationOTPDao) ConsumeAllByPhone(phoneNumber string) error {
+	tx := o.Connection.Table("user_verification_otp").Where("phone_number = ? and consumed_at IS NULL", phoneNumber).UpdateColumn("consumed_at", time.Now())
+	return tx.Error
+}
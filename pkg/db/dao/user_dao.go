@@ -1,7 +1,6 @@
 package dao
 
 import (
-	"context"
 	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/zapLogger"
 	"go.uber.org/zap"
@@ -10,13 +9,15 @@ import (
 
 //go:generate mockgen -package mocks -destination mocks/user_dao_mock.go github.com/SuperMatch/pkg/db/dao UserRepository
 type UserRepository interface {
-	Insert(ctx context.Context, user model.User) (model.User, error)
-	FindById(ctx context.Context, id int64) (model.User, error)
-	FindByEmail(ctx context.Context, email string) (model.User, error)
+	Insert(user model.User) (model.User, error)
+	FindById(externalId int) (model.User, error)
+	FindByEmail(email string) (model.User, error)
 	FindByMobile(mobile string) (model.User, error)
 	FindByMobileEmail(mobile, email string) ([]model.User, error)
 	UpdateUser(user model.User) (model.User, error)
-	DeleteUser(userID int, email string) error
+	UpdatePassword(userID int, password string) error
+	UpdateRole(userID int, role string) (bool, error)
+	Suspend(userID int) (bool, error)
 }
 
 // type UserDao struct {
@@ -75,12 +76,34 @@ func (u *UserDao) UpdateUser(user model.User) (model.User, error) {
 	return user, nil
 }
 
-func (u *UserDao) DeleteUser(userID int, email string) error {
-	tx := u.Connection.Debug().Table("users").Where("ID = ?", userID).Update("email", email)
+func (u *UserDao) UpdatePassword(userID int, password string) error {
+	tx := u.Connection.Table("users").Where("is_active = ?", true).Where("ID = ?", userID).Update("password", password)
 	if tx.Error != nil {
-		zapLogger.Logger.Error("error deleting user", zap.Error(tx.Error))
+		zapLogger.Logger.Error("error updating user password", zap.Error(tx.Error))
 		return tx.Error
 	}
 
 	return nil
 }
+
+// UpdateRole changes the role of an active user and reports whether the user exists.
+func (u *UserDao) UpdateRole(userID int, role string) (bool, error) {
+	tx := u.Connection.Table("users").Where("is_active = ?", true).Where("ID = ?", userID).Update("role", role)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error updating user role", zap.Error(tx.Error))
+		return false, tx.Error
+	}
+
+	return tx.RowsAffected == 1, nil
+}
+
+// Suspend deactivates the user, who can no longer sign in, and reports whether the user was active.
+func (u *UserDao) Suspend(userID int) (bool, error) {
+	tx := u.Connection.Table("users").Where("is_active = ?", true).Where("ID = ?", userID).Update("is_active", false)
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error suspending user", zap.Error(tx.Error))
+		return false, tx.Error
+	}
+
+	return tx.RowsAffected == 1, nil
+}
@@ -7,9 +7,13 @@ import (
 	"gorm.io/gorm"
 )
 
+//go:generate mockgen -package mocks -destination mocks/user_device_token_dao_mock.go github.com/SuperMatch/pkg/db/dao UserDeviceTokenDao
 type UserDeviceTokenDao interface {
 	Insert(deviceToken model.UserDeviceToken) error
 	GetDeviceTokensByUserId(userId int) ([]model.UserDeviceToken, error)
+	GetDeviceTokensBySessionId(userId int, sessionID string) ([]model.UserDeviceToken, error)
+	UpdateSessionId(id uint, sessionID string) error
+	Delete(ids []uint) error
 }
 
 type UserDeviceTokenDaoImpl struct {
@@ -40,3 +44,38 @@ func (d *UserDeviceTokenDaoImpl) GetDeviceTokensByUserId(userId int) ([]model.Us
 
 	return deviceTokens, nil
 }
+
+func (d *UserDeviceTokenDaoImpl) GetDeviceTokensBySessionId(userId int, sessionID string) ([]model.UserDeviceToken, error) {
+	var deviceTokens []model.UserDeviceToken
+	err := d.Connection.Table("user_device_tokens").Where("user_id = ?", userId).Where("session_id = ?", sessionID).Find(&deviceTokens)
+	if err.Error != nil {
+		zapLogger.Logger.Error("error getting user device token of session in DB")
+		return nil, err.Error
+	}
+
+	return deviceTokens, nil
+}
+
+func (d *UserDeviceTokenDaoImpl) UpdateSessionId(id uint, sessionID string) error {
+	err := d.Connection.Table("user_device_tokens").Where("id = ?", id).Update("session_id", sessionID)
+	if err.Error != nil {
+		zapLogger.Logger.Error("error updating session of user device token in DB")
+		return err.Error
+	}
+
+	return nil
+}
+
+func (d *UserDeviceTokenDaoImpl) Delete(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := d.Connection.Table("user_device_tokens").Where("id IN ?", ids).Delete(&model.UserDeviceToken{})
+	if err.Error != nil {
+		zapLogger.Logger.Error("error deleting user device tokens in DB")
+		return err.Error
+	}
+
+	return nil
+}
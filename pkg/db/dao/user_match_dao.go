@@ -2,19 +2,29 @@ package dao
 
 import (
 	"context"
+	"time"
 
 	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/pkg/db"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockgen -package mocks -destination mocks/user_match_dao_mock.go github.com/SuperMatch/pkg/db/dao UserMatchDao
 type UserMatchDao interface {
 	Insert(ctx context.Context, userMatch model.UserMatch) (model.UserMatch, error)
 	InsertMany(ctx context.Context, userMatch []model.UserMatch) ([]model.UserMatch, error)
+	InsertPair(ctx context.Context, match model.UserMatch, reverse model.UserMatch) (bool, error)
 	FindByUserId(ctx context.Context, userId int) ([]model.UserMatch, error)
 	FindByUserIdMatchId(ctx context.Context, userId, matchId int) (model.UserMatch, error)
 	DeleteByUserID(ctx context.Context, userId int, matchId int) error
+	MarkFirstMessage(ctx context.Context, userId, matchId int, at time.Time) error
+	OpenFirstMove(ctx context.Context, userId, matchId int) (bool, error)
+	Extend(ctx context.Context, userId, matchId int, extension time.Duration, now time.Time) (bool, error)
+	FindExpiringUnnotified(ctx context.Context, now, before time.Time, limit int) ([]model.UserMatch, error)
+	MarkExpiryNotified(ctx context.Context, id int) (bool, error)
+	ExpireDue(ctx context.Context, now time.Time, limit int) ([]model.UserMatch, error)
+	BackfillExpiry(ctx context.Context, expiresAt time.Time) (int64, error)
 }
 
 type UserMatchDaoImpl struct {
@@ -43,9 +53,37 @@ func (UserMatchDao *UserMatchDaoImpl) InsertMany(ctx context.Context, userMatch
 	return userMatch, nil
 }
 
+// InsertPair inserts both sides of a match in one transaction. Rows that already exist are left as
+// they are, and it reports whether the match is new. Expired rows of the pair are replaced, as the
+// unique index allows one row per side.
+func (UserMatchDao *UserMatchDaoImpl) InsertPair(ctx context.Context, match model.UserMatch, reverse model.UserMatch) (bool, error) {
+	created := false
+	err := UserMatchDao.Connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("deleted_at IS NOT NULL").
+			Where("(user_id = ? and match_id = ?) or (user_id = ? and match_id = ?)", match.UserID, match.MatchID, reverse.UserID, reverse.MatchID).
+			Delete(&model.UserMatch{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		for _, userMatch := range []model.UserMatch{match, reverse} {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&userMatch)
+			if result.Error != nil {
+				return result.Error
+			}
+			created = created || result.RowsAffected > 0
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
 func (UserMatchDao *UserMatchDaoImpl) FindByUserId(ctx context.Context, userId int) ([]model.UserMatch, error) {
 	var userMatches []model.UserMatch
-	tx := UserMatchDao.Connection.Where("user_id = ?", userId).Find(&userMatches)
+	tx := UserMatchDao.Connection.Where("deleted_at IS NULL").Where("user_id = ?", userId).Find(&userMatches)
 
 	if tx.Error != nil {
 		return userMatches, tx.Error
@@ -55,7 +93,7 @@ func (UserMatchDao *UserMatchDaoImpl) FindByUserId(ctx context.Context, userId i
 
 func (UserMatchDao *UserMatchDaoImpl) FindByUserIdMatchId(ctx context.Context, userId, matchId int) (model.UserMatch, error) {
 	var userMatch model.UserMatch
-	tx := UserMatchDao.Connection.Where("user_id = ? and match_id = ?", userId, matchId).First(&userMatch)
+	tx := UserMatchDao.Connection.Where("deleted_at IS NULL").Where("user_id = ? and match_id = ?", userId, matchId).First(&userMatch)
 
 	if tx.Error != nil {
 		return userMatch, tx.Error
@@ -70,3 +108,107 @@ func (UserMatchDao *UserMatchDaoImpl) DeleteByUserID(ctx context.Context, userId
 	}
 	return nil
 }
+
+// MarkFirstMessage records when the first message of the match was sent, which keeps both sides of
+// the match for good.
+func (UserMatchDao *UserMatchDaoImpl) MarkFirstMessage(ctx context.Context, userId, matchId int, at time.Time) error {
+	tx := UserMatchDao.Connection.Model(&model.UserMatch{}).
+		Where("(user_id = ? and match_id = ?) or (user_id = ? and match_id = ?)", userId, matchId, matchId, userId).
+		Where("first_message_at IS NULL").
+		Updates(map[string]interface{}{"first_message_at": at, "expires_at": nil, "expiry_notified_at": nil})
+	return tx.Error
+}
+
+// OpenFirstMove lets either side of a match send the first message, if the user had the first move and
+// nobody has messaged yet, and reports whether it did.
+func (UserMatchDao *UserMatchDaoImpl) OpenFirstMove(ctx context.Context, userId, matchId int) (bool, error) {
+	tx := UserMatchDao.Connection.Model(&model.UserMatch{}).
+		Where("(user_id = ? and match_id = ?) or (user_id = ? and match_id = ?)", userId, matchId, matchId, userId).
+		Where("deleted_at IS NULL and first_message_at IS NULL and first_move_by = ?", userId).
+		Update("first_move_by", nil)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+// Extend pushes back the expiry of both sides of a match that has not expired or been extended yet,
+// and reports whether it did. The users are reminded again before the new expiry.
+func (UserMatchDao *UserMatchDaoImpl) Extend(ctx context.Context, userId, matchId int, extension time.Duration, now time.Time) (bool, error) {
+	tx := UserMatchDao.Connection.Model(&model.UserMatch{}).
+		Where("(user_id = ? and match_id = ?) or (user_id = ? and match_id = ?)", userId, matchId, matchId, userId).
+		Where("deleted_at IS NULL and extended_by IS NULL and expires_at > ?", now).
+		Updates(map[string]interface{}{
+			"expires_at":         gorm.Expr("expires_at + INTERVAL ? SECOND", int64(extension.Seconds())),
+			"expiry_notified_at": nil,
+			"extended_by":        userId,
+		})
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+// FindExpiringUnnotified returns match rows expiring by before whose user has not been reminded yet.
+func (UserMatchDao *UserMatchDaoImpl) FindExpiringUnnotified(ctx context.Context, now, before time.Time, limit int) ([]model.UserMatch, error) {
+	var userMatches []model.UserMatch
+	tx := UserMatchDao.Connection.
+		Where("deleted_at IS NULL and expiry_notified_at IS NULL").
+		Where("expires_at > ? and expires_at <= ?", now, before).
+		Order("expires_at").
+		Limit(limit).
+		Find(&userMatches)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return userMatches, nil
+}
+
+// MarkExpiryNotified records the reminder of one side of a match and reports whether it was still due,
+// so each user is reminded once.
+func (UserMatchDao *UserMatchDaoImpl) MarkExpiryNotified(ctx context.Context, id int) (bool, error) {
+	tx := UserMatchDao.Connection.Model(&model.UserMatch{}).
+		Where("ID = ? and expiry_notified_at IS NULL", id).
+		Update("expiry_notified_at", time.Now())
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+// ExpireDue soft deletes match rows whose expiry has passed and returns them.
+func (UserMatchDao *UserMatchDaoImpl) ExpireDue(ctx context.Context, now time.Time, limit int) ([]model.UserMatch, error) {
+	var userMatches []model.UserMatch
+	err := UserMatchDao.Connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NULL and expires_at <= ?", now).
+			Order("expires_at").
+			Limit(limit).
+			Find(&userMatches)
+		if result.Error != nil || len(userMatches) == 0 {
+			return result.Error
+		}
+
+		ids := make([]int, 0, len(userMatches))
+		for _, userMatch := range userMatches {
+			ids = append(ids, userMatch.ID)
+		}
+		return tx.Model(&model.UserMatch{}).Where("ID in (?)", ids).Update("deleted_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return userMatches, nil
+}
+
+// BackfillExpiry gives the matches made before matches expired, that nobody has messaged yet, an
+// expiry, and returns how many match rows it updated. Matches made since always have an expiry, and
+// messaged ones have a first message or chats, so running it again updates nothing.
+func (UserMatchDao *UserMatchDaoImpl) BackfillExpiry(ctx context.Context, expiresAt time.Time) (int64, error) {
+	tx := UserMatchDao.Connection.WithContext(ctx).Exec(`UPDATE user_match um SET um.expires_at = ?
+WHERE um.deleted_at IS NULL AND um.expires_at IS NULL AND um.first_message_at IS NULL AND NOT EXISTS (
+    SELECT 1 FROM user_chats uc
+    WHERE (uc.sender_id = um.user_id AND uc.receiver_id = um.match_id) OR (uc.sender_id = um.match_id AND uc.receiver_id = um.user_id)
+)`, expiresAt)
+	return tx.RowsAffected, tx.Error
+}
@@ -21,7 +21,7 @@ type UserMediaRepository interface {
 	FindByIdAndUserId(ctx context.Context, id, userId int) (*model.UserMedia, error)
 	FindByUserId(ctx context.Context, userId int) ([]model.UserMedia, error)
 	DeleteById(ctx context.Context, id int, userId int) error
-	FindFirstGroupByUserID(ctc context.Context, userIDs []int) ([]UserMatchUserMediaDTO, error)
+	FindFirstGroupByUserID(ctx context.Context, userID int, matchIDs []int) ([]UserMatchUserMediaDTO, error)
 	FindByUserIDOrderID(userID, orderID int) (model.UserMedia, error)
 	UpdateProfileMedia(ctx context.Context, user model.UserMedia, mediaID int) (model.UserMedia, error)
 	FindByUserIDs(userIDs []int) ([]model.UserMedia, error)
@@ -108,24 +108,30 @@ func (u *UserMedia) DeleteById(ctx context.Context, id int, userId int) error {
 }
 
 type UserMatchUserMediaDTO struct {
-	ID        int        `json:"ID"`
-	UserId    int        `json:"user_id"`
-	MatchId   int        `json:"match_id"`
-	CreatedAt time.Time  `json:"created_at"`
-	OrderId   int        `json:"order_id"`
-	MediaId   int        `json:"media_id"`
-	URL       string     `json:"url"`
-	ChatId    *string    `json:"chat_id"`
-	DeletedAt *time.Time `json:"deleted_at"`
+	ID             int        `json:"ID"`
+	UserId         int        `json:"user_id"`
+	MatchId        int        `json:"match_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	OrderId        int        `json:"order_id"`
+	MediaId        int        `json:"media_id"`
+	URL            string     `json:"url"`
+	ChatId         *string    `json:"chat_id"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	ExtendedBy     *int       `json:"extended_by"`
+	FirstMoveBy    *int       `json:"first_move_by"`
+	FirstMessageAt *time.Time `json:"first_message_at"`
 }
 
-func (u *UserMedia) FindFirstGroupByUserID(ctx context.Context, userIDs []int) ([]UserMatchUserMediaDTO, error) {
+// FindFirstGroupByUserID returns the matches of a user with the given match ids, each with the first
+// profile photo of the match.
+func (u *UserMedia) FindFirstGroupByUserID(ctx context.Context, userID int, matchIDs []int) ([]UserMatchUserMediaDTO, error) {
 
 	var userMedia []UserMatchUserMediaDTO
 
-	query := "SELECT um.ID,um.user_id,um.match_id,um.created_at,pf.order_id,pf.ID as media_id,pf.url,pf.deleted_at,um.chat_id from user_match as um LEFT JOIN profile_media as pf ON um.match_id = pf.user_id where pf.order_id = 1 and um.match_id in (?) order by um.ID;"
+	query := "SELECT um.ID,um.user_id,um.match_id,um.created_at,pf.order_id,pf.ID as media_id,pf.url,pf.deleted_at,um.chat_id,um.expires_at,um.extended_by,um.first_move_by,um.first_message_at from user_match as um LEFT JOIN profile_media as pf ON um.match_id = pf.user_id where pf.order_id = 1 and um.deleted_at IS NULL and um.user_id = ? and um.match_id in (?) order by um.ID;"
 
-	tx := u.Connection.Debug().Raw(query, userIDs).Find(&userMedia)
+	tx := u.Connection.Debug().Raw(query, userID, matchIDs).Find(&userMedia)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return userMedia, nil
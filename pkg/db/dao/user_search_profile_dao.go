@@ -8,14 +8,22 @@ import (
 	"github.com/SuperMatch/zapLogger"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"time"
 )
 
+// pausedCondition selects the search profiles that are paused, either until they are resumed or
+// until their snooze time.
+const pausedCondition = "paused = true or snooze > ?"
+
 //go:generate mockgen -package mocks -destination mocks/user_search_profile_dao_mock.go github.com/SuperMatch/pkg/db/dao UserSearchProfileRepository
 type UserSearchProfileRepository interface {
 	CreateUserSearchProfile(ctx context.Context, userProfile map[string]interface{}) (model.UserSearchProfile, error)
 	UpdateUserSearchProfile(ctx context.Context, userProfile map[string]interface{}) (model.UserSearchProfile, error)
 	FindByProfileId(ctx context.Context, profileId int) (model.UserSearchProfile, error)
 	FindByUserId(ctx context.Context, userId int) (model.UserSearchProfile, error)
+	UpdatePause(ctx context.Context, profileId int, paused bool, snooze *time.Time) error
+	FindPausedUserIds(ctx context.Context, userIds []int) ([]int, error)
+	FindPausedProfileIds(ctx context.Context, profileIds []int) ([]int, error)
 }
 
 type UserSearchProfile struct {
@@ -92,3 +100,37 @@ func (u *UserSearchProfile) FindByUserId(ctx context.Context, userId int) (model
 	}
 	return userSearchProfile, nil
 }
+
+// UpdatePause sets whether the profile is paused and when it resumes by itself. A nil snooze clears it.
+func (u *UserSearchProfile) UpdatePause(ctx context.Context, profileId int, paused bool, snooze *time.Time) error {
+	tx := u.Connection.Model(&model.UserSearchProfile{}).Where("user_profile_id = ?", profileId).
+		Updates(map[string]interface{}{"paused": paused, "snooze": snooze})
+	if tx.Error != nil {
+		zapLogger.Logger.Error("error in updating pause of user search profile in db.", zap.Error(tx.Error))
+	}
+	return tx.Error
+}
+
+// FindPausedUserIds returns which of the users are paused.
+func (u *UserSearchProfile) FindPausedUserIds(ctx context.Context, userIds []int) ([]int, error) {
+	pausedIds := make([]int, 0)
+	if len(userIds) == 0 {
+		return pausedIds, nil
+	}
+
+	tx := u.Connection.Model(&model.UserSearchProfile{}).Where("user_id in ?", userIds).
+		Where(pausedCondition, time.Now()).Pluck("user_id", &pausedIds)
+	return pausedIds, tx.Error
+}
+
+// FindPausedProfileIds returns which of the user profiles are paused.
+func (u *UserSearchProfile) FindPausedProfileIds(ctx context.Context, profileIds []int) ([]int, error) {
+	pausedIds := make([]int, 0)
+	if len(profileIds) == 0 {
+		return pausedIds, nil
+	}
+
+	tx := u.Connection.Model(&model.UserSearchProfile{}).Where("user_profile_id in ?", profileIds).
+		Where(pausedCondition, time.Now()).Pluck("user_profile_id", &pausedIds)
+	return pausedIds, tx.Error
+}
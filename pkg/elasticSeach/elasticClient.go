@@ -1,9 +1,18 @@
 package elasticSeach
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
 	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/zapLogger"
 	"github.com/opensearch-project/opensearch-go"
-	"log"
+	"github.com/opensearch-project/opensearch-go/opensearchapi"
+	"go.uber.org/zap"
 )
 
 var EsClient *opensearch.Client
@@ -26,3 +35,62 @@ func CreateElasticClient(conf config.Config) error {
 	EsClient = es
 	return nil
 }
+
+// deleteByTerm deletes every document of index whose field equals value and returns how many were deleted.
+func deleteByTerm(client *opensearch.Client, index string, field string, value interface{}) (int64, error) {
+	query, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{field: value},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	refresh := true
+	res, err := opensearchapi.DeleteByQueryRequest{
+		Index:     []string{index},
+		Body:      bytes.NewReader(query),
+		Refresh:   &refresh,
+		Conflicts: "proceed",
+	}.Do(context.Background(), client)
+	if err != nil {
+		zapLogger.Logger.Error("error in deleting documents in elasticSearch", zap.String("index", index), zap.Error(err))
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("error in deleting documents of %s in elasticSearch: %s", index, res.Status())
+	}
+
+	var response struct {
+		Deleted int64 `json:"deleted"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return 0, err
+	}
+	return response.Deleted, nil
+}
+
+// deleteDocument deletes a document by id and reports whether it existed.
+func deleteDocument(client *opensearch.Client, index string, documentID string) (bool, error) {
+	res, err := opensearchapi.DeleteRequest{
+		Index:      index,
+		DocumentID: documentID,
+		Refresh:    "true",
+	}.Do(context.Background(), client)
+	if err != nil {
+		zapLogger.Logger.Error("error in deleting document in elasticSearch", zap.String("index", index), zap.Error(err))
+		return false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if res.IsError() {
+		return false, fmt.Errorf("error in deleting document of %s in elasticSearch: %s", index, res.Status())
+	}
+	return true, nil
+}
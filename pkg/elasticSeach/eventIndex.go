@@ -22,6 +22,7 @@ import (
 
 var eventIndex = "events"
 
+//go:generate mockgen -package mocks -destination mocks/eventIndex_mock.go github.com/SuperMatch/pkg/elasticSeach EventIndexer
 type EventIndexer interface {
 	CreateIndex() error
 	IndexUserEvent(event elasticsearchPkg.Event, doc []byte) error
@@ -29,6 +30,7 @@ type EventIndexer interface {
 	SearchEvents(user model.UserProfile, page model.Pagination, filters dto.EventFilterDTO) ([]elasticsearchPkg.Event, error)
 	UpdateUserEvent(event elasticsearchPkg.Event, doc []byte) error
 	DeleteUserEvent(eventID int) error
+	DeleteUserEvents(userID int) (int64, error)
 }
 
 type EventIndexerImpl struct {
@@ -354,3 +356,8 @@ func (e *EventIndexerImpl) DeleteUserEvent(eventID int) error {
 	return nil
 
 }
+
+// DeleteUserEvents removes every event created by a user and returns how many there were.
+func (e *EventIndexerImpl) DeleteUserEvents(userID int) (int64, error) {
+	return deleteByTerm(e.esClient, e.IndexName, "user_id", userID)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/elasticSeach (interfaces: EventIndexer)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/SuperMatch/model"
+	dto "github.com/SuperMatch/model/dto"
+	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventIndexer is a mock of EventIndexer interface.
+type MockEventIndexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventIndexerMockRecorder
+}
+
+// MockEventIndexerMockRecorder is the mock recorder for MockEventIndexer.
+type MockEventIndexerMockRecorder struct {
+	mock *MockEventIndexer
+}
+
+// NewMockEventIndexer creates a new mock instance.
+func NewMockEventIndexer(ctrl *gomock.Controller) *MockEventIndexer {
+	mock := &MockEventIndexer{ctrl: ctrl}
+	mock.recorder = &MockEventIndexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventIndexer) EXPECT() *MockEventIndexerMockRecorder {
+	return m.recorder
+}
+
+// CreateIndex mocks base method.
+func (m *MockEventIndexer) CreateIndex() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockEventIndexerMockRecorder) CreateIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockEventIndexer)(nil).CreateIndex))
+}
+
+// DeleteUserEvent mocks base method.
+func (m *MockEventIndexer) DeleteUserEvent(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserEvent indicates an expected call of DeleteUserEvent.
+func (mr *MockEventIndexerMockRecorder) DeleteUserEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserEvent", reflect.TypeOf((*MockEventIndexer)(nil).DeleteUserEvent), arg0)
+}
+
+// DeleteUserEvents mocks base method.
+func (m *MockEventIndexer) DeleteUserEvents(arg0 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserEvents", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserEvents indicates an expected call of DeleteUserEvents.
+func (mr *MockEventIndexerMockRecorder) DeleteUserEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserEvents", reflect.TypeOf((*MockEventIndexer)(nil).DeleteUserEvents), arg0)
+}
+
+// GetEventsById mocks base method.
+func (m *MockEventIndexer) GetEventsById(arg0 int) ([]elasticsearchPkg.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsById", arg0)
+	ret0, _ := ret[0].([]elasticsearchPkg.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsById indicates an expected call of GetEventsById.
+func (mr *MockEventIndexerMockRecorder) GetEventsById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsById", reflect.TypeOf((*MockEventIndexer)(nil).GetEventsById), arg0)
+}
+
+// IndexUserEvent mocks base method.
+func (m *MockEventIndexer) IndexUserEvent(arg0 elasticsearchPkg.Event, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexUserEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexUserEvent indicates an expected call of IndexUserEvent.
+func (mr *MockEventIndexerMockRecorder) IndexUserEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexUserEvent", reflect.TypeOf((*MockEventIndexer)(nil).IndexUserEvent), arg0, arg1)
+}
+
+// SearchEvents mocks base method.
+func (m *MockEventIndexer) SearchEvents(arg0 model.UserProfile, arg1 model.Pagination, arg2 dto.EventFilterDTO) ([]elasticsearchPkg.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]elasticsearchPkg.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchEvents indicates an expected call of SearchEvents.
+func (mr *MockEventIndexerMockRecorder) SearchEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEvents", reflect.TypeOf((*MockEventIndexer)(nil).SearchEvents), arg0, arg1, arg2)
+}
+
+// UpdateUserEvent mocks base method.
+func (m *MockEventIndexer) UpdateUserEvent(arg0 elasticsearchPkg.Event, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserEvent indicates an expected call of UpdateUserEvent.
+func (mr *MockEventIndexerMockRecorder) UpdateUserEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserEvent", reflect.TypeOf((*MockEventIndexer)(nil).UpdateUserEvent), arg0, arg1)
+}
@@ -34,6 +34,35 @@ func (m *MockElasticSearchIndexer) EXPECT() *MockElasticSearchIndexerMockRecorde
 	return m.recorder
 }
 
+// CreateIndex mocks base method.
+func (m *MockElasticSearchIndexer) CreateIndex() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockElasticSearchIndexerMockRecorder) CreateIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockElasticSearchIndexer)(nil).CreateIndex))
+}
+
+// DeleteUserProfile mocks base method.
+func (m *MockElasticSearchIndexer) DeleteUserProfile(arg0 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserProfile", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserProfile indicates an expected call of DeleteUserProfile.
+func (mr *MockElasticSearchIndexerMockRecorder) DeleteUserProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserProfile", reflect.TypeOf((*MockElasticSearchIndexer)(nil).DeleteUserProfile), arg0)
+}
+
 // GetUserProfile mocks base method.
 func (m *MockElasticSearchIndexer) GetUserProfile(arg0 int) (elasticsearchPkg.UserProfile, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/elasticSeach (interfaces: UserStoriesIndexer)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/SuperMatch/model"
+	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserStoriesIndexer is a mock of UserStoriesIndexer interface.
+type MockUserStoriesIndexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserStoriesIndexerMockRecorder
+}
+
+// MockUserStoriesIndexerMockRecorder is the mock recorder for MockUserStoriesIndexer.
+type MockUserStoriesIndexerMockRecorder struct {
+	mock *MockUserStoriesIndexer
+}
+
+// NewMockUserStoriesIndexer creates a new mock instance.
+func NewMockUserStoriesIndexer(ctrl *gomock.Controller) *MockUserStoriesIndexer {
+	mock := &MockUserStoriesIndexer{ctrl: ctrl}
+	mock.recorder = &MockUserStoriesIndexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserStoriesIndexer) EXPECT() *MockUserStoriesIndexerMockRecorder {
+	return m.recorder
+}
+
+// CreateIndex mocks base method.
+func (m *MockUserStoriesIndexer) CreateIndex() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockUserStoriesIndexerMockRecorder) CreateIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockUserStoriesIndexer)(nil).CreateIndex))
+}
+
+// DeleteUserStories mocks base method.
+func (m *MockUserStoriesIndexer) DeleteUserStories(arg0 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserStories", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserStories indicates an expected call of DeleteUserStories.
+func (mr *MockUserStoriesIndexerMockRecorder) DeleteUserStories(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserStories", reflect.TypeOf((*MockUserStoriesIndexer)(nil).DeleteUserStories), arg0)
+}
+
+// DeleteUserStory mocks base method.
+func (m *MockUserStoriesIndexer) DeleteUserStory(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserStory", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserStory indicates an expected call of DeleteUserStory.
+func (mr *MockUserStoriesIndexerMockRecorder) DeleteUserStory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserStory", reflect.TypeOf((*MockUserStoriesIndexer)(nil).DeleteUserStory), arg0)
+}
+
+// GetUserStoriesByLocation mocks base method.
+func (m *MockUserStoriesIndexer) GetUserStoriesByLocation(arg0 model.UserLocation, arg1 []int) ([]elasticsearchPkg.UserStories, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStoriesByLocation", arg0, arg1)
+	ret0, _ := ret[0].([]elasticsearchPkg.UserStories)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStoriesByLocation indicates an expected call of GetUserStoriesByLocation.
+func (mr *MockUserStoriesIndexerMockRecorder) GetUserStoriesByLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStoriesByLocation", reflect.TypeOf((*MockUserStoriesIndexer)(nil).GetUserStoriesByLocation), arg0, arg1)
+}
+
+// GetUserStoriesByProfileID mocks base method.
+func (m *MockUserStoriesIndexer) GetUserStoriesByProfileID(arg0 int) ([]elasticsearchPkg.UserStories, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStoriesByProfileID", arg0)
+	ret0, _ := ret[0].([]elasticsearchPkg.UserStories)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStoriesByProfileID indicates an expected call of GetUserStoriesByProfileID.
+func (mr *MockUserStoriesIndexerMockRecorder) GetUserStoriesByProfileID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStoriesByProfileID", reflect.TypeOf((*MockUserStoriesIndexer)(nil).GetUserStoriesByProfileID), arg0)
+}
+
+// GetUserStory mocks base method.
+func (m *MockUserStoriesIndexer) GetUserStory(arg0 string) (elasticsearchPkg.UserStories, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStory", arg0)
+	ret0, _ := ret[0].(elasticsearchPkg.UserStories)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserStory indicates an expected call of GetUserStory.
+func (mr *MockUserStoriesIndexerMockRecorder) GetUserStory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStory", reflect.TypeOf((*MockUserStoriesIndexer)(nil).GetUserStory), arg0)
+}
+
+// IndexUserStories mocks base method.
+func (m *MockUserStoriesIndexer) IndexUserStories(arg0 elasticsearchPkg.UserStories, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexUserStories", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexUserStories indicates an expected call of IndexUserStories.
+func (mr *MockUserStoriesIndexerMockRecorder) IndexUserStories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexUserStories", reflect.TypeOf((*MockUserStoriesIndexer)(nil).IndexUserStories), arg0, arg1)
+}
@@ -30,6 +30,7 @@ type ElasticSearchIndexer interface {
 	GetUserProfile(userProfileId int) (elasticsearchPkg.UserProfile, error)
 	SearchProfile(query map[string]interface{}) ([]elasticsearchPkg.UserProfile, error)
 	UpdateSearchProfile(userProfile elasticsearchPkg.UserProfile, doc []byte) error
+	DeleteUserProfile(userProfileId int) (bool, error)
 	CreateIndex() error
 }
 
@@ -266,3 +267,8 @@ func (e *ElasticSearchIndexerImpl) CreateIndex() error {
 
 	return nil
 }
+
+// DeleteUserProfile removes a profile from search and reports whether it was indexed.
+func (e *ElasticSearchIndexerImpl) DeleteUserProfile(userProfileId int) (bool, error) {
+	return deleteDocument(e.esClient, e.IndexName, strconv.Itoa(userProfileId))
+}
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 	"io"
 	"log"
+	"net/http"
 	"strings"
 	"time"
 )
@@ -21,11 +22,15 @@ const (
 	IsoDateFormat    = "2006-01-02"
 )
 
+//go:generate mockgen -package mocks -destination mocks/userStoriesIndex_mock.go github.com/SuperMatch/pkg/elasticSeach UserStoriesIndexer
 type UserStoriesIndexer interface {
 	CreateIndex() error
 	IndexUserStories(userStories elasticsearchPkg.UserStories, doc []byte) error
 	GetUserStoriesByProfileID(userProfileID int) ([]elasticsearchPkg.UserStories, error)
-	GetUserStoriesByLocation(location model.UserLocation) ([]elasticsearchPkg.UserStories, error)
+	GetUserStoriesByLocation(location model.UserLocation, excludedProfileIDs []int) ([]elasticsearchPkg.UserStories, error)
+	DeleteUserStories(userProfileID int) (int64, error)
+	GetUserStory(id string) (elasticsearchPkg.UserStories, bool, error)
+	DeleteUserStory(id string) (bool, error)
 }
 
 type UserStoriesIndexerImpl struct {
@@ -225,7 +230,9 @@ func (e *UserStoriesIndexerImpl) GetUserStoriesByProfileID(userProfileID int) ([
 
 }
 
-func QueryStoriesByLocation(location model.UserLocation) map[string]interface{} {
+// QueryStoriesByLocation builds the search of unexpired stories near the location, leaving out the
+// stories of the profiles in excludedProfileIDs.
+func QueryStoriesByLocation(location model.UserLocation, excludedProfileIDs []int) map[string]interface{} {
 
 	var mustMap []map[string]interface{}
 
@@ -250,10 +257,20 @@ func QueryStoriesByLocation(location model.UserLocation) map[string]interface{}
 
 	mustMap = append(mustMap, y)
 
+	mustNotMap := []map[string]interface{}{}
+	if len(excludedProfileIDs) > 0 {
+		mustNotMap = append(mustNotMap, map[string]interface{}{
+			"terms": map[string]interface{}{
+				"user_profile_id": excludedProfileIDs,
+			},
+		})
+	}
+
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
 			"bool": map[string]interface{}{
-				"must": mustMap,
+				"must":     mustMap,
+				"must_not": mustNotMap,
 			},
 		},
 		"sort": []map[string]interface{}{
@@ -278,8 +295,8 @@ func QueryStoriesByLocation(location model.UserLocation) map[string]interface{}
 	return query
 }
 
-func (e *UserStoriesIndexerImpl) GetUserStoriesByLocation(location model.UserLocation) ([]elasticsearchPkg.UserStories, error) {
-	query := QueryStoriesByLocation(location)
+func (e *UserStoriesIndexerImpl) GetUserStoriesByLocation(location model.UserLocation, excludedProfileIDs []int) ([]elasticsearchPkg.UserStories, error) {
+	query := QueryStoriesByLocation(location, excludedProfileIDs)
 	qq, _ := json.Marshal(query)
 	res, err := opensearchapi.SearchRequest{
 		Index: []string{e.IndexName},
@@ -331,3 +348,42 @@ func (e *UserStoriesIndexerImpl) GetUserStoriesByLocation(location model.UserLoc
 
 	return userStories, nil
 }
+
+// DeleteUserStories removes every story of a profile and returns how many there were.
+func (e *UserStoriesIndexerImpl) DeleteUserStories(userProfileID int) (int64, error) {
+	return deleteByTerm(e.esClient, e.IndexName, "user_profile_id", userProfileID)
+}
+
+// GetUserStory returns a story by id and reports whether it exists.
+func (e *UserStoriesIndexerImpl) GetUserStory(id string) (elasticsearchPkg.UserStories, bool, error) {
+	res, err := opensearchapi.GetRequest{
+		Index:      e.IndexName,
+		DocumentID: id,
+	}.Do(context.Background(), e.esClient)
+	if err != nil {
+		e.logger.Error("error in getting user story from elasticSearch", zap.Error(err))
+		return elasticsearchPkg.UserStories{}, false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return elasticsearchPkg.UserStories{}, false, nil
+	}
+	if res.IsError() {
+		return elasticsearchPkg.UserStories{}, false, fmt.Errorf("error in getting user story from elasticSearch: %s", res.Status())
+	}
+
+	var response struct {
+		Found  bool                         `json:"found"`
+		Source elasticsearchPkg.UserStories `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return elasticsearchPkg.UserStories{}, false, err
+	}
+	return response.Source, response.Found, nil
+}
+
+// DeleteUserStory removes a story and reports whether it existed.
+func (e *UserStoriesIndexerImpl) DeleteUserStory(id string) (bool, error) {
+	return deleteDocument(e.esClient, e.IndexName, id)
+}
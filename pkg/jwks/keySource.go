@@ -0,0 +1,182 @@
+package jwks
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+)
+
+var ErrKeyNotFound = errors.New("signing key not found")
+
+//go:generate mockgen -package mocks -destination mocks/key_source_mock.go github.com/SuperMatch/pkg/jwks KeySource
+
+// KeySource resolves the public key of a token issuer by its kid.
+type KeySource interface {
+	Key(kid string) (interface{}, error)
+}
+
+// RemoteKeySource fetches a JSON Web Key Set over HTTP and caches it. An unknown kid triggers a
+// refetch, at most once per minRefreshInterval, so key rotations of the issuer are picked up.
+type RemoteKeySource struct {
+	url        string
+	httpClient *http.Client
+	cacheTTL   time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+const (
+	defaultCacheTTL    = time.Hour
+	minRefreshInterval = time.Minute
+)
+
+func NewRemoteKeySource(url string) *RemoteKeySource {
+	return &RemoteKeySource{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cacheTTL:   defaultCacheTTL,
+	}
+}
+
+func (r *RemoteKeySource) Key(kid string) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[kid]
+	expired := time.Since(r.fetchedAt) > r.cacheTTL
+	if ok && !expired {
+		return key, nil
+	}
+
+	if expired || time.Since(r.fetchedAt) > minRefreshInterval {
+		if err := r.refresh(); err != nil {
+			// keep serving the previous key set while the issuer is unreachable
+			zapLogger.Logger.Error("error in fetching jwks", zap.String("url", r.url), zap.Error(err))
+			if ok {
+				return key, nil
+			}
+			return nil, err
+		}
+	}
+
+	key, ok = r.keys[kid]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+func (r *RemoteKeySource) refresh() error {
+	resp, err := r.httpClient.Get(r.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwks request failed with status %d", resp.StatusCode)
+	}
+
+	var keySet dto.JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&keySet); err != nil {
+		return err
+	}
+
+	keys, err := ParseKeySet(keySet)
+	if err != nil {
+		return err
+	}
+
+	r.keys = keys
+	r.fetchedAt = time.Now()
+	if maxAge, ok := cacheMaxAge(resp.Header.Get("Cache-Control")); ok {
+		r.cacheTTL = maxAge
+	}
+	return nil
+}
+
+func cacheMaxAge(cacheControl string) (time.Duration, bool) {
+	var seconds int
+	for _, directive := range strings.Split(cacheControl, ",") {
+		if _, err := fmt.Sscanf(strings.TrimSpace(directive), "max-age=%d", &seconds); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	return 0, false
+}
+
+// StaticKeySource serves a fixed set of keys, for tests and for issuers whose keys are configured locally.
+type StaticKeySource struct {
+	keys map[string]interface{}
+}
+
+func NewStaticKeySource(keys map[string]interface{}) *StaticKeySource {
+	return &StaticKeySource{keys: keys}
+}
+
+func (s *StaticKeySource) Key(kid string) (interface{}, error) {
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+// ParseKeySet converts the RSA and EC keys of a JSON Web Key Set to public keys by kid. Keys of
+// other types are skipped.
+func ParseKeySet(keySet dto.JWKS) (map[string]interface{}, error) {
+	keys := make(map[string]interface{})
+
+	for _, jwk := range keySet.Keys {
+		switch jwk.Kty {
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+			if err != nil {
+				return nil, fmt.Errorf("jwk %s: %w", jwk.Kid, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+			if err != nil {
+				return nil, fmt.Errorf("jwk %s: %w", jwk.Kid, err)
+			}
+			keys[jwk.Kid] = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+
+		case "EC":
+			if jwk.Crv != "P-256" {
+				continue
+			}
+			x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+			if err != nil {
+				return nil, fmt.Errorf("jwk %s: %w", jwk.Kid, err)
+			}
+			y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+			if err != nil {
+				return nil, fmt.Errorf("jwk %s: %w", jwk.Kid, err)
+			}
+			keys[jwk.Kid] = &ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(x),
+				Y:     new(big.Int).SetBytes(y),
+			}
+		}
+	}
+
+	return keys, nil
+}
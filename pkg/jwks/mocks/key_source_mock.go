@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/jwks (interfaces: KeySource)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockKeySource is a mock of KeySource interface.
+type MockKeySource struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeySourceMockRecorder
+}
+
+// MockKeySourceMockRecorder is the mock recorder for MockKeySource.
+type MockKeySourceMockRecorder struct {
+	mock *MockKeySource
+}
+
+// NewMockKeySource creates a new mock instance.
+func NewMockKeySource(ctrl *gomock.Controller) *MockKeySource {
+	mock := &MockKeySource{ctrl: ctrl}
+	mock.recorder = &MockKeySourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeySource) EXPECT() *MockKeySourceMockRecorder {
+	return m.recorder
+}
+
+// Key mocks base method.
+func (m *MockKeySource) Key(arg0 string) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Key", arg0)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Key indicates an expected call of Key.
+func (mr *MockKeySourceMockRecorder) Key(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Key", reflect.TypeOf((*MockKeySource)(nil).Key), arg0)
+}
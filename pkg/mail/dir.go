@@ -0,0 +1,41 @@
+package mail
+
+import (
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DirMailer does not send anything. It writes every email as an .eml file to a directory, so
+// emails can be opened in a mail client during development and read back in tests.
+type DirMailer struct {
+	dir  string
+	from netmail.Address
+}
+
+func NewDirMailer(dir string, from netmail.Address) *DirMailer {
+	return &DirMailer{
+		dir:  dir,
+		from: from,
+	}
+}
+
+func (d *DirMailer) Send(message Message) (string, error) {
+	body, id, err := buildMIME(d.from, message)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		return "", err
+	}
+
+	name := time.Now().Format("20060102T150405") + "-" + strings.SplitN(id, "@", 2)[0] + ".eml"
+	if err := os.WriteFile(filepath.Join(d.dir, name), body, 0600); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"fmt"
+	netmail "net/mail"
+
+	"github.com/SuperMatch/config"
+)
+
+//go:generate mockgen -package mocks -destination mocks/mailer_mock.go github.com/SuperMatch/pkg/mail Mailer
+
+// Message is a rendered email with an HTML body and its plain text alternative.
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Mailer sends an email from the configured sender and returns the provider's message id.
+type Mailer interface {
+	Send(message Message) (string, error)
+}
+
+// Sender is the Mailer selected by the mail provider config, created at startup by CreateMailer.
+var Sender Mailer
+
+func CreateMailer(config config.Config) error {
+	from := netmail.Address{Name: config.MailConfig.FromName, Address: config.MailConfig.From}
+
+	switch config.MailConfig.Provider {
+	case "ses":
+		Sender = NewSESMailer(config.AWSConfig, from)
+	case "smtp":
+		Sender = NewSMTPMailer(config.MailConfig, from)
+	case "dir":
+		Sender = NewDirMailer(config.MailConfig.Dir, from)
+	default:
+		return fmt.Errorf("unknown mail provider %q", config.MailConfig.Provider)
+	}
+	return nil
+}
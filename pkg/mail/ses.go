@@ -0,0 +1,63 @@
+package mail
+
+import (
+	netmail "net/mail"
+
+	"github.com/SuperMatch/config"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ses"
+)
+
+type SESMailer struct {
+	awsConfig config.AWSConfig
+	from      netmail.Address
+}
+
+func NewSESMailer(awsConfig config.AWSConfig, from netmail.Address) *SESMailer {
+	return &SESMailer{
+		awsConfig: awsConfig,
+		from:      from,
+	}
+}
+
+func (s *SESMailer) Send(message Message) (string, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(s.awsConfig.Region),
+		Credentials: credentials.NewStaticCredentials(s.awsConfig.AccessKeyID, s.awsConfig.AccessKeySecret, ""),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	input := &ses.SendEmailInput{
+		Destination: &ses.Destination{
+			ToAddresses: []*string{aws.String(message.To)},
+		},
+		Message: &ses.Message{
+			Body: &ses.Body{
+				Html: &ses.Content{
+					Charset: aws.String("utf-8"),
+					Data:    aws.String(message.HTML),
+				},
+				Text: &ses.Content{
+					Charset: aws.String("utf-8"),
+					Data:    aws.String(message.Text),
+				},
+			},
+			Subject: &ses.Content{
+				Charset: aws.String("utf-8"),
+				Data:    aws.String(message.Subject),
+			},
+		},
+		Source: aws.String(s.from.String()),
+	}
+
+	resp, err := ses.New(sess).SendEmail(input)
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(resp.MessageId), nil
+}
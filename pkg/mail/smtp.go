@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+
+	"github.com/SuperMatch/config"
+)
+
+// SMTPMailer sends email through an SMTP relay. net/smtp upgrades the connection with STARTTLS
+// when the server offers it and only sends credentials over TLS or to localhost.
+type SMTPMailer struct {
+	address string
+	auth    smtp.Auth
+	from    netmail.Address
+}
+
+func NewSMTPMailer(mailConfig config.MailConfig, from netmail.Address) *SMTPMailer {
+	var auth smtp.Auth
+	if mailConfig.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", mailConfig.SMTPUsername, mailConfig.SMTPPassword, mailConfig.SMTPHost)
+	}
+
+	return &SMTPMailer{
+		address: net.JoinHostPort(mailConfig.SMTPHost, mailConfig.SMTPPort),
+		auth:    auth,
+		from:    from,
+	}
+}
+
+func (s *SMTPMailer) Send(message Message) (string, error) {
+	body, id, err := buildMIME(s.from, message)
+	if err != nil {
+		return "", err
+	}
+
+	err = smtp.SendMail(s.address, s.auth, s.from.Address, []string{message.To}, body)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
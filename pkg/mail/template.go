@@ -0,0 +1,93 @@
+package mail
+
+import (
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+// Template names an email template and the version of it to render. Every version lives in
+// templates/<name>/v<version>/ as subject.tmpl, body.txt.tmpl and body.html.tmpl; a changed email
+// gets a new version directory so the old wording stays available until nothing uses it.
+type Template struct {
+	Name    string
+	Version int
+}
+
+var (
+	VerifyEmailTemplate   = Template{Name: "verify_email", Version: 1}
+	PasswordResetTemplate = Template{Name: "password_reset", Version: 1}
+	DigestTemplate        = Template{Name: "digest", Version: 1}
+)
+
+type VerifyEmailData struct {
+	AppName   string
+	Name      string
+	VerifyURL string
+}
+
+type PasswordResetData struct {
+	AppName          string
+	Name             string
+	ResetURL         string
+	ExpiresInMinutes int
+}
+
+type DigestData struct {
+	AppName        string
+	Name           string
+	NewLikes       int
+	NewMatches     int
+	UnreadMessages int
+	AppURL         string
+}
+
+// Render renders the subject, text and HTML body of an email to the given address.
+func Render(tmpl Template, to string, data interface{}) (Message, error) {
+	dir := fmt.Sprintf("templates/%s/v%d/", tmpl.Name, tmpl.Version)
+
+	subject, err := renderText(dir+"subject.tmpl", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	text, err := renderText(dir+"body.txt.tmpl", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	html, err := htmlTemplate.ParseFS(templateFiles, dir+"body.html.tmpl")
+	if err != nil {
+		return Message{}, err
+	}
+
+	var body strings.Builder
+	if err := html.Execute(&body, data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		To:      to,
+		Subject: strings.Join(strings.Fields(subject), " "),
+		HTML:    body.String(),
+		Text:    strings.TrimSpace(text) + "\n",
+	}, nil
+}
+
+func renderText(path string, data interface{}) (string, error) {
+	tmpl, err := textTemplate.ParseFS(templateFiles, path)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
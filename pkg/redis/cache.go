@@ -6,6 +6,7 @@ import (
 	"github.com/SuperMatch/zapLogger"
 	"go.uber.org/zap"
 	"strconv"
+	"strings"
 	"time"
 
 	Redis "github.com/redis/go-redis/v9"
@@ -22,10 +23,59 @@ type LikeDislikeCacheInterface interface {
 	AddToUserMatchList(key string, value string) error
 	RemoveFromUserMatchList(key string) error
 	GetUserLikes(key string) ([]int, error)
+	HasUserLikes(key string) (bool, error)
+	SetUserLikes(key string, likers []int) error
 	PutLiker(key, value string) error
 	RemoveLikerFromLikeeList(key, value string) error
+	DeleteUserData(userID string) (int64, error)
+	RecordSwipe(likerID, likeeID string, swipeType int) (SwipeResult, error)
+	RemoveSwipe(likerID, likeeID string) error
+	ScanSwipes(handle func(swipes []CachedSwipe) error) (int, error)
 }
 
+// SwipeResult is what recording a swipe found. LikersCached is false when the likee's likers list
+// was not cached, so the like could not be added to it.
+type SwipeResult struct {
+	Matched      bool
+	LikersCached bool
+}
+
+// CachedSwipe is a swipe read back from the cache. SwipedAt is worked out from the time the swipe
+// has left before it expires.
+type CachedSwipe struct {
+	LikerID   int
+	LikeeID   int
+	SwipeType int
+	SwipedAt  time.Time
+}
+
+// swipeScanBatchSize is how many keys ScanSwipes reads at a time.
+const swipeScanBatchSize = 1000
+
+// swipeScript records a swipe and checks for the reciprocal like in one step, so two users liking
+// each other at the same moment produce exactly one match.
+//
+// KEYS: liker:likee, likee:liker, likee's likers list, liker's likers list
+// ARGV: swipe type, ttl in seconds, liker id, likee id
+var swipeScript = Redis.NewScript(`
+redis.call("SET", KEYS[1], ARGV[1], "EX", ARGV[2])
+if ARGV[1] == "0" then
+	redis.call("LREM", KEYS[4], 0, ARGV[4])
+	return {0, 1}
+end
+local response = redis.call("GET", KEYS[2])
+if response and response ~= "0" then
+	redis.call("LREM", KEYS[4], 0, ARGV[4])
+	return {1, 1}
+end
+if redis.call("EXISTS", KEYS[3]) == 0 then
+	return {0, 0}
+end
+redis.call("LREM", KEYS[3], 0, ARGV[3])
+redis.call("LPUSH", KEYS[3], ARGV[3])
+return {0, 1}
+`)
+
 type LikeDislikeCache struct {
 	redisClient *Redis.Client
 }
@@ -126,6 +176,159 @@ func (l *LikeDislikeCache) GetUserLikes(key string) ([]int, error) {
 
 }
 
+// RecordSwipe caches the swipe and, atomically with it, matches a reciprocal like or adds the
+// like to the likee's likers list. A dislike or a match removes the likee from the liker's list.
+func (l *LikeDislikeCache) RecordSwipe(likerID, likeeID string, swipeType int) (SwipeResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	keys := []string{likerID + ":" + likeeID, likeeID + ":" + likerID, likeeID, likerID}
+	result, err := swipeScript.Run(ctx, l.redisClient, keys, swipeType, TTL, likerID, likeeID).Int64Slice()
+	if err != nil {
+		zapLogger.Logger.Error("error in recording swipe in redis", zap.Error(err))
+		return SwipeResult{}, err
+	}
+
+	return SwipeResult{Matched: result[0] == 1, LikersCached: result[1] == 1}, nil
+}
+
+// RemoveSwipe removes a swipe from the cache, with the liker from the likee's likers list.
+func (l *LikeDislikeCache) RemoveSwipe(likerID, likeeID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := l.redisClient.TxPipelined(ctx, func(pipe Redis.Pipeliner) error {
+		pipe.Del(ctx, likerID+":"+likeeID)
+		pipe.LRem(ctx, likeeID, 0, likerID)
+		return nil
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in removing swipe from redis", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ScanSwipes reads every swipe in the cache, the liker:likee keys, and hands them to handle a batch at
+// a time. It returns how many swipes it read.
+func (l *LikeDislikeCache) ScanSwipes(handle func(swipes []CachedSwipe) error) (int, error) {
+	ctx := context.Background()
+
+	scanned := 0
+	var cursor uint64
+	for {
+		keys, next, err := l.redisClient.Scan(ctx, cursor, "*:*", swipeScanBatchSize).Result()
+		if err != nil {
+			zapLogger.Logger.Error("error in scanning swipes in redis", zap.Error(err))
+			return scanned, err
+		}
+
+		swipes, err := l.readSwipes(ctx, keys)
+		if err != nil {
+			return scanned, err
+		}
+		if len(swipes) > 0 {
+			if err := handle(swipes); err != nil {
+				return scanned, err
+			}
+			scanned += len(swipes)
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return scanned, nil
+		}
+	}
+}
+
+// readSwipes reads the swipes of the keys that are liker:likee keys, skipping the other keys that
+// share the pattern and the ones that expired meanwhile.
+func (l *LikeDislikeCache) readSwipes(ctx context.Context, keys []string) ([]CachedSwipe, error) {
+	swipes := make([]CachedSwipe, 0, len(keys))
+	swipeKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids := strings.Split(key, ":")
+		if len(ids) != 2 {
+			continue
+		}
+		likerID, err := strconv.Atoi(ids[0])
+		if err != nil {
+			continue
+		}
+		likeeID, err := strconv.Atoi(ids[1])
+		if err != nil {
+			continue
+		}
+		swipes = append(swipes, CachedSwipe{LikerID: likerID, LikeeID: likeeID})
+		swipeKeys = append(swipeKeys, key)
+	}
+
+	values := make([]*Redis.StringCmd, len(swipeKeys))
+	ttls := make([]*Redis.DurationCmd, len(swipeKeys))
+	_, err := l.redisClient.Pipelined(ctx, func(pipe Redis.Pipeliner) error {
+		for i, key := range swipeKeys {
+			values[i] = pipe.Get(ctx, key)
+			ttls[i] = pipe.TTL(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && err != Redis.Nil {
+		zapLogger.Logger.Error("error in reading swipes from redis", zap.Error(err))
+		return nil, err
+	}
+
+	now := time.Now()
+	read := swipes[:0]
+	for i, swipe := range swipes {
+		swipeType, err := strconv.Atoi(values[i].Val())
+		if values[i].Err() != nil || err != nil {
+			continue
+		}
+		swipe.SwipeType = swipeType
+		swipe.SwipedAt = now
+		if ttl := ttls[i].Val(); ttl > 0 {
+			swipe.SwipedAt = now.Add(ttl - time.Duration(TTL)*time.Second)
+		}
+		read = append(read, swipe)
+	}
+	return read, nil
+}
+
+// HasUserLikes reports whether the likers list of a user is cached.
+func (l *LikeDislikeCache) HasUserLikes(key string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	exists, err := l.redisClient.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return exists == 1, nil
+}
+
+// SetUserLikes replaces the likers list of a user, when it is rebuilt from the database.
+func (l *LikeDislikeCache) SetUserLikes(key string, likers []int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	values := make([]interface{}, 0, len(likers))
+	for _, liker := range likers {
+		values = append(values, strconv.Itoa(liker))
+	}
+
+	_, err := l.redisClient.TxPipelined(ctx, func(pipe Redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(values) > 0 {
+			pipe.RPush(ctx, key, values...)
+		}
+		return nil
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in setting likee's likers list", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func (l *LikeDislikeCache) PutLiker(key, value string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -151,3 +354,40 @@ func (l *LikeDislikeCache) RemoveLikerFromLikeeList(key, value string) error {
 	}
 	return nil
 }
+
+// DeleteUserData deletes the likes, dislikes and lists of a user, and removes the user from the
+// likers lists of everyone they liked. It returns how many keys were deleted.
+func (l *LikeDislikeCache) DeleteUserData(userID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var deleted int64
+	for _, pattern := range []string{userID + ":*", "*:" + userID} {
+		iter := l.redisClient.Scan(ctx, 0, pattern, 1000).Iterator()
+		for iter.Next(ctx) {
+			key := iter.Val()
+			// the user is in the likers list of everyone they liked
+			if strings.HasPrefix(key, userID+":") {
+				if err := l.redisClient.LRem(ctx, strings.TrimPrefix(key, userID+":"), 0, userID).Err(); err != nil {
+					return deleted, err
+				}
+			}
+
+			removed, err := l.redisClient.Del(ctx, key).Result()
+			if err != nil {
+				return deleted, err
+			}
+			deleted += removed
+		}
+		if err := iter.Err(); err != nil {
+			zapLogger.Logger.Error("error in scanning user keys in redis", zap.Error(err))
+			return deleted, err
+		}
+	}
+
+	removed, err := l.redisClient.Del(ctx, userID).Result()
+	if err != nil {
+		return deleted, err
+	}
+	return deleted + removed, nil
+}
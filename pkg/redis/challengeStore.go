@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/SuperMatch/zapLogger"
+	Redis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -package mocks -destination mocks/challenge_store_mock.go github.com/SuperMatch/pkg/redis ChallengeStoreInterface
+
+// ChallengeStoreInterface keeps short lived values, like pending sign ins, until they are used or expire.
+type ChallengeStoreInterface interface {
+	Put(key string, value string, ttl time.Duration) error
+	Get(key string) (string, bool, error)
+	Delete(key string) (bool, error)
+}
+
+type ChallengeStore struct {
+	redisClient *Redis.Client
+}
+
+func ChallengeStoreConstructor() *ChallengeStore {
+	return &ChallengeStore{
+		redisClient: RedisClient,
+	}
+}
+
+func (c *ChallengeStore) Put(key string, value string, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := c.redisClient.Set(ctx, key, value, ttl).Err()
+	if err != nil {
+		zapLogger.Logger.Error("error in storing challenge", zap.Error(err))
+	}
+	return err
+}
+
+func (c *ChallengeStore) Get(key string) (string, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	value, err := c.redisClient.Get(ctx, key).Result()
+	if err == Redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		zapLogger.Logger.Error("error in getting challenge", zap.Error(err))
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// Delete removes a challenge and reports whether it still existed, so only one caller can complete it.
+func (c *ChallengeStore) Delete(key string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	deleted, err := c.redisClient.Del(ctx, key).Result()
+	return deleted == 1, err
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	reflect "reflect"
 
+	redis "github.com/SuperMatch/pkg/redis"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -47,6 +48,21 @@ func (mr *MockLikeDislikeCacheInterfaceMockRecorder) AddToUserMatchList(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToUserMatchList", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).AddToUserMatchList), arg0, arg1)
 }
 
+// DeleteUserData mocks base method.
+func (m *MockLikeDislikeCacheInterface) DeleteUserData(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserData", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserData indicates an expected call of DeleteUserData.
+func (mr *MockLikeDislikeCacheInterfaceMockRecorder) DeleteUserData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserData", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).DeleteUserData), arg0)
+}
+
 // GetLikeDislike mocks base method.
 func (m *MockLikeDislikeCacheInterface) GetLikeDislike(arg0 string) (*string, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +93,36 @@ func (mr *MockLikeDislikeCacheInterfaceMockRecorder) GetMatchList(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchList", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).GetMatchList), arg0)
 }
 
+// GetUserLikes mocks base method.
+func (m *MockLikeDislikeCacheInterface) GetUserLikes(arg0 string) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLikes", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLikes indicates an expected call of GetUserLikes.
+func (mr *MockLikeDislikeCacheInterfaceMockRecorder) GetUserLikes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLikes", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).GetUserLikes), arg0)
+}
+
+// HasUserLikes mocks base method.
+func (m *MockLikeDislikeCacheInterface) HasUserLikes(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUserLikes", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasUserLikes indicates an expected call of HasUserLikes.
+func (mr *MockLikeDislikeCacheInterfaceMockRecorder) HasUserLikes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUserLikes", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).HasUserLikes), arg0)
+}
+
 // PutLikeDislike mocks base method.
 func (m *MockLikeDislikeCacheInterface) PutLikeDislike(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -91,6 +137,35 @@ func (mr *MockLikeDislikeCacheInterfaceMockRecorder) PutLikeDislike(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutLikeDislike", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).PutLikeDislike), arg0, arg1)
 }
 
+// PutLiker mocks base method.
+func (m *MockLikeDislikeCacheInterface) PutLiker(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutLiker", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutLiker indicates an expected call of PutLiker.
+func (mr *MockLikeDislikeCacheInterfaceMockRecorder) PutLiker(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutLiker", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).PutLiker), arg0, arg1)
+}
+
+// RecordSwipe mocks base method.
+func (m *MockLikeDislikeCacheInterface) RecordSwipe(arg0, arg1 string, arg2 int) (redis.SwipeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSwipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(redis.SwipeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordSwipe indicates an expected call of RecordSwipe.
+func (mr *MockLikeDislikeCacheInterfaceMockRecorder) RecordSwipe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSwipe", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).RecordSwipe), arg0, arg1, arg2)
+}
+
 // RemoveFromUserMatchList mocks base method.
 func (m *MockLikeDislikeCacheInterface) RemoveFromUserMatchList(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -104,3 +179,60 @@ func (mr *MockLikeDislikeCacheInterfaceMockRecorder) RemoveFromUserMatchList(arg
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromUserMatchList", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).RemoveFromUserMatchList), arg0)
 }
+
+// RemoveLikerFromLikeeList mocks base method.
+func (m *MockLikeDislikeCacheInterface) RemoveLikerFromLikeeList(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLikerFromLikeeList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveLikerFromLikeeList indicates an expected call of RemoveLikerFromLikeeList.
+func (mr *MockLikeDislikeCacheInterfaceMockRecorder) RemoveLikerFromLikeeList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLikerFromLikeeList", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).RemoveLikerFromLikeeList), arg0, arg1)
+}
+
+// RemoveSwipe mocks base method.
+func (m *MockLikeDislikeCacheInterface) RemoveSwipe(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSwipe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSwipe indicates an expected call of RemoveSwipe.
+func (mr *MockLikeDislikeCacheInterfaceMockRecorder) RemoveSwipe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSwipe", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).RemoveSwipe), arg0, arg1)
+}
+
+// ScanSwipes mocks base method.
+func (m *MockLikeDislikeCacheInterface) ScanSwipes(arg0 func([]redis.CachedSwipe) error) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanSwipes", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanSwipes indicates an expected call of ScanSwipes.
+func (mr *MockLikeDislikeCacheInterfaceMockRecorder) ScanSwipes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanSwipes", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).ScanSwipes), arg0)
+}
+
+// SetUserLikes mocks base method.
+func (m *MockLikeDislikeCacheInterface) SetUserLikes(arg0 string, arg1 []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserLikes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserLikes indicates an expected call of SetUserLikes.
+func (mr *MockLikeDislikeCacheInterfaceMockRecorder) SetUserLikes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserLikes", reflect.TypeOf((*MockLikeDislikeCacheInterface)(nil).SetUserLikes), arg0, arg1)
+}
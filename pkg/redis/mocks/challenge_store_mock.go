@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/redis (interfaces: ChallengeStoreInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChallengeStoreInterface is a mock of ChallengeStoreInterface interface.
+type MockChallengeStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockChallengeStoreInterfaceMockRecorder
+}
+
+// MockChallengeStoreInterfaceMockRecorder is the mock recorder for MockChallengeStoreInterface.
+type MockChallengeStoreInterfaceMockRecorder struct {
+	mock *MockChallengeStoreInterface
+}
+
+// NewMockChallengeStoreInterface creates a new mock instance.
+func NewMockChallengeStoreInterface(ctrl *gomock.Controller) *MockChallengeStoreInterface {
+	mock := &MockChallengeStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockChallengeStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChallengeStoreInterface) EXPECT() *MockChallengeStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockChallengeStoreInterface) Delete(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockChallengeStoreInterfaceMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockChallengeStoreInterface)(nil).Delete), arg0)
+}
+
+// Get mocks base method.
+func (m *MockChallengeStoreInterface) Get(arg0 string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockChallengeStoreInterfaceMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockChallengeStoreInterface)(nil).Get), arg0)
+}
+
+// Put mocks base method.
+func (m *MockChallengeStoreInterface) Put(arg0, arg1 string, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockChallengeStoreInterfaceMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockChallengeStoreInterface)(nil).Put), arg0, arg1, arg2)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SuperMatch/pkg/redis (interfaces: RateLimiterInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRateLimiterInterface is a mock of RateLimiterInterface interface.
+type MockRateLimiterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimiterInterfaceMockRecorder
+}
+
+// MockRateLimiterInterfaceMockRecorder is the mock recorder for MockRateLimiterInterface.
+type MockRateLimiterInterfaceMockRecorder struct {
+	mock *MockRateLimiterInterface
+}
+
+// NewMockRateLimiterInterface creates a new mock instance.
+func NewMockRateLimiterInterface(ctrl *gomock.Controller) *MockRateLimiterInterface {
+	mock := &MockRateLimiterInterface{ctrl: ctrl}
+	mock.recorder = &MockRateLimiterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimiterInterface) EXPECT() *MockRateLimiterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockRateLimiterInterface) Count(arg0 string) (int64, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockRateLimiterInterfaceMockRecorder) Count(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockRateLimiterInterface)(nil).Count), arg0)
+}
+
+// Decrement mocks base method.
+func (m *MockRateLimiterInterface) Decrement(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrement", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decrement indicates an expected call of Decrement.
+func (mr *MockRateLimiterInterfaceMockRecorder) Decrement(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrement", reflect.TypeOf((*MockRateLimiterInterface)(nil).Decrement), arg0)
+}
+
+// Increment mocks base method.
+func (m *MockRateLimiterInterface) Increment(arg0 string, arg1 time.Duration) (int64, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockRateLimiterInterfaceMockRecorder) Increment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockRateLimiterInterface)(nil).Increment), arg0, arg1)
+}
+
+// Reset mocks base method.
+func (m *MockRateLimiterInterface) Reset(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockRateLimiterInterfaceMockRecorder) Reset(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockRateLimiterInterface)(nil).Reset), arg0)
+}
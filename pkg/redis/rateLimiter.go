@@ -0,0 +1,108 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/SuperMatch/zapLogger"
+	Redis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -package mocks -destination mocks/rate_limiter_mock.go github.com/SuperMatch/pkg/redis RateLimiterInterface
+
+// RateLimiterInterface counts events per key in fixed windows. A window starts with the first
+// event of a key and the counter is dropped when it ends.
+type RateLimiterInterface interface {
+	Increment(key string, window time.Duration) (int64, time.Duration, error)
+	Count(key string) (int64, time.Duration, error)
+	Decrement(key string) error
+	Reset(key string) error
+}
+
+// incrementScript increments a counter and starts its window on the first event, atomically so a
+// counter can never be left without an expiry.
+var incrementScript = Redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+// decrementScript takes an event back from a counter that is still in its window. A counter whose
+// window ended is not recreated.
+var decrementScript = Redis.NewScript(`
+local count = tonumber(redis.call("GET", KEYS[1]))
+if count and count > 0 then
+	redis.call("DECR", KEYS[1])
+end
+return 0
+`)
+
+type RateLimiter struct {
+	redisClient *Redis.Client
+}
+
+func RateLimiterConstructor() *RateLimiter {
+	return &RateLimiter{
+		redisClient: RedisClient,
+	}
+}
+
+// Increment adds an event to the window of key and returns the number of events in the window
+// and the time left until it ends.
+func (r *RateLimiter) Increment(key string, window time.Duration) (int64, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := incrementScript.Run(ctx, r.redisClient, []string{key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		zapLogger.Logger.Error("error in incrementing rate limit counter", zap.String("key", key), zap.Error(err))
+		return 0, 0, err
+	}
+
+	return result[0], time.Duration(result[1]) * time.Millisecond, nil
+}
+
+func (r *RateLimiter) Count(key string) (int64, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := r.redisClient.Get(ctx, key).Int64()
+	if err == Redis.Nil {
+		return 0, 0, nil
+	}
+	if err != nil {
+		zapLogger.Logger.Error("error in getting rate limit counter", zap.String("key", key), zap.Error(err))
+		return 0, 0, err
+	}
+
+	ttl, err := r.redisClient.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return count, ttl, nil
+}
+
+// Decrement takes back an event counted in the current window of key, leaving the window as it is.
+func (r *RateLimiter) Decrement(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := decrementScript.Run(ctx, r.redisClient, []string{key}).Err()
+	if err != nil {
+		zapLogger.Logger.Error("error in decrementing rate limit counter", zap.String("key", key), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *RateLimiter) Reset(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.redisClient.Del(ctx, key).Err()
+}
@@ -0,0 +1,43 @@
+package sms
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidPhoneNumber = errors.New("phone number must be in international format with a country code, e.g. +14155552671")
+
+// ParsePhoneNumber normalizes a phone number to E.164. The number must carry its country code,
+// either with a leading + or the 00 international prefix. Spaces, dashes, dots and parentheses
+// are ignored.
+func ParsePhoneNumber(phoneNumber string) (string, error) {
+	var digits strings.Builder
+	for _, r := range strings.TrimSpace(phoneNumber) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && digits.Len() == 0:
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+
+	number := digits.String()
+	if strings.HasPrefix(number, "00") {
+		number = "+" + number[2:]
+	}
+
+	if !strings.HasPrefix(number, "+") {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	// E.164 allows at most 15 digits and no country code starts with 0
+	national := number[1:]
+	if len(national) < 7 || len(national) > 15 || national[0] == '0' {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	return number, nil
+}
@@ -0,0 +1,29 @@
+package sms
+
+import (
+	"fmt"
+
+	"github.com/SuperMatch/config"
+)
+
+//go:generate mockgen -package mocks -destination mocks/sms_sender_mock.go github.com/SuperMatch/pkg/sms SMSSender
+
+// SMSSender sends a text message to an E.164 phone number and returns the provider's message id.
+type SMSSender interface {
+	Send(to string, body string) (string, error)
+}
+
+// Sender is the SMSSender selected by the SMS provider config, created at startup by CreateSMSSender.
+var Sender SMSSender
+
+func CreateSMSSender(config config.Config) error {
+	switch config.SMSConfig.Provider {
+	case "twilio":
+		Sender = NewTwilioSender(config.TwilioConfig)
+	case "log":
+		Sender = NewLogSender(config.SMSConfig.LogFile)
+	default:
+		return fmt.Errorf("unknown sms provider %q", config.SMSConfig.Provider)
+	}
+	return nil
+}
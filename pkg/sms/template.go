@@ -0,0 +1,27 @@
+package sms
+
+import (
+	"embed"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var templates = template.Must(template.ParseFS(templateFiles, "templates/*.tmpl"))
+
+type OTPMessage struct {
+	AppName          string
+	Code             string
+	ExpiresInMinutes int
+}
+
+func RenderOTPMessage(message OTPMessage) (string, error) {
+	var body strings.Builder
+	if err := templates.ExecuteTemplate(&body, "otp.tmpl", message); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(body.String()), nil
+}
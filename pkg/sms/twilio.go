@@ -0,0 +1,38 @@
+package sms
+
+import (
+	"github.com/SuperMatch/config"
+	"github.com/twilio/twilio-go"
+	twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
+)
+
+type TwilioSender struct {
+	client *twilio.RestClient
+	from   string
+}
+
+func NewTwilioSender(twilioConfig config.TwilioConfig) *TwilioSender {
+	client := twilio.NewRestClientWithParams(twilio.ClientParams{
+		Username: twilioConfig.AccountSID,
+		Password: twilioConfig.AuthToken,
+	})
+
+	return &TwilioSender{
+		client: client,
+		from:   twilioConfig.PhoneNumber,
+	}
+}
+
+func (t *TwilioSender) Send(to string, body string) (string, error) {
+	params := &twilioApi.CreateMessageParams{}
+	params.SetTo(to)
+	params.SetFrom(t.from)
+	params.SetBody(body)
+
+	resp, err := t.client.Api.CreateMessage(params)
+	if err != nil {
+		return "", err
+	}
+
+	return *resp.Sid, nil
+}
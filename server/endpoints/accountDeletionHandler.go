@@ -0,0 +1,89 @@
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SuperMatch/server/middleware"
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduleAccountDeletionHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Delete account
+//	@Description	Schedule the account and all of its data to be erased after the grace period and sign it out everywhere. Sign in again to restore it before then. The session must have signed in within the last 10 minutes.
+//	@Tags			Account
+//	@Produce		json
+//	@Success		202	{object}	dto.AccountDeletion
+//	@Failure		401	{string}	string	"Unauthorized"
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/account/deletion [post]
+func ScheduleAccountDeletionHandler(c *gin.Context) {
+	accountDeletionService := Service.NewAccountDeletionService()
+	deletion, err := accountDeletionService.ScheduleDeletion(middleware.GetUserID(c), middleware.GetAuthenticatedAt(c))
+	if errors.Is(err, Service.ErrReauthenticationRequired) {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "reauthentication required", "error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in deleting account", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, deletion)
+}
+
+// GetAccountDeletionHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Get account deletion
+//	@Description	Get the scheduled deletion of the account and when it will be erased
+//	@Tags			Account
+//	@Produce		json
+//	@Success		200	{object}	dto.AccountDeletion
+//	@Failure		404	{string}	string	"no account deletion found"
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/account/deletion [get]
+func GetAccountDeletionHandler(c *gin.Context) {
+	accountDeletionService := Service.NewAccountDeletionService()
+	deletion, err := accountDeletionService.GetScheduledDeletion(middleware.GetUserID(c))
+	if errors.Is(err, Service.ErrAccountDeletionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "no account deletion found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching account deletion", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deletion)
+}
+
+// RestoreAccountHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Restore account
+//	@Description	Cancel the scheduled deletion of the account during its grace period
+//	@Tags			Account
+//	@Produce		json
+//	@Success		200	{string}	string	"account restored successfully"
+//	@Failure		404	{string}	string	"no account deletion found"
+//	@Failure		409	{string}	string	"Conflict"
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/account/deletion [delete]
+func RestoreAccountHandler(c *gin.Context) {
+	accountDeletionService := Service.NewAccountDeletionService()
+	err := accountDeletionService.RestoreAccount(middleware.GetUserID(c))
+	if errors.Is(err, Service.ErrAccountDeletionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "no account deletion found"})
+		return
+	} else if errors.Is(err, Service.ErrAccountDeletionInProgress) {
+		c.JSON(http.StatusConflict, gin.H{"message": "error in restoring account", "error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in restoring account", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "account restored successfully"})
+}
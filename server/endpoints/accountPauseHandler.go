@@ -0,0 +1,80 @@
+package endpoints
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/server/middleware"
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// PauseAccountHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Pause account
+//	@Description	Hide the user from search results, the likes of other users and stories by location. Without until the account stays paused until it is resumed. Matches and chats are kept.
+//	@Tags			Account
+//	@Accept			json
+//	@Produce		json
+//	@Param			pauseAccount	body		dto.PauseAccount	false	"when to resume"
+//	@Success		200				{object}	dto.UserSearchProfile
+//	@Failure		400				{string}	string	"Bad request"
+//	@Failure		500				{string}	string	"Internal Server Error"
+//	@Router			/user/account/pause [post]
+func PauseAccountHandler(c *gin.Context) {
+	var request dto.PauseAccount
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request", "error": err.Error()})
+		return
+	}
+
+	userProfileService := Service.NewUserProfileService()
+	userProfile, err := userProfileService.GetUserProfileFromDB(middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching user profile", "error": err.Error()})
+		return
+	}
+
+	userSearchProfileService := Service.NewUserSearchProfileService()
+	searchProfile, err := userSearchProfileService.PauseAccount(c, userProfile, request.Until)
+	if errors.Is(err, Service.ErrInvalidPauseUntil) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request", "error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in pausing account", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, searchProfile)
+}
+
+// ResumeAccountHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Resume account
+//	@Description	Show a paused user again
+//	@Tags			Account
+//	@Produce		json
+//	@Success		200	{object}	dto.UserSearchProfile
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/account/pause [delete]
+func ResumeAccountHandler(c *gin.Context) {
+	userProfileService := Service.NewUserProfileService()
+	userProfile, err := userProfileService.GetUserProfileFromDB(middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching user profile", "error": err.Error()})
+		return
+	}
+
+	userSearchProfileService := Service.NewUserSearchProfileService()
+	searchProfile, err := userSearchProfileService.ResumeAccount(c, userProfile)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in resuming account", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, searchProfile)
+}
@@ -0,0 +1,94 @@
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SuperMatch/model/dto"
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateUserRoleHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Update user role
+//	@Description	Change the role of a user to user, moderator or admin. All sessions of the user are signed out so the new role applies from their next sign in. The admin role is refused until the user has enabled two factor authentication. Requires the admin role.
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			user_id		path		int						true	"user id"
+//	@Param			updateRole	body		dto.UpdateRoleRequest	true	"role"
+//	@Success		200			{string}	string					"role updated successfully"
+//	@Failure		400			{string}	string					"Bad request"
+//	@Failure		403			{string}	string					"Forbidden"
+//	@Failure		404			{string}	string					"user not found"
+//	@Failure		409			{string}	string					"two factor authentication not enabled"
+//	@Failure		500			{string}	string					"Internal Server Error"
+//	@Router			/admin/users/{user_id}/role [put]
+func UpdateUserRoleHandler(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid user id"})
+		return
+	}
+
+	var request dto.UpdateRoleRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	adminService := Service.NewAdminService()
+	err = adminService.UpdateUserRole(userID, request.Role)
+	if errors.Is(err, Service.ErrInvalidRole) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "error in updating role", "error": err.Error()})
+		return
+	} else if errors.Is(err, Service.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "user not found"})
+		return
+	} else if errors.Is(err, Service.ErrTOTPEnrollmentRequired) {
+		c.JSON(http.StatusConflict, gin.H{"message": "error in updating role", "error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in updating role", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "role updated successfully"})
+}
+
+// GetAccountDeletionReceiptHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Get account deletion receipt
+//	@Description	Get an account deletion with the receipt of what was erased once it is completed. Requires the admin role.
+//	@Tags			Admin
+//	@Produce		json
+//	@Param			deletion_id	path		int		true	"deletion id"
+//	@Success		200			{object}	dto.AccountDeletion
+//	@Failure		400			{string}	string	"Bad request"
+//	@Failure		403			{string}	string	"Forbidden"
+//	@Failure		404			{string}	string	"no account deletion found"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/admin/account-deletions/{deletion_id} [get]
+func GetAccountDeletionReceiptHandler(c *gin.Context) {
+	deletionID, err := strconv.ParseUint(c.Param("deletion_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid deletion id"})
+		return
+	}
+
+	accountDeletionService := Service.NewAccountDeletionService()
+	deletion, err := accountDeletionService.GetDeletion(uint(deletionID))
+	if errors.Is(err, Service.ErrAccountDeletionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "no account deletion found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching account deletion", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deletion)
+}
@@ -1,7 +1,9 @@
 package endpoints
 
 import (
+	"errors"
 	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/server/middleware"
 	"github.com/SuperMatch/service"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -18,19 +20,14 @@ import (
 //	@Produce		json
 //	@Param			media			formData	file	true	"Media"
 //	@Param			message			formData	string	true	"Message"
-//	@Param			sender_id		header		int		true	"Sender ID"
 //	@Param			receiver_id		header		int		true	"Receiver ID"
 //	@Success		200				{string}	string	"chat saved successfully"
 //	@Failure		400				{string}	string	Bad	request
+//	@Failure		403				{string}	string	"user is blocked, the match expired or the other user makes the first move"
 //	@Failure		500				{string}	string	"internal server error"
 //	@Router			/chat/message	[POST]
 func SaveMessage(c *gin.Context) {
-	userID := c.Request.Header.Get("sender_id")
-	senderID, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	senderID := middleware.GetUserID(c)
 
 	userID2 := c.Request.Header.Get("receiver_id")
 	receiverID, err := strconv.Atoi(userID2)
@@ -45,8 +42,13 @@ func SaveMessage(c *gin.Context) {
 
 	chatService := service.NewChatService()
 	err = chatService.SaveMessage(senderID, receiverID, message[0], files)
+	if errors.Is(err, service.ErrUserBlocked) || errors.Is(err, service.ErrMatchExpired) || errors.Is(err, service.ErrNotFirstMove) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "error in saving chat", "error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in saving chat", "error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "chat saved successfully"})
@@ -67,10 +69,11 @@ func SaveMessage(c *gin.Context) {
 //	@Failure		500					{string}	string	"internal server error"
 //	@Router			/chat/user/chats	[GET]
 func RetrieveUserChats(c *gin.Context) {
+	userID := middleware.GetUserID(c)
 	chatID := c.Request.Header.Get("chat_id")
 
 	chatService := service.NewChatService()
-	chats, err := chatService.RetrieveUserChats(chatID)
+	chats, err := chatService.RetrieveUserChats(userID, chatID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in retrieving chats", "error": err.Error()})
 	}
@@ -86,18 +89,12 @@ func RetrieveUserChats(c *gin.Context) {
 //	@Tags			Chat
 //	@Accept			json
 //	@Produce		json
-//	@Param			user_id			header		int		true	"User ID"
 //	@Success		200				{string}	string	"chats retrieved successfully
 //	@Failure		400				{string}	string	Bad	request
 //	@Failure		500				{string}	string	"internal server error"
 //	@Router			/chat/user/list	[GET]
 func GetUserChatsList(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	ID, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	ID := middleware.GetUserID(c)
 
 	chatService := service.NewChatService()
 	chats, err := chatService.GetUserChatsList(ID)
@@ -122,6 +119,8 @@ func GetUserChatsList(c *gin.Context) {
 //	@Failure		500						{string}	string				"internal server error"
 //	@Router			/chat/messages/status	[PUT]
 func UpdateMessagesStatus(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
 	var messageIDs model.MessagesIDs
 
 	if err := c.BindJSON(&messageIDs); err != nil {
@@ -130,7 +129,7 @@ func UpdateMessagesStatus(c *gin.Context) {
 	}
 
 	chatService := service.NewChatService()
-	err := chatService.UpdateMessagesStatus(messageIDs.MessageIDS)
+	err := chatService.UpdateMessagesStatus(userID, messageIDs.MessageIDS)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in updating messages status", "error": err.Error()})
 	}
@@ -152,6 +151,8 @@ func UpdateMessagesStatus(c *gin.Context) {
 //	@Failure		500					{string}	string			"internal server error"
 //	@Router			/chat/last/messages	[GET]
 func GetLastMessages(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
 	var chatIds model.ChatIDs
 
 	if err := c.BindJSON(&chatIds); err != nil {
@@ -160,7 +161,7 @@ func GetLastMessages(c *gin.Context) {
 	}
 
 	chatService := service.NewChatService()
-	chats, err := chatService.RetrieveLastMessages(chatIds.ChatIDS)
+	chats, err := chatService.RetrieveLastMessages(userID, chatIds.ChatIDS)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in retrieving last messages", "error": err.Error()})
 	}
@@ -0,0 +1,65 @@
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SuperMatch/server/middleware"
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestDataExportHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Export my data
+//	@Description	Request an archive of all data held about the user, built in the background. Poll the export until it is ready to get its download link. While an export is being built it is returned instead of requesting another one.
+//	@Tags			Account
+//	@Produce		json
+//	@Success		202	{object}	dto.DataExport
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/data-exports [post]
+func RequestDataExportHandler(c *gin.Context) {
+	dataExportService := Service.NewDataExportService()
+	export, err := dataExportService.RequestExport(middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in requesting data export", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, export)
+}
+
+// GetDataExportHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Get data export
+//	@Description	Get the status of a data export. Once ready it has a download_url valid for 15 minutes, until the archive expires.
+//	@Tags			Account
+//	@Produce		json
+//	@Param			export_id	path		int	true	"export id"
+//	@Success		200			{object}	dto.DataExport
+//	@Failure		400			{string}	string	"invalid export id"
+//	@Failure		404			{string}	string	"no data export found"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/user/data-exports/{export_id} [get]
+func GetDataExportHandler(c *gin.Context) {
+	exportID, err := strconv.ParseUint(c.Param("export_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid export id"})
+		return
+	}
+
+	dataExportService := Service.NewDataExportService()
+	export, err := dataExportService.GetExport(middleware.GetUserID(c), uint(exportID))
+	if errors.Is(err, Service.ErrDataExportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "no data export found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching data export", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, export)
+}
@@ -7,6 +7,7 @@ import (
 	utils "github.com/SuperMatch/utilities"
 
 	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/server/middleware"
 	Service "github.com/SuperMatch/service"
 	"github.com/SuperMatch/zapLogger"
 	"github.com/gin-gonic/gin"
@@ -17,14 +18,14 @@ import (
 //
 //	@Security		ApiKeyAuth
 //	@Summary		CreateEventIndexHandler
-//	@Description	Create an event index
+//	@Description	Create an event index. Requires the admin role.
 //	@Tags			Events
 //	@Accept			json
 //	@Produce		json
 //	@Success		200				{string}	string	"ok"
 //	@Failure		400				{string}	string	"bad request"
 //	@Failure		500				{string}	string	"internal server error"
-//	@Router			/event/index	[POST]
+//	@Router			/admin/event/index	[POST]
 func CreateEventIndexHandler(c *gin.Context) {
 
 	eventService := Service.NewEventServiceImpl()
@@ -48,7 +49,6 @@ func CreateEventIndexHandler(c *gin.Context) {
 //	@Tags			Events
 //	@Accept			json
 //	@Produce		json
-//	@Param			user_id		header		int						true	"User ID"
 //	@Param			event		body		dto.CreateEventDTO		true	"Event Data"
 //	@Success		200			{object}	dto.EventResponseDTO	"event created successfully."
 //	@Failure		400			{string}	string					Bad	request
@@ -56,12 +56,7 @@ func CreateEventIndexHandler(c *gin.Context) {
 //	@Router			/user/event	[POST]
 func CreateUserEventsHandler(c *gin.Context) {
 
-	id := c.Request.Header.Get("user_id")
-	userID, err := strconv.Atoi(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	userID := middleware.GetUserID(c)
 
 	var eventDTO dto.CreateEventDTO
 	if err := c.BindJSON(&eventDTO); err != nil {
@@ -98,18 +93,12 @@ func CreateUserEventsHandler(c *gin.Context) {
 //	@Tags			Events
 //	@Accept			json
 //	@Produce		json
-//	@Param			user_id			header		int	true	"User ID"
 //	@Success		200				{object}	dto.EventResponseDTO
 //	@Failure		400				{string}	string	Bad	request
 //	@Failure		500				{string}	string	"internal server error"
 //	@Router			/user/events	[GET]
 func GetUserEventsHandler(c *gin.Context) {
-	id := c.Request.Header.Get("user_id")
-	userID, err := strconv.Atoi(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	userID := middleware.GetUserID(c)
 
 	userProfileService := Service.NewUserProfileService()
 	userProfile, err := userProfileService.GetUserProfileFromDB(userID)
@@ -141,18 +130,12 @@ func GetUserEventsHandler(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			eventFilter		body		dto.EventFilterDTO	true	"Event Filter"
-//	@Param			user_id			header		int					true	"User ID"
 //	@Success		200				{object}	dto.EventResponseDTO
 //	@Failure		400				{string}	string	Bad	request
 //	@Failure		500				{string}	string	"internal server error"
 //	@Router			/events/search	[GET]
 func SearchEventsHandler(c *gin.Context) {
-	id := c.Request.Header.Get("user_id")
-	userID, err := strconv.Atoi(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	userID := middleware.GetUserID(c)
 
 	page, err := utils.ReadPaginationDataFromRequest(c)
 	if err != nil {
@@ -198,18 +181,12 @@ func SearchEventsHandler(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			event		body		dto.CreateEventDTO	true	"Event Data"
-//	@Param			user_id		header		int					true	"User ID"
 //	@Success		200			{object}	dto.CreateEventDTO
 //	@Failure		400			{string}	string	Bad	request
 //	@Failure		500			{string}	string	"internal server error"
 //	@Router			/user/event	[PUT]
 func UpdateUserEventHandler(c *gin.Context) {
-	id := c.Request.Header.Get("user_id")
-	userID, err := strconv.Atoi(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	userID := middleware.GetUserID(c)
 
 	var eventDTO dto.CreateEventDTO
 	if err := c.BindJSON(&eventDTO); err != nil {
@@ -247,21 +224,15 @@ func UpdateUserEventHandler(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			event		body		dto.CreateEventDTO	true	"Event Data"
-//	@Param			user_id		header		int					true	"User ID"
 //	@Param			event_id	header		int					true	"Event ID"
 //	@Success		200			{string}	string				"event deleted successfully."
 //	@Failure		400			{string}	string				Bad	request
 //	@Failure		500			{string}	string				"internal server error"
 //	@Router			/user/event	[DELETE]
 func DeleteUserEventHandler(c *gin.Context) {
-	id := c.Request.Header.Get("user_id")
-	userID, err := strconv.Atoi(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	userID := middleware.GetUserID(c)
 
-	id = c.Request.Header.Get("event_id")
+	id := c.Request.Header.Get("event_id")
 	eventID, err := strconv.Atoi(id)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -0,0 +1,259 @@
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/sms"
+	"github.com/SuperMatch/server/middleware"
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetUserIdentitiesHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Get sign in methods
+//	@Description	List the sign in methods linked to the account
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{array}		dto.Identity
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/identities [get]
+func GetUserIdentitiesHandler(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	identityService := Service.NewIdentityService()
+	identities, err := identityService.GetIdentities(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching sign in methods", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, identities)
+}
+
+// LinkGoogleHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Link Google
+//	@Description	Link a Google account as sign in method. The session must have signed in within the last 10 minutes.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			idToken	body		dto.UserIDToken	true	"ID token"
+//	@Success		200		{object}	dto.Identity
+//	@Failure		400		{string}	string	"Bad request"
+//	@Failure		401		{string}	string	"Unauthorized"
+//	@Failure		409		{string}	string	"Conflict"
+//	@Failure		500		{string}	string	"Internal Server Error"
+//	@Router			/user/identities/google [post]
+func LinkGoogleHandler(c *gin.Context) {
+	var idToken dto.UserIDToken
+	if err := c.BindJSON(&idToken); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	loginService := Service.NewLoginService()
+	resp, err := loginService.GoogleLogin(idToken.IDToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "error in GoogleLogin function", "error": err.Error()})
+		return
+	}
+
+	identityService := Service.NewIdentityService()
+	identity, err := identityService.LinkIdentity(middleware.GetUserID(c), middleware.GetAuthenticatedAt(c), model.UserIdentity{
+		Provider: model.IdentityProviderGoogle,
+		Subject:  resp.Sub,
+		Email:    resp.Email,
+	})
+	if abortOnIdentityError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, identity)
+}
+
+// LinkAppleHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Link Apple
+//	@Description	Link an Apple ID as sign in method. The session must have signed in within the last 10 minutes.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			appleLogin	body		dto.AppleLoginRequest	true	"Apple identity token"
+//	@Success		200			{object}	dto.Identity
+//	@Failure		400			{string}	string	"Bad request"
+//	@Failure		401			{string}	string	"Unauthorized"
+//	@Failure		409			{string}	string	"Conflict"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/user/identities/apple [post]
+func LinkAppleHandler(c *gin.Context) {
+	var request dto.AppleLoginRequest
+	if err := c.BindJSON(&request); err != nil || request.IdentityToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	loginService := Service.NewLoginService()
+	resp, err := loginService.AppleLogin(request)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "error in AppleLogin function", "error": err.Error()})
+		return
+	}
+
+	identityService := Service.NewIdentityService()
+	identity, err := identityService.LinkIdentity(middleware.GetUserID(c), middleware.GetAuthenticatedAt(c), model.UserIdentity{
+		Provider: model.IdentityProviderApple,
+		Subject:  resp.Subject,
+		Email:    resp.Email,
+	})
+	if abortOnIdentityError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, identity)
+}
+
+// LinkPhoneHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Link phone number
+//	@Description	Link a phone number as sign in method with an OTP sent by /user/send/otp. The session must have signed in within the last 10 minutes.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			linkPhone	body		dto.LinkPhoneRequest	true	"phone number and OTP"
+//	@Success		200			{object}	dto.Identity
+//	@Failure		400			{string}	string	"Bad request"
+//	@Failure		401			{string}	string	"Unauthorized"
+//	@Failure		409			{string}	string	"Conflict"
+//	@Failure		429			{string}	string	"Too many requests"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/user/identities/phone [post]
+func LinkPhoneHandler(c *gin.Context) {
+	var request dto.LinkPhoneRequest
+	if err := c.BindJSON(&request); err != nil || request.OTP == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	phoneNumber, err := sms.ParsePhoneNumber(request.PhoneNumber)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid phone number", "error": err.Error()})
+		return
+	}
+
+	loginService := Service.NewLoginService()
+	isOtpVerified, err := loginService.VerifyOTPService(phoneNumber, request.OTP, c.ClientIP())
+	if abortOnRateLimit(c, err) {
+		return
+	}
+	if err != nil || !isOtpVerified {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "OTP not approved, phone number verification failed"})
+		return
+	}
+
+	identityService := Service.NewIdentityService()
+	identity, err := identityService.LinkIdentity(middleware.GetUserID(c), middleware.GetAuthenticatedAt(c), model.UserIdentity{
+		Provider: model.IdentityProviderPhone,
+		Subject:  phoneNumber,
+	})
+	if abortOnIdentityError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, identity)
+}
+
+// LinkPasswordHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Link email and password
+//	@Description	Add an email and password sign in method. The session must have signed in within the last 10 minutes.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			linkPassword	body		dto.LinkPasswordRequest	true	"email and password"
+//	@Success		200				{object}	dto.Identity
+//	@Failure		400				{string}	string	"Bad request"
+//	@Failure		401				{string}	string	"Unauthorized"
+//	@Failure		409				{string}	string	"Conflict"
+//	@Failure		500				{string}	string	"Internal Server Error"
+//	@Router			/user/identities/password [post]
+func LinkPasswordHandler(c *gin.Context) {
+	var request dto.LinkPasswordRequest
+	if err := c.BindJSON(&request); err != nil || request.Email == "" || request.Password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	identityService := Service.NewIdentityService()
+	identity, err := identityService.LinkPassword(middleware.GetUserID(c), middleware.GetAuthenticatedAt(c), request.Email, request.Password)
+	if errors.Is(err, Service.ErrInvalidEmail) || errors.Is(err, Service.ErrWeakPassword) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "error in linking sign in method", "error": err.Error()})
+		return
+	}
+	if abortOnIdentityError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, identity)
+}
+
+// UnlinkIdentityHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Unlink sign in method
+//	@Description	Remove a sign in method from the account. The last one cannot be removed. The session must have signed in within the last 10 minutes.
+//	@Tags			Authentication
+//	@Produce		json
+//	@Param			identity_id	path		int		true	"identity id"
+//	@Success		200			{string}	string	"sign in method unlinked successfully"
+//	@Failure		400			{string}	string	"Bad request"
+//	@Failure		401			{string}	string	"Unauthorized"
+//	@Failure		404			{string}	string	"sign in method not found"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/user/identities/{identity_id} [delete]
+func UnlinkIdentityHandler(c *gin.Context) {
+	identityID, err := strconv.ParseUint(c.Param("identity_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid identity id"})
+		return
+	}
+
+	identityService := Service.NewIdentityService()
+	err = identityService.UnlinkIdentity(middleware.GetUserID(c), middleware.GetAuthenticatedAt(c), uint(identityID))
+	if errors.Is(err, Service.ErrIdentityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "sign in method not found"})
+		return
+	} else if errors.Is(err, Service.ErrLastIdentity) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "error in unlinking sign in method", "error": err.Error()})
+		return
+	}
+	if abortOnIdentityError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "sign in method unlinked successfully"})
+}
+
+// abortOnIdentityError responds to the errors shared by the link and unlink APIs.
+func abortOnIdentityError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, Service.ErrReauthenticationRequired):
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "reauthentication required", "error": err.Error()})
+	case errors.Is(err, Service.ErrIdentityAlreadyLinked), errors.Is(err, Service.ErrProviderAlreadyLinked):
+		c.JSON(http.StatusConflict, gin.H{"message": "error in linking sign in method", "error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in changing sign in methods", "error": err.Error()})
+	}
+	return true
+}
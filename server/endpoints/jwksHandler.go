@@ -0,0 +1,27 @@
+package endpoints
+
+import (
+	"net/http"
+
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler godoc
+//
+//	@Summary		JWKS
+//	@Description	Public keys for verifying auth tokens, in JSON Web Key Set format
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{object}	dto.JWKS
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/.well-known/jwks.json [get]
+func JWKSHandler(c *gin.Context) {
+	if Service.SigningKeys == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "signing keys are not loaded"})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, Service.SigningKeys.JWKS())
+}
@@ -2,6 +2,7 @@ package endpoints
 
 import (
 	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/server/middleware"
 	"github.com/SuperMatch/service"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -9,12 +10,7 @@ import (
 )
 
 func GetDeviceToken(c *gin.Context) {
-	ID := c.Request.Header.Get("user_id")
-	userID, err := strconv.Atoi(ID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	userID := middleware.GetUserID(c)
 
 	var deviceToken model.UserDeviceToken
 	if err := c.BindJSON(&deviceToken); err != nil {
@@ -22,9 +18,10 @@ func GetDeviceToken(c *gin.Context) {
 		return
 	}
 	deviceToken.UserID = userID
+	deviceToken.SessionID = middleware.GetSessionID(c)
 
 	notificationService := service.NewNotificationService()
-	err = notificationService.InsertDeviceToken(deviceToken)
+	err := notificationService.InsertDeviceToken(deviceToken)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in inserting device token", "error": err.Error()})
 		return
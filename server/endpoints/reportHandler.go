@@ -0,0 +1,167 @@
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/server/middleware"
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ReportContentHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Report content
+//	@Description	Report a profile, photo, nudge answer, chat message, story or event of another user for moderation. Reasons are spam, inappropriate, harassment, fake_profile, underage, scam and other. Reporting the same content again while the report is open returns the open report.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Param			report	body		dto.CreateReport	true	"report"
+//	@Success		201		{object}	dto.Report
+//	@Failure		400		{string}	string	"Bad request"
+//	@Failure		404		{string}	string	"reported content not found"
+//	@Failure		500		{string}	string	"Internal Server Error"
+//	@Router			/user/reports [post]
+func ReportContentHandler(c *gin.Context) {
+	var request dto.CreateReport
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request", "error": err.Error()})
+		return
+	}
+
+	moderationService := Service.NewModerationService()
+	report, err := moderationService.ReportContent(middleware.GetUserID(c), request)
+	if errors.Is(err, Service.ErrInvalidReport) || errors.Is(err, Service.ErrCannotReportSelf) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request", "error": err.Error()})
+		return
+	} else if errors.Is(err, Service.ErrReportedContentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "reported content not found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in reporting content", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// GetReportsHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Get moderation queue
+//	@Description	Get a page of 50 reports with the status, oldest first. Requires the moderator role.
+//	@Tags			Admin
+//	@Produce		json
+//	@Param			status	query		string	false	"open, actioned or dismissed"	default(open)
+//	@Param			page	query		int		false	"page"							default(0)
+//	@Success		200		{array}		dto.Report
+//	@Failure		400		{string}	string	"Bad request"
+//	@Failure		403		{string}	string	"Forbidden"
+//	@Failure		500		{string}	string	"Internal Server Error"
+//	@Router			/admin/reports [get]
+func GetReportsHandler(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid page"})
+		return
+	}
+
+	moderationService := Service.NewModerationService()
+	reports, err := moderationService.GetReports(c.DefaultQuery("status", model.ReportOpen), page)
+	if errors.Is(err, Service.ErrInvalidReportQueueStatus) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request", "error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching reports", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+// GetReportReviewHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Review report
+//	@Description	Get a report with the reported content in context: the photos and nudges of a reported profile, the conversation around a reported message, and the profile of the reported user with their number of open reports. The content is empty when it was removed since it was reported. Requires the moderator role.
+//	@Tags			Admin
+//	@Produce		json
+//	@Param			report_id	path		int		true	"report id"
+//	@Success		200			{object}	dto.ReportReview
+//	@Failure		400			{string}	string	"Bad request"
+//	@Failure		403			{string}	string	"Forbidden"
+//	@Failure		404			{string}	string	"report not found"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/admin/reports/{report_id} [get]
+func GetReportReviewHandler(c *gin.Context) {
+	reportID, err := strconv.ParseUint(c.Param("report_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid report id"})
+		return
+	}
+
+	moderationService := Service.NewModerationService()
+	review, err := moderationService.GetReportReview(uint(reportID))
+	if errors.Is(err, Service.ErrReportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "report not found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching report", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// ResolveReportHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Resolve report
+//	@Description	Take an action on a report: dismiss, hide_media for photos, delete_nudge, delete_story, delete_event, or suspend_account for any content. Actions other than dismiss close every open report of the same content. Reporters are notified of the outcome. Requires the moderator role.
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			report_id	path		int					true	"report id"
+//	@Param			resolution	body		dto.ResolveReport	true	"action"
+//	@Success		200			{object}	dto.Report
+//	@Failure		400			{string}	string	"Bad request"
+//	@Failure		403			{string}	string	"Forbidden"
+//	@Failure		404			{string}	string	"report not found"
+//	@Failure		409			{string}	string	"report is already resolved"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/admin/reports/{report_id}/resolve [post]
+func ResolveReportHandler(c *gin.Context) {
+	reportID, err := strconv.ParseUint(c.Param("report_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid report id"})
+		return
+	}
+
+	var request dto.ResolveReport
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request", "error": err.Error()})
+		return
+	}
+
+	moderationService := Service.NewModerationService()
+	report, err := moderationService.ResolveReport(middleware.GetUserID(c), uint(reportID), request)
+	if errors.Is(err, Service.ErrInvalidModerationAction) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request", "error": err.Error()})
+		return
+	} else if errors.Is(err, Service.ErrReportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "report not found"})
+		return
+	} else if errors.Is(err, Service.ErrReportResolved) {
+		c.JSON(http.StatusConflict, gin.H{"message": "report is already resolved"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in resolving report", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
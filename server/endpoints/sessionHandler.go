@@ -0,0 +1,107 @@
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SuperMatch/server/middleware"
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetUserSessionsHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Get user sessions
+//	@Description	List the active sessions of the user with their device and last used time
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{array}		dto.Session
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/sessions [get]
+func GetUserSessionsHandler(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	sessionService := Service.NewSessionService()
+	sessions, err := sessionService.GetActiveSessions(userID, middleware.GetSessionID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching user sessions", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeUserSessionHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Revoke user session
+//	@Description	Log out one session of the user and unregister its device push token
+//	@Tags			Authentication
+//	@Produce		json
+//	@Param			session_id	path		string	true	"session id"
+//	@Success		200			{string}	string	"session revoked successfully"
+//	@Failure		404			{string}	string	"session not found"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/user/sessions/{session_id} [delete]
+func RevokeUserSessionHandler(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	sessionService := Service.NewSessionService()
+	err := sessionService.RevokeSession(userID, c.Param("session_id"))
+	if errors.Is(err, Service.ErrSessionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "session not found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in revoking session", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "session revoked successfully"})
+}
+
+// LogoutHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Logout
+//	@Description	Log out the current session and unregister its device push token
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{string}	string	"logged out successfully"
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/logout [post]
+func LogoutHandler(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	sessionService := Service.NewSessionService()
+	err := sessionService.RevokeSession(userID, middleware.GetSessionID(c))
+	if err != nil && !errors.Is(err, Service.ErrSessionNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in logging out", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
+}
+
+// LogoutAllHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Logout everywhere
+//	@Description	Log out every session of the user and unregister all device push tokens
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{string}	string	"logged out of all sessions successfully"
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/logout/all [post]
+func LogoutAllHandler(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	sessionService := Service.NewSessionService()
+	err := sessionService.RevokeAllSessions(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in logging out of all sessions", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out of all sessions successfully"})
+}
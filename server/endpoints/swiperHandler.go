@@ -1,12 +1,16 @@
 package endpoints
 
 import (
+	"errors"
 	"github.com/SuperMatch/model/dto"
+	"math"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/SuperMatch/server/middleware"
 	"github.com/SuperMatch/service"
 )
 
@@ -15,14 +19,15 @@ import (
 //	@Security		ApiKeyAuth
 //
 //	@Summary		swipe
-//	@Description	swipe
+//	@Description	Like (type 1), super like (type 2) or dislike (any other type) a user. Likes and super likes count against daily quotas that depend on the plan and reset at midnight in the time zone of the user. The response has the likes left today and when they reset, unless likes are unlimited.
 //	@Tags			user
 //	@Accept			json
 //	@Produce		json
 //	@Param			userLike	body		dto.UserLikeDTO	true	"userLike"
-//	@Param			user_id		header		string			true	"user_id"
 //	@Success		200			{string}	string			"success"
 //	@Failure		400			{string}	string			"Bad request"
+//	@Failure		403			{string}	string			"user is blocked"
+//	@Failure		429			{string}	string			"daily limit reached"
 //	@Failure		500			{string}	string			"Internal Server Error"
 //	@Router			/user/swipe [post]
 func SwipeHandler(c *gin.Context) {
@@ -32,20 +37,61 @@ func SwipeHandler(c *gin.Context) {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
+	userLike.LikerID = middleware.GetUserID(c)
 
 	// swipe service
 	swipeService := service.NewSwipeService()
-	isMatch, err := swipeService.Swipe(userLike)
-
+	result, err := swipeService.Swipe(userLike)
+	if abortOnQuotaExceeded(c, err) {
+		return
+	}
+	if errors.Is(err, service.ErrUserBlocked) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "error in swiping", "error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
 	}
 
-	response := map[string]string{"isMatch": strconv.FormatBool(isMatch)}
+	response := gin.H{"isMatch": strconv.FormatBool(result.IsMatch)}
+	if result.RemainingLikes != nil {
+		response["remainingLikes"] = *result.RemainingLikes
+		response["likesResetAt"] = result.LikesResetAt.Format(time.RFC3339)
+	}
 	c.JSON(200, gin.H{"message": "success", "data": response})
 }
 
+// UndoSwipeHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Undo swipe
+//	@Description	Undo the last swipe of the user within a few minutes of it, unless it made a match. Premium only.
+//	@Tags			user
+//	@Produce		json
+//	@Success		200	{object}	dto.UserLikeDTO	"the swipe that was undone"
+//	@Failure		403	{string}	string			"Forbidden"
+//	@Failure		404	{string}	string			"no swipe to undo"
+//	@Failure		409	{string}	string			"Conflict"
+//	@Failure		500	{string}	string			"Internal Server Error"
+//	@Router			/user/swipe/undo [post]
+func UndoSwipeHandler(c *gin.Context) {
+	swipeService := service.NewSwipeService()
+	swipe, err := swipeService.UndoLastSwipe(middleware.GetUserID(c))
+	switch {
+	case errors.Is(err, service.ErrEntitlementRequired):
+		c.JSON(http.StatusForbidden, gin.H{"message": "rewind is a premium feature", "error": err.Error()})
+	case errors.Is(err, service.ErrNoSwipeToUndo):
+		c.JSON(http.StatusNotFound, gin.H{"message": "no swipe to undo"})
+	case errors.Is(err, service.ErrSwipeUndoExpired), errors.Is(err, service.ErrSwipeMatched):
+		c.JSON(http.StatusConflict, gin.H{"message": "error in undoing swipe", "error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in undoing swipe", "error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, gin.H{"message": "swipe undone", "data": swipe})
+	}
+}
+
 // GetUserMatchHandler godoc
 //
 //	@Security		ApiKeyAuth
@@ -53,14 +99,12 @@ func SwipeHandler(c *gin.Context) {
 //	@Description	Get User Match
 //	@Tags			user
 //	@Produce		json
-//	@Param			user_id	header		string				true	"user_"
 //	@Success		200		{array}		dto.UserMatchDTO	"successfully received user match"
 //	@Failure		400		{string}	string				"Bad request"
 //	@Failure		500		{string}	string				"error in fetching user match."
 //	@Router			/user/match [get]
 func GetUserMatchHandler(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	id, _ := strconv.Atoi(userID)
+	id := middleware.GetUserID(c)
 	swipeService := service.NewSwipeService()
 	data, err := swipeService.GetUserMatchListFromDB(id)
 	if err != nil {
@@ -77,14 +121,12 @@ func GetUserMatchHandler(c *gin.Context) {
 //	@Description	Get User Likes
 //	@Tags			user
 //	@Produce		json
-//	@Param			user_id	header		string				true	"user_"
 //	@Success		200		{array}		model.UserLikers	"successfully received user likes"
 //	@Failure		400		{string}	string				"Bad request"
 //	@Failure		500		{string}	string				"error in fetching user match."
 //	@Router			/user/likes [get]
 func GetUserLikesHandler(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	id, _ := strconv.Atoi(userID)
+	id := middleware.GetUserID(c)
 	swipeService := service.NewSwipeService()
 	data, err := swipeService.GetUserLikes(id)
 	if err != nil {
@@ -93,3 +135,86 @@ func GetUserLikesHandler(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "successfully received user likes", "data": data})
 }
+
+// abortOnQuotaExceeded responds with 429 when err is a QuotaExceededError, telling when the quota resets.
+func abortOnQuotaExceeded(c *gin.Context, err error) bool {
+	var quotaErr *service.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return false
+	}
+
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(quotaErr.ResetAt).Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"message": "daily limit reached", "error": err.Error(), "reset_at": quotaErr.ResetAt})
+	return true
+}
+
+// ExtendMatchHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Extend match
+//	@Description	Give a match nobody has messaged yet more time before it expires. A match can be extended once. Premium only.
+//	@Tags			user
+//	@Produce		json
+//	@Param			match_id	path		int		true	"matched user id"
+//	@Success		200			{string}	string	"match extended"
+//	@Failure		400			{string}	string	"invalid match id"
+//	@Failure		403			{string}	string	"Forbidden"
+//	@Failure		404			{string}	string	"match not found"
+//	@Failure		409			{string}	string	"Conflict"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/user/matches/{match_id}/extend [post]
+func ExtendMatchHandler(c *gin.Context) {
+	matchID, err := strconv.Atoi(c.Param("match_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid match id"})
+		return
+	}
+
+	matchExpiryService := service.NewMatchExpiryService()
+	expiresAt, err := matchExpiryService.ExtendMatch(middleware.GetUserID(c), matchID)
+	switch {
+	case errors.Is(err, service.ErrEntitlementRequired):
+		c.JSON(http.StatusForbidden, gin.H{"message": "extending matches is a premium feature", "error": err.Error()})
+	case errors.Is(err, service.ErrMatchNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": "match not found"})
+	case errors.Is(err, service.ErrMatchExpired), errors.Is(err, service.ErrMatchNotExpiring), errors.Is(err, service.ErrMatchAlreadyExtended):
+		c.JSON(http.StatusConflict, gin.H{"message": "error in extending match", "error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in extending match", "error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, gin.H{"message": "match extended", "data": gin.H{"expires_at": expiresAt}})
+	}
+}
+
+// OpenFirstMoveHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Open first move
+//	@Description	Let either user of a match send the first message. Only the user who has the first move can, before anyone has messaged.
+//	@Tags			user
+//	@Produce		json
+//	@Param			match_id	path		int		true	"matched user id"
+//	@Success		200			{string}	string	"either user can send the first message"
+//	@Failure		400			{string}	string	"invalid match id"
+//	@Failure		409			{string}	string	"Conflict"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/user/matches/{match_id}/first-move [delete]
+func OpenFirstMoveHandler(c *gin.Context) {
+	matchID, err := strconv.Atoi(c.Param("match_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid match id"})
+		return
+	}
+
+	chatService := service.NewChatService()
+	err = chatService.OpenFirstMove(middleware.GetUserID(c), matchID)
+	if errors.Is(err, service.ErrNoFirstMove) {
+		c.JSON(http.StatusConflict, gin.H{"message": "error in opening first move", "error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in opening first move", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "either user can send the first message"})
+}
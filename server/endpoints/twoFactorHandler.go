@@ -0,0 +1,174 @@
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/server/middleware"
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// EnrollTOTPHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Enroll authenticator app
+//	@Description	Create a secret for an authenticator app. Show provisioning_uri as a QR code, then confirm with a first code.
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{object}	dto.TOTPEnrollment
+//	@Failure		409	{string}	string	"Conflict"
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/2fa/totp/enroll [post]
+func EnrollTOTPHandler(c *gin.Context) {
+	twoFactorService := Service.NewTwoFactorService()
+	enrollment, err := twoFactorService.Enroll(middleware.GetUserID(c))
+	if abortOnTwoFactorError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// ConfirmTOTPHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Confirm authenticator app
+//	@Description	Enable two factor authentication with a first code of the authenticator app. The recovery codes are only shown in this response.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body		dto.TOTPCodeRequest	true	"authenticator code"
+//	@Success		200		{object}	dto.RecoveryCodes
+//	@Failure		400		{string}	string	"Bad request"
+//	@Failure		401		{string}	string	"Unauthorized"
+//	@Failure		409		{string}	string	"Conflict"
+//	@Failure		500		{string}	string	"Internal Server Error"
+//	@Router			/user/2fa/totp/confirm [post]
+func ConfirmTOTPHandler(c *gin.Context) {
+	var request dto.TOTPCodeRequest
+	if err := c.BindJSON(&request); err != nil || request.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	twoFactorService := Service.NewTwoFactorService()
+	recoveryCodes, err := twoFactorService.Confirm(middleware.GetUserID(c), request.Code)
+	if abortOnTwoFactorError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RecoveryCodes{RecoveryCodes: recoveryCodes})
+}
+
+// DisableTOTPHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Disable two factor authentication
+//	@Description	Disable two factor authentication with a code of the authenticator app or a recovery code. Staff accounts cannot disable it.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body		dto.TOTPCodeRequest	true	"authenticator or recovery code"
+//	@Success		200		{string}	string	"two factor authentication disabled"
+//	@Failure		400		{string}	string	"Bad request"
+//	@Failure		401		{string}	string	"Unauthorized"
+//	@Failure		403		{string}	string	"Forbidden"
+//	@Failure		409		{string}	string	"Conflict"
+//	@Failure		500		{string}	string	"Internal Server Error"
+//	@Router			/user/2fa/totp [delete]
+func DisableTOTPHandler(c *gin.Context) {
+	var request dto.TOTPCodeRequest
+	if err := c.BindJSON(&request); err != nil || request.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	twoFactorService := Service.NewTwoFactorService()
+	err := twoFactorService.Disable(middleware.GetUserID(c), request.Code)
+	if abortOnTwoFactorError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "two factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodesHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Regenerate recovery codes
+//	@Description	Replace the recovery codes of the account. The old codes stop working.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body		dto.TOTPCodeRequest	true	"authenticator or recovery code"
+//	@Success		200		{object}	dto.RecoveryCodes
+//	@Failure		400		{string}	string	"Bad request"
+//	@Failure		401		{string}	string	"Unauthorized"
+//	@Failure		500		{string}	string	"Internal Server Error"
+//	@Router			/user/2fa/recovery-codes [post]
+func RegenerateRecoveryCodesHandler(c *gin.Context) {
+	var request dto.TOTPCodeRequest
+	if err := c.BindJSON(&request); err != nil || request.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	twoFactorService := Service.NewTwoFactorService()
+	recoveryCodes, err := twoFactorService.RegenerateRecoveryCodes(middleware.GetUserID(c), request.Code)
+	if abortOnTwoFactorError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RecoveryCodes{RecoveryCodes: recoveryCodes})
+}
+
+// VerifyMFAHandler godoc
+//
+//	@Summary		Verify two factor authentication
+//	@Description	Complete a sign in that responded with mfa_required, using its mfa_token and a code of the authenticator app or a recovery code.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			verifyMFA	body		dto.VerifyMFARequest	true	"mfa token and code"
+//	@Success		200			{object}	dto.AuthToken
+//	@Failure		400			{string}	string	"Bad request"
+//	@Failure		401			{string}	string	"Unauthorized"
+//	@Failure		429			{string}	string	"Too many requests"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/user/2fa/verify [post]
+func VerifyMFAHandler(c *gin.Context) {
+	var request dto.VerifyMFARequest
+	if err := c.BindJSON(&request); err != nil || request.MFAToken == "" || request.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	loginService := Service.NewLoginService()
+	authToken, err := loginService.VerifyMFA(request.MFAToken, request.Code, sessionDevice(c))
+	if abortOnTwoFactorError(c, err) {
+		return
+	}
+
+	setAuthTokenHeaders(c, authToken)
+	c.JSON(http.StatusOK, authToken)
+}
+
+// abortOnTwoFactorError responds with the status matching a two factor authentication error.
+func abortOnTwoFactorError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case abortOnRateLimit(c, err):
+	case errors.Is(err, Service.ErrInvalidTOTPCode), errors.Is(err, Service.ErrInvalidMFAToken):
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "two factor authentication failed", "error": err.Error()})
+	case errors.Is(err, Service.ErrTOTPNotEnrolled), errors.Is(err, Service.ErrTOTPAlreadyEnrolled):
+		c.JSON(http.StatusConflict, gin.H{"message": "error in changing two factor authentication", "error": err.Error()})
+	case errors.Is(err, Service.ErrTOTPRequiredForRole):
+		c.JSON(http.StatusForbidden, gin.H{"message": "error in changing two factor authentication", "error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in two factor authentication", "error": err.Error()})
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/server/middleware"
+	Service "github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+)
+
+// BlockUserHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Block user
+//	@Description	Block a user. Any match with them is removed, neither can message the other and they no longer see each other in search results, likes or stories.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Param			blockUser	body		dto.BlockUser	true	"user to block"
+//	@Success		200			{string}	string			"user blocked"
+//	@Failure		400			{string}	string			"Bad request"
+//	@Failure		500			{string}	string			"Internal Server Error"
+//	@Router			/user/blocks [post]
+func BlockUserHandler(c *gin.Context) {
+	var request dto.BlockUser
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request", "error": err.Error()})
+		return
+	}
+
+	userBlockService := Service.NewUserBlockService()
+	err := userBlockService.BlockUser(middleware.GetUserID(c), request.UserID)
+	if errors.Is(err, Service.ErrCannotBlockSelf) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request", "error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in blocking user", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user blocked"})
+}
+
+// UnblockUserHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Unblock user
+//	@Description	Lift a block. A match removed by the block is not restored.
+//	@Tags			user
+//	@Produce		json
+//	@Param			user_id	path		int		true	"blocked user id"
+//	@Success		200		{string}	string	"user unblocked"
+//	@Failure		400		{string}	string	"invalid user id"
+//	@Failure		404		{string}	string	"user is not blocked"
+//	@Failure		500		{string}	string	"Internal Server Error"
+//	@Router			/user/blocks/{user_id} [delete]
+func UnblockUserHandler(c *gin.Context) {
+	blockedID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid user id"})
+		return
+	}
+
+	userBlockService := Service.NewUserBlockService()
+	err = userBlockService.UnblockUser(middleware.GetUserID(c), blockedID)
+	if errors.Is(err, Service.ErrBlockNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "user is not blocked"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in unblocking user", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user unblocked"})
+}
+
+// GetBlockedUsersHandler godoc
+//
+//	@Security		ApiKeyAuth
+//	@Summary		Blocked users
+//	@Description	List the users blocked by the user, latest first
+//	@Tags			user
+//	@Produce		json
+//	@Success		200	{array}		model.UserBlock
+//	@Failure		500	{string}	string	"Internal Server Error"
+//	@Router			/user/blocks [get]
+func GetBlockedUsersHandler(c *gin.Context) {
+	userBlockService := Service.NewUserBlockService()
+	blocks, err := userBlockService.GetBlockedUsers(middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching blocked users", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "successfully received blocked users.", "data": blocks})
+}
@@ -15,6 +15,7 @@ import (
 	"gorm.io/gorm"
 
 	dto "github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/server/middleware"
 	Service "github.com/SuperMatch/service"
 	"github.com/gin-gonic/gin"
 )
@@ -30,14 +31,13 @@ const user_profile_S3_bucket = "user-profile-supermatch"
 //	@Accept			json
 //	@Produce		json
 //	@Param			userProfile	body		dto.UserProfile	true	"userProfile"
-//	@Param			user_id		header		string			true	"user_id"
 //	@Success		200			{object}	dto.UserProfile	"user Profile created."
 //	@Failure		400			{string}	string			"Bad request"
 //	@Failure		500			{string}	string			"Internal Server Error"
 //	@Router			/user/profile [post]
 
 func CreateProfileHandler(c *gin.Context) {
-	userID, _ := strconv.Atoi(c.GetHeader("user_id"))
+	userID := middleware.GetUserID(c)
 
 	var profileDTO dto.UserProfile
 	if err := c.ShouldBindJSON(&profileDTO); err != nil {
@@ -77,19 +77,13 @@ func CreateProfileHandler(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			userProfile	body		dto.UserProfile	true	"userProfile"
-//	@Param			userID		header		string			true	"user_id"
 //	@Success		200			{object}	dto.UserProfile	"user Profile updated."
 //	@Failure		400			{string}	string			"Bad request"
 //	@Failure		500			{string}	string			"Internal Server Error"
 //	@Router			/user/profile [put]
 func UpdateUserProfileHandler(c *gin.Context) {
-	id := c.Request.Header.Get("user_id")
-	zapLogger.Logger.Debug(fmt.Sprintf("user_id=%s", id))
-	userID, err := strconv.Atoi(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	userID := middleware.GetUserID(c)
+	zapLogger.Logger.Debug(fmt.Sprintf("user_id=%d", userID))
 
 	var profileDTO dto.UserProfile
 	if err := c.ShouldBindJSON(&profileDTO); err != nil {
@@ -105,6 +99,10 @@ func UpdateUserProfileHandler(c *gin.Context) {
 	}
 	actualUserProfile.UserId = userID
 	profileDTO, err = userProfileService.UpdateUserProfile(actualUserProfile, profileDTO)
+	if errors.Is(err, Service.ErrInvalidTimeZone) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -119,17 +117,14 @@ func UpdateUserProfileHandler(c *gin.Context) {
 //	@Description	API to get the user profile
 //	@Tags			Profile
 //	@Produce		json
-//	@Param			user_id	header		string			true	"user_id"
 //	@Success		200		{object}	dto.UserProfile	"successfully received profile."
 //	@Failure		400		{string}	Bad				request
 //	@Failure		500		{string}	Internal		Server	Error
 //	@Header			all		{string}	token			"token"
-//	@Header			all		{string}	userID			"user_id"
 //	@Router			/user/profile [get]
 func GetUserProfileHandler(c *gin.Context) {
 
-	userID := c.Request.Header.Get("user_id")
-	id, _ := strconv.Atoi(userID)
+	id := middleware.GetUserID(c)
 	userProfileService := Service.NewUserProfileService()
 	userProfile, err := userProfileService.GetUserProfileFromDB(id)
 
@@ -150,19 +145,13 @@ func GetUserProfileHandler(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			userSearchProfile	body		dto.UserSearchProfile	true	"userSearchProfile"
-//	@Param			userID				header		string					true	"user_id"
 //	@Success		200					{object}	dto.UserProfile			"user Search Profile updated."
 //	@Failure		400					{string}	string					"Bad request"
 //	@Failure		500					{string}	string					"Internal Server Error"
 //	@Router			/user/updateSearchProfile [put]
 func UpdateSearchProfileHandler(c *gin.Context) {
 
-	userID := c.Request.Header.Get("user_id")
-	id, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
 
 	var userSearchProfile dto.UserSearchProfile
 	if err := c.ShouldBindJSON(&userSearchProfile); err != nil {
@@ -194,27 +183,15 @@ func UpdateSearchProfileHandler(c *gin.Context) {
 //	@Tags			Profile
 //	@Accept			json
 //	@Produce		json
-//	@Param			userID	header		string			true	"user_id"
 //	@Success		200		{object}	dto.UserProfile	"user Search Profile found."
 //	@Failure		400		{string}	string			"Bad request"
 //	@Failure		500		{string}	string			"Internal Server Error"
 //	@Router			/user/searchProfile [get]
 func GetUserSearchProfileHandler(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	_, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	profileID := c.Request.Header.Get("profile_id")
-	profileId, err := strconv.Atoi(profileID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	userID := middleware.GetUserID(c)
 
 	userSearchProfileService := Service.NewUserSearchProfileService()
-	userSearchProfile, err := userSearchProfileService.FindByProfileId(c, profileId)
+	userSearchProfile, err := userSearchProfileService.FindByUserId(c, userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching user profile", "error": err.Error()})
 		return
@@ -230,21 +207,14 @@ func GetUserSearchProfileHandler(c *gin.Context) {
 //	@Description	API to get user search profile
 //	@Tags			Profile
 //	@Produce		json
-//	@Param			userID	header		string							true	"user_id"
 //	@Success		200		{object}	elasticsearchPkg.UserProfile	"successfully received profiles."
 //	@Failure		400		{string}	string							"Bad request"
 //	@Failure		500		{string}	string							"Internal Server Error"
 //	@Router			/searchProfile [get]
 func SearchProfileHandler(c *gin.Context) {
 
-	userID := c.Request.Header.Get("user_id")
-
-	id, err := strconv.Atoi(userID)
+	id := middleware.GetUserID(c)
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
 	userProfileService := Service.NewUserProfileService()
 	userProfile, err := userProfileService.GetUserProfile(id)
 	if err != nil {
@@ -270,14 +240,12 @@ func SearchProfileHandler(c *gin.Context) {
 //	@Tags			Profile
 //	@Accept			json
 //	@Produce		json
-//	@Param			userID	header		string			true	"user_id"
 //	@Success		200		{object}	dto.UserProfile	"successfully received profile."
 //	@Failure		400		{string}	string			"Bad request"
 //	@Failure		500		{string}	string			"Internal Server Error"
 //	@Router			/user/upgradePremium [post]
 func PremiumUpgradeHandler(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	id, _ := strconv.Atoi(userID)
+	id := middleware.GetUserID(c)
 
 	userProfileService := Service.NewUserProfileService()
 	userProfile, err := userProfileService.GetUserProfileFromDB(id)
@@ -303,36 +271,22 @@ func PremiumUpgradeHandler(c *gin.Context) {
 //	@Accept			mpfd
 //	@Produce		json
 //	@Param			images	formData	file	true	"ImageToUpload"
-//	@Param			userID	header		string	true	"user_id"
 //	@Success		200		{string}	string	"successfully uploaded files."
 //	@Failure		400		{string}	string	"Bad request"
 //	@Failure		500		{string}	string	"Internal Server Error"
 //	@Router			/user/profileMedia [post]
 func SaveMediaHandler(c *gin.Context) {
 
-	userId := c.Request.Header.Get("user_id")
-	userProfileId := c.Request.Header.Get("user_profile_id")
-
-	id, err := strconv.Atoi(userId)
-
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	//to be used multiple profile
-	_, err = strconv.Atoi(userProfileId)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "error in getting user_profile_id.", "error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
+	userId := strconv.Itoa(id)
 
 	userProfileService := Service.NewUserProfileService()
 	s3service := Service.NewS3Service()
 
-	userProfile, _ := userProfileService.GetUserProfileFromDB(id)
+	userProfile, err := userProfileService.GetUserProfileFromDB(id)
 
 	if err != nil {
-		zapLogger.Logger.Error(fmt.Sprintf("error in fetching user profile for user_profile_id=%s", userProfileId))
+		zapLogger.Logger.Error(fmt.Sprintf("error in fetching user profile for user_id=%d", id))
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching user profile", "error": err.Error()})
 		return
 	}
@@ -384,17 +338,22 @@ func SaveMediaHandler(c *gin.Context) {
 //	@Description	Get Profile media with order and s3 signed URL
 //	@Tags			Media
 //	@Produce		json
-//	@Param			userID	header		string	true	"user_id"
 //	@Success		200		{string}	string	"successfully received profile media."
 //	@Failure		400		{string}	string	"Bad request"
 //	@Failure		500		{string}	string	"Internal Server Error"
 //	@Router			/user/profileMedia [get]
 func GetUserProfileMediaHandler(c *gin.Context) {
-	//get userID from Query Params
+	//get userID from Query Params, defaulting to the authenticated user
 
-	userID := c.Request.URL.Query().Get("user_id")
-
-	id, _ := strconv.Atoi(userID)
+	id := middleware.GetUserID(c)
+	if userID := c.Request.URL.Query().Get("user_id"); userID != "" {
+		var err error
+		id, err = strconv.Atoi(userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
 	userProfileService := Service.NewUserProfileService()
 	data, err := userProfileService.GetProfileMediaByUserId(id)
 	if err != nil {
@@ -413,20 +372,18 @@ func GetUserProfileMediaHandler(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			mediaId	query		int		true	"MediaID"
-//	@Param			userID	header		string	true	"user_id"
 //	@Success		200		{string}	string	"successfully deleted media."
 //	@Failure		400		{string}	string	"Bad request"
 //	@Failure		500		{string}	string	"Internal Server Error"
 //	@Router			/user/profileMedia [delete]
 func DeleteMediaHandler(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
+	id := middleware.GetUserID(c)
 	mediaID, ok := c.GetQuery("mediaId")
 
 	if !ok {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "mediaId is required."})
 		return
 	}
-	id, _ := strconv.Atoi(userID)
 	media, _ := strconv.Atoi(mediaID)
 
 	userProfileService := Service.NewUserProfileService()
@@ -448,19 +405,13 @@ func DeleteMediaHandler(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			advancedFilter	body		dto.AdvancedFilter	true	"advancedFilter"
-//	@Param			userID			header		string				true	"user_id"
 //	@Success		200				{object}	dto.AdvancedFilter	"advanced filters updated."
 //	@Failure		400				{string}	string				"Bad request"
 //	@Failure		500				{string}	string				"Internal Server Error"
 //	@Router			/user/advancedFilter [post]
 func UpdateAdvancedFilterHandler(c *gin.Context) {
 
-	userID := c.Request.Header.Get("user_id")
-	id, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
 
 	var advancedFilters dto.AdvancedFilter
 
@@ -494,7 +445,6 @@ func UpdateAdvancedFilterHandler(c *gin.Context) {
 //	@Tags			Profile
 //	@Accept			json
 //	@Produce		json
-//	@Param			userID	header		string						true	"user_id"
 //	@Success		200		{object}	model.InterestsListResponse	"Fetched interests list"
 //	@Failure		400		{string}	string						"Bad request"
 //	@Failure		500		{string}	string						"Internal Server Error"
@@ -519,18 +469,12 @@ func GetInterests(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			interests	body		model.InterestsCategory	true	"userInterests"
-//	@Param			userID		header		string					true	"user_id"
 //	@Success		200			{string}	string					"UserInterests created successfully"
 //	@Failure		400			{string}	string					"Bad request"
 //	@Failure		500			{string}	string					"Internal Server Error"
 //	@Router			/user/interests [post]
 func CreateUserInterests(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	id, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
 
 	var interests model.InterestsCategory
 	if err := c.ShouldBindJSON(&interests); err != nil {
@@ -556,18 +500,12 @@ func CreateUserInterests(c *gin.Context) {
 //	@Tags			Profile
 //	@Accept			json
 //	@Produce		json
-//	@Param			userID	header		string					true	"user_id"
 //	@Success		200		{object}	model.InterestsCategory	"Fetched user interests list"
 //	@Failure		400		{string}	string					"Bad request"
 //	@Failure		500		{string}	string					"Internal Server Error"
 //	@Router			/user/interests [get]
 func GetUserInterests(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	id, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
 
 	userProfileService := Service.NewUserProfileService()
 	userInterests, err := userProfileService.GetUserInterests(id)
@@ -610,18 +548,12 @@ func GetNudges(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			nudge	body		model.NudgeDetail	true	"userNudge"
-//	@Param			userID	header		string				true	"user_id"
 //	@Success		200		{object}	model.NudgeDetail	"UserNudge created successfully"
 //	@Failure		400		{string}	string				"Bad request"
 //	@Failure		500		{string}	string				"Internal Server Error"
 //	@Router			/user/nudge [post]
 func CreateUserNudge(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	id, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
 
 	var nudgesDetail model.NudgeDetail
 	if err := c.ShouldBindJSON(&nudgesDetail); err != nil {
@@ -647,18 +579,12 @@ func CreateUserNudge(c *gin.Context) {
 //	@Tags			Profile
 //	@Accept			json
 //	@Produce		json
-//	@Param			userID	header		string				true	"user_id"
 //	@Success		200		{object}	model.NudgeDetail	"Fetched user nudges list"
 //	@Failure		400		{string}	string				"Bad request"
 //	@Failure		500		{string}	string				"Internal Server Error"
 //	@Router			/user/nudges [get]
 func GetUserNudges(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	id, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
 
 	userProfileService := Service.NewUserProfileService()
 	userNudges, err := userProfileService.GetUserNudgesService(id)
@@ -678,19 +604,13 @@ func GetUserNudges(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			mediaDetails	body		model.MediaOrderId	true	"mediaDetails"
-//	@Param			userID			header		string				true	"user_id"
 //	@Success		200				{string}	string				"user media profile updated successfully"
 //	@Failure		400				{string}	string				"Bad request"
 //	@Failure		500				{string}	string				"Internal Server Error"
 //	@Router			/user/update/profileMedia [post]
 func UpdateMediaHandler(c *gin.Context) {
 
-	userId := c.Request.Header.Get("user_id")
-	id, err := strconv.Atoi(userId)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
 
 	var mediaDetails model.MediaOrderId
 	if err := c.ShouldBindJSON(&mediaDetails); err != nil {
@@ -699,7 +619,7 @@ func UpdateMediaHandler(c *gin.Context) {
 	}
 
 	userProfileService := Service.NewUserProfileService()
-	userProfile, _ := userProfileService.GetUserProfileFromDB(id)
+	userProfile, err := userProfileService.GetUserProfileFromDB(id)
 	if err != nil {
 		zapLogger.Logger.Error(fmt.Sprintf("error in fetching user profile for user_id=%d", id))
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in fetching user profile", "error": err.Error()})
@@ -724,18 +644,12 @@ func UpdateMediaHandler(c *gin.Context) {
 //	@Produce		json
 //	@Param			media			formData	file				true	"nudgeMedia"
 //	@Param			nudgeRequest	body		model.NudgeRequest	true	"nudgeRequest"
-//	@Param			userID			header		string				true	"user_id"
 //	@Success		200				{object}	model.NudgeDetail	"user nudge created successfully"
 //	@Failure		400				{string}	string				"Bad request"
 //	@Failure		500				{string}	string				"Internal Server Error"
 //	@Router			/user/nudge/media [post]
 func CreateUserNudgeWithMedia(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	id, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
 
 	form, _ := c.MultipartForm()
 	files := form.File["media"]
@@ -755,7 +669,7 @@ func CreateUserNudgeWithMedia(c *gin.Context) {
 	userProfileService := Service.NewUserProfileService()
 
 	if len(files) != 0 {
-		updatedNudgeDetails, err := userProfileService.UploadNudgeMediaToS3(userID, files[0], nudgeDetails)
+		updatedNudgeDetails, err := userProfileService.UploadNudgeMediaToS3(strconv.Itoa(id), files[0], nudgeDetails)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"message": "error in uploading media file", "error": err.Error()})
 			return
@@ -777,14 +691,14 @@ func CreateUserNudgeWithMedia(c *gin.Context) {
 //
 //	@Security		ApiKeyAuth
 //	@Summary		CreateProfileIndex
-//	@Description	Create User Profile Index
+//	@Description	Create User Profile Index. Requires the admin role.
 //	@Tags			Profile
 //	@Accept			json
 //	@Produce		json
 //	@Success		200							{string}	string	"index created successfully"
 //	@Failure		400							{string}	string	Bad	request
 //	@Failure		500							{string}	string	"internal server error"
-//	@Router			/create/user_profile/index	[POST]
+//	@Router			/admin/create/user_profile/index	[POST]
 func CreateProfileIndex(c *gin.Context) {
 	userProfileService := Service.NewUserProfileService()
 	err := userProfileService.CreateProfileIndex()
@@ -809,14 +723,13 @@ func CreateProfileIndex(c *gin.Context) {
 //	@Produce		json
 //	@Param			media			formData	file				true	"nudgeMedia"
 //	@Param			nudgeRequest	body		model.NudgeRequest	true	"nudgeRequest"
-//	@Param			userID			header		string				true	"user_id"
 //	@Param			nudgeID			header		string				true	"nudge_id"
 //	@Success		200				{object}	model.NudgeDetail	"user nudge updated successfully"
 //	@Failure		400				{string}	string				"Bad request"
 //	@Failure		500				{string}	string				"Internal Server Error"
 //	@Router			/user/nudge		[PUT]
 func UpdateUserNudge(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
+	userID := middleware.GetUserID(c)
 
 	id := c.Request.Header.Get("nudge_id")
 	nudgeID, err := strconv.Atoi(id)
@@ -844,7 +757,7 @@ func UpdateUserNudge(c *gin.Context) {
 	userProfileService := Service.NewUserProfileService()
 
 	if len(files) != 0 {
-		updatedNudgeDetails, err := userProfileService.UploadNudgeMediaToS3(userID, files[0], nudgeDetails)
+		updatedNudgeDetails, err := userProfileService.UploadNudgeMediaToS3(strconv.Itoa(userID), files[0], nudgeDetails)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"message": "error in uploading media file", "error": err.Error()})
 			return
@@ -854,7 +767,7 @@ func UpdateUserNudge(c *gin.Context) {
 		nudgeDetails.Type = "text"
 	}
 
-	userNudge, err := userProfileService.UpdateUserNudge(nudgeDetails, nudgeID)
+	userNudge, err := userProfileService.UpdateUserNudge(nudgeDetails, userID, nudgeID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in updating user nudge", "error": err.Error()})
 	}
@@ -877,6 +790,8 @@ func UpdateUserNudge(c *gin.Context) {
 //	@Failure		500			{string}	string	"internal server error"
 //	@Router			/user/nudge	[DELETE]
 func DeleteUserNudge(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
 	id := c.Request.Header.Get("nudge_id")
 	nudgeID, err := strconv.Atoi(id)
 	if err != nil {
@@ -885,7 +800,7 @@ func DeleteUserNudge(c *gin.Context) {
 	}
 
 	userProfileService := Service.NewUserProfileService()
-	err = userProfileService.DeleteUserNudge(nudgeID)
+	err = userProfileService.DeleteUserNudge(userID, nudgeID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in deleting user nudge", "error": err.Error()})
 	}
@@ -907,12 +822,7 @@ func DeleteUserNudge(c *gin.Context) {
 //	@Failure		500				{string}	string				"internal server error"
 //	@Router			/user/location	[PUT]
 func UpdateLocationHandler(c *gin.Context) {
-	id := c.Request.Header.Get("user_id")
-	userId, err := strconv.Atoi(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	userId := middleware.GetUserID(c)
 
 	var location model.UserLocation
 	if err := c.ShouldBindJSON(&location); err != nil {
@@ -961,18 +871,12 @@ func GetFiltersHandler(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			interests	body		model.InterestData	true	"userInterestsData"
-//	@Param			userID		header		string				true	"user_id"
 //	@Success		200			{string}	string				"User interests updated successfully"
 //	@Failure		400			{string}	string				"Bad request"
 //	@Failure		500			{string}	string				"Internal Server Error"
 //	@Router			/user/interests [put]
 func UpdateUserInterests(c *gin.Context) {
-	userID := c.Request.Header.Get("user_id")
-	id, err := strconv.Atoi(userID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	id := middleware.GetUserID(c)
 
 	var interests model.InterestData
 	if err := c.ShouldBindJSON(&interests); err != nil {
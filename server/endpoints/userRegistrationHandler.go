@@ -2,31 +2,30 @@ package endpoints
 
 import (
 	"errors"
+	"math"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/model/dto"
-	"github.com/SuperMatch/pkg/db"
-	"github.com/SuperMatch/pkg/db/dao"
+	"github.com/SuperMatch/pkg/sms"
 	Service "github.com/SuperMatch/service"
 	"github.com/getsentry/sentry-go"
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 // UserRegistrationHandler godoc
 //
 //	@Summary		userRegister
-//	@Description	User register API
+//	@Description	User register API. Passwords need 8 to 72 characters with at least one letter and one digit.
 //	@Tags			Authentication
 //	@Accept			json
 //	@Produce		json
 //	@Param			userRegister	body		dto.UserRegistrationDTO	true	"userRegister"
-//	@Success		200				{string}	string					"user created."
+//	@Success		201				{string}	string					"user created."
 //	@Failure		400				{string}	string					"Bad request"
+//	@Failure		409				{string}	string					"user already exist"
 //	@Failure		500				{string}	string					"Internal Server Error"
 //	@Router			/user/register [post]
 func UserRegistrationHandler(c *gin.Context) {
@@ -37,42 +36,34 @@ func UserRegistrationHandler(c *gin.Context) {
 		return
 	}
 
-	user := model.User{
-		FirstName:  userData.FirstName,
-		LastName:   userData.LastName,
-		Mobile:     userData.Mobile,
-		Code:       userData.Code,
-		Email:      userData.Email,
-		Password:   userData.Password,
-		IsActive:   true,
-		SignUpType: "password",
-	}
-
-	userDao := &dao.UserDao{
-		Connection: *db.GlobalOrm,
+	if userData.Mobile != "" {
+		mobile, err := sms.ParsePhoneNumber(userData.Mobile)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid mobile number", "error": err.Error()})
+			return
+		}
+		userData.Mobile = mobile
 	}
 
-	users, err := userDao.FindByMobileEmail(user.Mobile, user.Email)
-
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "db connection error"})
+	loginService := Service.NewLoginService()
+	userID, authToken, err := loginService.PasswordSignUp(userData, sessionDevice(c))
+	if errors.Is(err, Service.ErrInvalidEmail) || errors.Is(err, Service.ErrWeakPassword) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "error in creating user.", "error": err.Error()})
 		return
-	}
-
-	if len(users) > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "user already exist"})
+	} else if errors.Is(err, Service.ErrUserAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{"message": "user already exist"})
+		return
+	} else if abortOnAccountExists(c, err) {
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in creating user.", "error": err.Error()})
 		return
 	}
 
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(user.Password), 8)
-	user.Password = string(hashedPassword)
-	user, _ = userDao.Insert(user)
-
-	//just for test purpose only
 	var profileDTO dto.UserProfile
 
 	userProfileService := Service.NewUserProfileService()
-	actualUserProfile, err := userProfileService.GetUserProfileFromDB(int(user.ID))
+	actualUserProfile, err := userProfileService.GetUserProfileFromDB(userID)
 
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -84,26 +75,30 @@ func UserRegistrationHandler(c *gin.Context) {
 		return
 	}
 
-	actualUserProfile.UserId = int(user.ID)
+	actualUserProfile.UserId = userID
 	profileDTO, err = userProfileService.CreateUserProfile(actualUserProfile, profileDTO)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in creating user.", "error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"data": profileDTO, "message": "user created successfully."})
+
+	setAuthTokenHeaders(c, authToken)
+	c.JSON(http.StatusCreated, gin.H{"data": profileDTO, "token": authToken.Token, "refresh_token": authToken.RefreshToken, "message": "user created successfully."})
 }
 
 // LoginUser godoc
 //
 //	@Summary		userLogin
-//	@Description	User Username and Password login API
+//	@Description	User email and password login API. Too many failed attempts lock the account for a while.
 //	@Tags			Authentication
 //	@Accept			json
 //	@Produce		json
 //	@Param			userLogin	body		dto.Login	true	"userLogin"
 //	@Success		200			{string}	string		token
 //	@Failure		400			{string}	string		"Bad request"
+//	@Failure		401			{string}	string		"Unauthorized"
+//	@Failure		429			{string}	string		"Too many requests"
 //	@Failure		500			{string}	string		"Internal Server Error"
 //	@Router			/user/login [post]
 func LoginUser(c *gin.Context) {
@@ -113,57 +108,169 @@ func LoginUser(c *gin.Context) {
 		return
 	}
 
-	if strings.Contains(newUser.Username, " ") || strings.Contains(newUser.Username, "?") || strings.Contains(newUser.Password, " ") || strings.Contains(newUser.Password, "?") {
+	if newUser.Username == "" || newUser.Password == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "username or passwrod is missing."})
 		return
 	}
 
-	userRepo := dao.UserDao{
-		Connection: *db.GlobalOrm,
+	loginService := Service.NewLoginService()
+	_, authToken, err := loginService.PasswordSignIn(newUser.Username, newUser.Password, sessionDevice(c))
+	if abortOnRateLimit(c, err) || abortOnMFARequired(c, err) {
+		return
+	} else if errors.Is(err, Service.ErrInvalidCredentials) {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "username or password is wrong."})
+		return
+	} else if err != nil {
+		sentry.CaptureException(err)
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Error in signing in", "error": err.Error()})
+		return
 	}
 
-	actualUser, err := userRepo.FindByEmail(newUser.Username)
+	setAuthTokenHeaders(c, authToken)
+	c.JSON(http.StatusOK, gin.H{"token": authToken.Token, "refresh_token": authToken.RefreshToken})
+}
 
-	if err != nil {
-		sentry.CaptureException(err)
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "No such user found."})
+// ForgotPasswordHandler godoc
+//
+//	@Summary		Forgot Password
+//	@Description	API for mailing a password reset link. It responds the same whether or not the email belongs to an account.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			forgotPassword	body		dto.ForgotPasswordRequest	true	"email"
+//	@Success		200				{string}	string						"password reset email sent"
+//	@Failure		400				{string}	string						"Bad request"
+//	@Failure		500				{string}	string						"Internal Server Error"
+//	@Router			/user/password/forgot [post]
+func ForgotPasswordHandler(c *gin.Context) {
+	var request dto.ForgotPasswordRequest
+	if err := c.BindJSON(&request); err != nil || request.Email == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
 		return
 	}
 
-	if strings.ToLower(actualUser.SignUpType) != "password" {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "password didn't exists for this account. please sign in using " + actualUser.SignUpType + " method."})
+	loginService := Service.NewLoginService()
+	err := loginService.ForgotPassword(request.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in sending password reset email", "error": err.Error()})
 		return
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(actualUser.Password), []byte(newUser.Password))
+	c.JSON(http.StatusOK, gin.H{"message": "if an account exists for this email, a password reset link has been sent"})
+}
 
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "username or password is wrong."})
+// ResetPasswordHandler godoc
+//
+//	@Summary		Reset Password
+//	@Description	API for setting a new password with the token from a password reset email. All sessions of the account are signed out.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			resetPassword	body		dto.ResetPasswordRequest	true	"reset token and new password"
+//	@Success		200				{string}	string						"password reset"
+//	@Failure		400				{string}	string						"Bad request"
+//	@Failure		500				{string}	string						"Internal Server Error"
+//	@Router			/user/password/reset [post]
+func ResetPasswordHandler(c *gin.Context) {
+	var request dto.ResetPasswordRequest
+	if err := c.BindJSON(&request); err != nil || request.Token == "" || request.Password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
 		return
 	}
 
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "No such user Found."})
+	loginService := Service.NewLoginService()
+	err := loginService.ResetPassword(request.Token, request.Password)
+	if errors.Is(err, Service.ErrInvalidResetToken) || errors.Is(err, Service.ErrWeakPassword) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "error in resetting password", "error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in resetting password", "error": err.Error()})
 		return
 	}
 
-	JWTService := Service.JWTImpl{}
-	token, expiresAt, err := JWTService.GenerateToken(int(actualUser.ID), actualUser.Email, true)
+	c.JSON(http.StatusOK, gin.H{"message": "password reset successfully. please sign in again."})
+}
 
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error in generating auth token"})
+// RefreshTokenHandler godoc
+//
+//	@Summary		Refresh Token
+//	@Description	API for exchanging a refresh token for a new access and refresh token pair. Every refresh token can be used once; reusing one revokes all tokens issued from the same login.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			refreshToken	body		dto.RefreshTokenRequest	true	"refresh token"
+//	@Success		200				{object}	dto.AuthToken
+//	@Failure		400				{string}	string	"Bad request"
+//	@Failure		401				{string}	string	"Unauthorized"
+//	@Failure		500				{string}	string	"Internal Server Error"
+//	@Router			/user/token/refresh [post]
+func RefreshTokenHandler(c *gin.Context) {
+	var request dto.RefreshTokenRequest
+	if err := c.BindJSON(&request); err != nil || request.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
 		return
 	}
 
-	err = JWTService.SaveToken(actualUser, token, expiresAt)
+	loginService := Service.NewLoginService()
+	authToken, err := loginService.RefreshToken(request.RefreshToken, sessionDevice(c))
+	if errors.Is(err, Service.ErrInvalidRefreshToken) || errors.Is(err, Service.ErrRefreshTokenExpired) || errors.Is(err, Service.ErrRefreshTokenReused) {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "refresh token rejected", "error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in refreshing token", "error": err.Error()})
+		return
+	}
 
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error in saving auth token", "error": err.Error()})
+	setAuthTokenHeaders(c, authToken)
+	c.JSON(http.StatusOK, authToken)
+}
+
+// abortOnRateLimit responds with 429 and a Retry-After header when err is a rate limit error.
+func abortOnRateLimit(c *gin.Context, err error) bool {
+	var rateLimitErr *Service.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		return false
+	}
+
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"message": "too many requests", "error": err.Error()})
+	return true
+}
+
+// abortOnAccountExists responds with 409 when err is an AccountExistsError, telling the user which sign in methods the account has.
+func abortOnAccountExists(c *gin.Context, err error) bool {
+	var accountExistsErr *Service.AccountExistsError
+	if !errors.As(err, &accountExistsErr) {
+		return false
+	}
+
+	c.JSON(http.StatusConflict, gin.H{"message": "account already exists", "error": err.Error(), "providers": accountExistsErr.Providers})
+	return true
+}
+
+// abortOnMFARequired responds with the mfa token when err is a MFARequiredError. The client completes
+// the sign in by sending the token with a code to /user/2fa/verify.
+func abortOnMFARequired(c *gin.Context, err error) bool {
+	var mfaRequiredErr *Service.MFARequiredError
+	if !errors.As(err, &mfaRequiredErr) {
+		return false
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "two factor authentication required", "mfa_required": true, "mfa_token": mfaRequiredErr.Token, "mfa_expires_at": mfaRequiredErr.ExpiresAt})
+	return true
+}
+
+func sessionDevice(c *gin.Context) dto.SessionDevice {
+	return dto.SessionDevice{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
 	}
+}
 
-	c.Header("expires_at", expiresAt.String())
-	c.Header("token", token)
-	c.JSON(http.StatusOK, gin.H{"token": token})
+func setAuthTokenHeaders(c *gin.Context, authToken dto.AuthToken) {
+	c.Header("expires_at", authToken.ExpiresAt.String())
+	c.Header("token", authToken.Token)
+	c.Header("refresh_token", authToken.RefreshToken)
 }
 
 // GoogleLoginHandler godoc
@@ -193,45 +300,70 @@ func GoogleLoginHandler(c *gin.Context) {
 		return
 	}
 
-	userRepo := dao.UserDao{
-		Connection: *db.GlobalOrm,
+	identity := model.UserIdentity{
+		Provider: model.IdentityProviderGoogle,
+		Subject:  resp.Sub,
+		Email:    resp.Email,
 	}
 
-	userDetails, err := userRepo.FindByEmail(resp.Email)
-	if err == nil {
-		if strings.ToLower(userDetails.SignUpType) != "social" {
-			c.JSON(http.StatusUnauthorized, gin.H{"message": "social signin didn't exists for this account. please sign in using " + userDetails.SignUpType + " method.", "error": err.Error()})
-			return
-		}
+	identityService := Service.NewIdentityService()
+	user, authToken, err := identityService.SignIn(identity, loginService.GetUserDetailsFromGoogle(resp), sessionDevice(c))
+	if abortOnAccountExists(c, err) || abortOnMFARequired(c, err) {
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in user sign in", "error": err.Error()})
+		return
+	}
 
-		loginService := Service.NewLoginService()
-		token, expiresAt, err := loginService.UserSignIN(userDetails)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "error in user sign in", "error": err.Error()})
-			return
-		}
+	setAuthTokenHeaders(c, authToken)
+	c.JSON(http.StatusOK, gin.H{"token": authToken.Token, "refresh_token": authToken.RefreshToken, "user_id": user.ID})
+}
 
-		c.Header("expires_at", expiresAt.String())
-		c.Header("token", token)
-		c.JSON(http.StatusOK, gin.H{"token": token, "user_id": userDetails.ID})
+// AppleLoginHandler godoc
+//
+//	@Summary		AppleLoginHandler
+//	@Description	Sign in with Apple API
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			appleLogin	body		dto.AppleLoginRequest	true	"Apple identity token"
+//	@Success		200			{string}	string					token
+//	@Failure		400			{string}	string					"Bad request"
+//	@Failure		401			{string}	string					"Unauthorized"
+//	@Failure		500			{string}	string					"Internal Server Error"
+//	@Router			/user/apple/login [post]
+func AppleLoginHandler(c *gin.Context) {
+
+	var request dto.AppleLoginRequest
+	if err := c.BindJSON(&request); err != nil || request.IdentityToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
 
-	} else if errors.Is(err, gorm.ErrRecordNotFound) {
-		user := loginService.GetUserDetailsFromGoogle(resp)
-		userID, token, expiresAt, err := loginService.UserSignUP(user)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "error in user sign up", "error": err.Error()})
-			return
-		}
+	loginService := Service.NewLoginService()
+	resp, err := loginService.AppleLogin(request)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "error in AppleLogin function", "error": err.Error()})
+		return
+	}
 
-		c.Header("expires_at", expiresAt.String())
-		c.Header("token", token)
-		c.JSON(http.StatusOK, gin.H{"token": token, "user_id": userID})
+	identity := model.UserIdentity{
+		Provider: model.IdentityProviderApple,
+		Subject:  resp.Subject,
+		Email:    resp.Email,
+	}
 
-	} else {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "user is not registered", "error": err.Error()})
+	identityService := Service.NewIdentityService()
+	user, authToken, err := identityService.SignIn(identity, loginService.GetUserDetailsFromApple(resp, request), sessionDevice(c))
+	if abortOnAccountExists(c, err) || abortOnMFARequired(c, err) {
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error in user sign in", "error": err.Error()})
 		return
 	}
 
+	setAuthTokenHeaders(c, authToken)
+	c.JSON(http.StatusOK, gin.H{"token": authToken.Token, "refresh_token": authToken.RefreshToken, "user_id": user.ID})
 }
 
 // SendOTP godoc
@@ -244,6 +376,7 @@ func GoogleLoginHandler(c *gin.Context) {
 //	@Param			userData	body		dto.UserData	true	"userData"
 //	@Success		200			{string}	string			"OTP sent successfully"
 //	@Failure		400			{string}	string			"Bad request"
+//	@Failure		429			{string}	string			"Too many requests"
 //	@Failure		500			{string}	string			"Internal Server Error"
 //	@Router			/user/send/otp [post]
 func SendOTP(c *gin.Context) {
@@ -253,8 +386,17 @@ func SendOTP(c *gin.Context) {
 		return
 	}
 
+	phoneNumber, err := sms.ParsePhoneNumber(userData.PhoneNumber)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid phone number", "error": err.Error()})
+		return
+	}
+
 	loginService := Service.NewLoginService()
-	_, err := loginService.SendOTPService(userData.PhoneNumber)
+	_, err = loginService.SendOTPService(phoneNumber, c.ClientIP())
+	if abortOnRateLimit(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to send the OTP for verification"})
 		return
@@ -273,6 +415,7 @@ func SendOTP(c *gin.Context) {
 //	@Param			verifyUser	body		dto.VerifyUser	true	"verify user data"
 //	@Success		200			{string}	string			token
 //	@Failure		400			{string}	string			"Bad request"
+//	@Failure		429			{string}	string			"Too many requests"
 //	@Failure		500			{string}	string			"Internal Server Error"
 //	@Router			/user/verify/otp [post]
 func VerifyOTP(c *gin.Context) {
@@ -282,22 +425,37 @@ func VerifyOTP(c *gin.Context) {
 		return
 	}
 
+	if verifyUser.User == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		return
+	}
+
+	phoneNumber, err := sms.ParsePhoneNumber(verifyUser.User.PhoneNumber)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid phone number", "error": err.Error()})
+		return
+	}
+
 	loginService := Service.NewLoginService()
-	isOtpVerified, err := loginService.VerifyOTPService(verifyUser.User.PhoneNumber, verifyUser.OTP)
+	isOtpVerified, err := loginService.VerifyOTPService(phoneNumber, verifyUser.OTP, c.ClientIP())
+	if abortOnRateLimit(c, err) {
+		return
+	}
 	if err != nil || !isOtpVerified {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "OTP not approved, user verification failed", "error": err.Error()})
 		return
 	}
 
 	if isOtpVerified {
-		token, expiresAt, err := loginService.CheckUserExistOrNot(verifyUser.User.PhoneNumber)
-		if err != nil {
+		authToken, err := loginService.CheckUserExistOrNot(phoneNumber, sessionDevice(c))
+		if abortOnAccountExists(c, err) || abortOnMFARequired(c, err) {
+			return
+		} else if err != nil {
 			c.JSON(http.StatusForbidden, gin.H{"message": "error checking user existence", "error": err.Error()})
 			return
 		}
-		c.Header("expires_at", expiresAt.String())
-		c.Header("token", token)
-		c.JSON(http.StatusOK, gin.H{"message": "OTP verified successfully", "token": token})
+		setAuthTokenHeaders(c, authToken)
+		c.JSON(http.StatusOK, gin.H{"message": "OTP verified successfully", "token": authToken.Token, "refresh_token": authToken.RefreshToken})
 	}
 
 }
@@ -358,39 +516,3 @@ func VerifyEmail(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "email verified successfully."})
 }
-
-// DeleteUserHandler godoc
-//
-//	@Summary		Delete user handler
-//	@Description	API for deleting user by updating the email. Just for testing purpose.
-//	@Tags			For Testing Purpose Only
-//	@Accept			json
-//	@Produce		json
-//	@Param			user_id	header		int				true	"User ID"
-//	@Param			email	body		dto.UserEmail	true	"new email which will replace the old email"
-//	@Success		200		{string}	string			"user deleted successfully."
-//	@Failure		400		{string}	string			"Bad request"
-//	@Failure		500		{string}	string			"Internal Server Error"
-//	@Router			/user/delete [post]
-func DeleteUserHandler(c *gin.Context) {
-	ID := c.Request.Header.Get("user_id")
-	userID, err := strconv.Atoi(ID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
-		return
-	}
-
-	var email dto.UserEmail
-	if err := c.BindJSON(&email); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
-		return
-	}
-	loginService := Service.NewLoginService()
-	err = loginService.DeleteUser(userID, email.Email)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "error deleting user", "error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "user deleted successfully."})
-}
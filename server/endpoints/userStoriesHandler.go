@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/SuperMatch/model"
 	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
+	"github.com/SuperMatch/server/middleware"
 	Service "github.com/SuperMatch/service"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -14,14 +15,14 @@ import (
 //
 //	@Security		ApiKeyAuth
 //	@Summary		CreateStoriesIndex
-//	@Description	Create User Stories Index
+//	@Description	Create User Stories Index. Requires the admin role.
 //	@Tags			Stories
 //	@Accept			json
 //	@Produce		json
 //	@Success		200							{string}	string	"index created successfully"
 //	@Failure		400							{string}	string	Bad	request
 //	@Failure		500							{string}	string	"internal server error"
-//	@Router			/create/user_stories/index	[POST]
+//	@Router			/admin/create/user_stories/index	[POST]
 func CreateStoriesIndex(c *gin.Context) {
 	userStoriesService := Service.NewUserStoriesService()
 	err := userStoriesService.CreateStoriesIndex()
@@ -46,21 +47,21 @@ func CreateStoriesIndex(c *gin.Context) {
 //	@Produce		json
 //	@Param			media				formData	file					true	"Media"
 //	@Param			values				formData	elasticsearchPkg.Values	true	"Values"
-//	@Param			user_id				header		int						true	"User ID"
-//	@Param			user_profile_id		header		int						true	"User Profile ID"
 //	@Success		200					{string}	string					"user stories indexed successfully"
 //	@Failure		400					{string}	string					Bad	request
 //	@Failure		500					{string}	string					"internal server error"
 //	@Router			/user/stories/index	[POST]
 func IndexUserStories(c *gin.Context) {
-	id := c.GetHeader("user_id")
+	userID := middleware.GetUserID(c)
+	id := strconv.Itoa(userID)
 
-	id2 := c.GetHeader("user_profile_id")
-	userProfileID, err := strconv.Atoi(id2)
+	userProfileService := Service.NewUserProfileService()
+	userProfile, err := userProfileService.GetUserProfileFromDB(userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	userProfileID := int(userProfile.ID)
 
 	form, _ := c.MultipartForm()
 	files := form.File["media"]
@@ -164,7 +165,7 @@ func GetUserStoriesByLocation(c *gin.Context) {
 	}
 
 	userStoriesService := Service.NewUserStoriesService()
-	userStories, err := userStoriesService.GetUserStoriesByLocation(location)
+	userStories, err := userStoriesService.GetUserStoriesByLocation(middleware.GetUserID(c), location)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"message": "error in getting user stories by location",
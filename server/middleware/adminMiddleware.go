@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/SuperMatch/model"
+	Service "github.com/SuperMatch/service"
+	"github.com/SuperMatch/utilities"
+	"github.com/SuperMatch/zapLogger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// auditBodyLimit is how much of a JSON request body is kept in the audit log.
+const auditBodyLimit = 4096
+
+// RequireRole only lets requests through whose token carries role or a role above it. It has to run after AuthMiddleWare.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !model.HasRole(GetRole(c), role) {
+			zapLogger.Logger.Info("request denied for missing role", zap.Int("user_id", GetUserID(c)), zap.String("required_role", role))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "insufficient permissions"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireMFA only lets requests through from sessions that signed in with two factor authentication. It has to run after AuthMiddleWare.
+func RequireMFA() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !GetMFAVerified(c) {
+			zapLogger.Logger.Info("request denied for missing two factor authentication", zap.Int("user_id", GetUserID(c)))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "two factor authentication required, enroll and sign in again"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AuditLog writes every request it handles, with the caller and the response status, to the admin audit log.
+func AuditLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body string
+		if c.Request.Body != nil && strings.HasPrefix(c.ContentType(), "application/json") {
+			data, err := io.ReadAll(c.Request.Body)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "error in reading request."})
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(data))
+			body = utilities.TruncateString(string(data), auditBodyLimit)
+		}
+
+		c.Next()
+
+		adminService := Service.NewAdminService()
+		err := adminService.RecordAuditLog(model.AdminAuditLog{
+			UserId:      GetUserID(c),
+			Role:        GetRole(c),
+			Method:      c.Request.Method,
+			Path:        utilities.TruncateString(c.Request.URL.RequestURI(), 255),
+			Status:      c.Writer.Status(),
+			IPAddress:   c.ClientIP(),
+			UserAgent:   utilities.TruncateString(c.Request.UserAgent(), 255),
+			RequestBody: body,
+		})
+		if err != nil {
+			zapLogger.Logger.Error("error in writing admin audit log", zap.Error(err))
+		}
+	}
+}
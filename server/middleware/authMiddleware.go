@@ -1,63 +1,129 @@
 package middleware
 
 import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/zapLogger"
-	"strconv"
+	"go.uber.org/zap"
 
 	Service "github.com/SuperMatch/service"
 	"github.com/gin-gonic/gin"
 )
 
+// UserIDKey is the gin context key under which the authenticated user id is stored.
+const UserIDKey = "user_id"
+
+// SessionIDKey is the gin context key under which the session (token family) of the request is stored.
+const SessionIDKey = "session_id"
+
+// RoleKey is the gin context key under which the role of the authenticated user is stored.
+const RoleKey = "role"
+
+// AuthenticatedAtKey is the gin context key under which the time the session signed in is stored.
+const AuthenticatedAtKey = "authenticated_at"
+
+// MFAVerifiedKey is the gin context key under which it is stored whether the session passed two factor authentication.
+const MFAVerifiedKey = "mfa_verified"
+
 func AuthMiddleWare() gin.HandlerFunc {
+	return Authenticate(Service.NewJWTService())
+}
+
+// Authenticate is AuthMiddleWare checking tokens with authService.
+func Authenticate(authService *Service.JWTImpl) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		zapLogger.Logger.Debug("auth middleware is checking authentication for this request")
-		authToken := c.GetHeader("token")
-		userId := c.GetHeader("user_id")
+		authToken := readToken(c)
 		if authToken == "" {
 			zapLogger.Logger.Debug("auth middleware has found no token in this request")
-			c.AbortWithStatusJSON(401, gin.H{"message": "token is missing"})
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "token is missing"})
 			return
 		}
-		if userId == "" {
-			zapLogger.Logger.Debug("auth middleware has found no userID in this request")
-			c.AbortWithStatusJSON(401, gin.H{"message": "user_id is missing"})
+
+		claims, userToken, err := validateToken(authService, authToken)
+		if err != nil {
+			zapLogger.Logger.Debug("auth middleware has found invalid token", zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid token"})
 			return
 		}
 
-		validateToken(c, userId, authToken)
+		c.Set(UserIDKey, claims.UserID)
+		c.Set(SessionIDKey, userToken.FamilyID)
+		c.Set(RoleKey, claims.Role)
+		c.Set(MFAVerifiedKey, userToken.MFAVerified)
+		if userToken.AuthenticatedAt != nil {
+			c.Set(AuthenticatedAtKey, *userToken.AuthenticatedAt)
+		}
+
+		if err := authService.TouchToken(userToken); err != nil {
+			zapLogger.Logger.Error("auth middleware could not record token usage", zap.Error(err))
+		}
 
 		c.Next()
 		zapLogger.Logger.Debug("auth middleware has checked authentication for this request")
 	}
 }
 
-func validateToken(c *gin.Context, userId, token string) {
+// GetUserID returns the id of the user authenticated by AuthMiddleWare.
+func GetUserID(c *gin.Context) int {
+	return c.GetInt(UserIDKey)
+}
 
-	zapLogger.Logger.Debug("auth middleware is validating token")
-	authservice := &Service.JWTImpl{}
+// GetSessionID returns the session of the token authenticated by AuthMiddleWare.
+func GetSessionID(c *gin.Context) string {
+	return c.GetString(SessionIDKey)
+}
 
-	claims, err := authservice.ValidateToken(token)
+// GetRole returns the role of the user authenticated by AuthMiddleWare. Tokens issued before roles existed belong to users.
+func GetRole(c *gin.Context) string {
+	role := c.GetString(RoleKey)
+	if role == "" {
+		return model.RoleUser
+	}
+	return role
+}
 
-	if err != nil {
-		zapLogger.Logger.Debug("auth middleware has found invalid token")
-		c.AbortWithStatusJSON(401, gin.H{"message": "invalid token"})
-		return
+// GetAuthenticatedAt returns when the session of the request signed in, or the zero time if unknown.
+func GetAuthenticatedAt(c *gin.Context) time.Time {
+	return c.GetTime(AuthenticatedAtKey)
+}
+
+// GetMFAVerified reports whether the session of the request passed two factor authentication at sign in.
+func GetMFAVerified(c *gin.Context) bool {
+	return c.GetBool(MFAVerifiedKey)
+}
+
+// readToken reads the auth token from the token header, falling back to a bearer Authorization header.
+func readToken(c *gin.Context) string {
+	token := c.GetHeader("token")
+	if token != "" {
+		return token
 	}
-	userIDD, err := strconv.Atoi(userId)
 
-	if err != nil {
-		zapLogger.Logger.Debug("auth middleware has found invalid token")
-		c.AbortWithStatusJSON(401, gin.H{"message": "invalid userId"})
-		return
+	authorization := c.GetHeader("Authorization")
+	if strings.HasPrefix(authorization, "Bearer ") {
+		return strings.TrimPrefix(authorization, "Bearer ")
 	}
+	return ""
+}
+
+func validateToken(authService *Service.JWTImpl, token string) (Service.AuthClaims, model.UserToken, error) {
+
+	zapLogger.Logger.Debug("auth middleware is validating token")
 
-	if claims.UserID != userIDD {
-		zapLogger.Logger.Debug("auth middleware has found invalid token")
-		c.AbortWithStatusJSON(401, gin.H{"message": "invalid token"})
-		return
+	claims, err := authService.ValidateToken(token)
+	if err != nil {
+		return claims, model.UserToken{}, err
 	}
 
-	authservice.ValidateTokenFromDatabase(c, claims, token)
+	userToken, err := authService.ValidateTokenFromDatabase(claims, token)
+	if err != nil {
+		return claims, userToken, err
+	}
 
 	zapLogger.Logger.Debug("auth middleware has found valid token")
+	return claims, userToken, nil
 }
@@ -2,7 +2,9 @@ package server
 
 import (
 	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/server/endpoints"
+	"github.com/SuperMatch/server/middleware"
 	"github.com/SuperMatch/zapLogger"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -30,19 +32,22 @@ func ConfigRouter(deps *RouterDeps) (*gin.Engine, error) {
 
 	router.POST("/user/register", endpoints.UserRegistrationHandler)
 	router.POST("/user/login", endpoints.LoginUser)
+	router.POST("/user/password/forgot", endpoints.ForgotPasswordHandler)
+	router.POST("/user/password/reset", endpoints.ResetPasswordHandler)
 	router.POST("/user/google/login", endpoints.GoogleLoginHandler)
+	router.POST("/user/apple/login", endpoints.AppleLoginHandler)
 	router.POST("/user/send/otp", endpoints.SendOTP)
 	router.POST("/user/verify/otp", endpoints.VerifyOTP)
+	router.POST("/user/token/refresh", endpoints.RefreshTokenHandler)
 	router.POST("/user/verify/email", endpoints.SendVerificationEmail)
 	router.GET("/user/verify/email", endpoints.VerifyEmail)
-
-	//elasticsearch indexing
-	router.POST("/create/user_profile/index", endpoints.CreateProfileIndex)
-	router.POST("/create/user_stories/index", endpoints.CreateStoriesIndex)
+	router.GET("/.well-known/jwks.json", endpoints.JWKSHandler)
+	router.POST("/user/2fa/verify", endpoints.VerifyMFAHandler)
 
 	//Image uploader
 
-	//router.Use(middleware.AuthMiddleWare())
+	//every route registered below requires a valid token
+	router.Use(middleware.AuthMiddleWare())
 	//User APIs
 	router.POST("/user/upgradePremium", endpoints.PremiumUpgradeHandler)
 	router.POST("/user/profile", endpoints.CreateProfileHandler)
@@ -51,7 +56,33 @@ func ConfigRouter(deps *RouterDeps) (*gin.Engine, error) {
 	router.PUT("/user/updateSearchProfile", endpoints.UpdateSearchProfileHandler)
 	router.GET("/user/searchProfile", endpoints.GetUserSearchProfileHandler)
 	router.POST("/user/updateLocation", endpoints.UpdateLocationHandler)
-	router.POST("/user/delete", endpoints.DeleteUserHandler)
+	router.POST("/user/account/deletion", endpoints.ScheduleAccountDeletionHandler)
+	router.GET("/user/account/deletion", endpoints.GetAccountDeletionHandler)
+	router.DELETE("/user/account/deletion", endpoints.RestoreAccountHandler)
+	router.POST("/user/account/pause", endpoints.PauseAccountHandler)
+	router.DELETE("/user/account/pause", endpoints.ResumeAccountHandler)
+	router.POST("/user/data-exports", endpoints.RequestDataExportHandler)
+	router.GET("/user/data-exports/:export_id", endpoints.GetDataExportHandler)
+
+	//session APIs
+	router.GET("/user/sessions", endpoints.GetUserSessionsHandler)
+	router.DELETE("/user/sessions/:session_id", endpoints.RevokeUserSessionHandler)
+	router.POST("/user/logout", endpoints.LogoutHandler)
+	router.POST("/user/logout/all", endpoints.LogoutAllHandler)
+
+	//sign in method APIs
+	router.GET("/user/identities", endpoints.GetUserIdentitiesHandler)
+	router.POST("/user/identities/google", endpoints.LinkGoogleHandler)
+	router.POST("/user/identities/apple", endpoints.LinkAppleHandler)
+	router.POST("/user/identities/phone", endpoints.LinkPhoneHandler)
+	router.POST("/user/identities/password", endpoints.LinkPasswordHandler)
+	router.DELETE("/user/identities/:identity_id", endpoints.UnlinkIdentityHandler)
+
+	//two factor authentication APIs
+	router.POST("/user/2fa/totp/enroll", endpoints.EnrollTOTPHandler)
+	router.POST("/user/2fa/totp/confirm", endpoints.ConfirmTOTPHandler)
+	router.DELETE("/user/2fa/totp", endpoints.DisableTOTPHandler)
+	router.POST("/user/2fa/recovery-codes", endpoints.RegenerateRecoveryCodesHandler)
 
 	//user media
 	router.POST("/user/profileMedia", endpoints.SaveMediaHandler)
@@ -60,11 +91,18 @@ func ConfigRouter(deps *RouterDeps) (*gin.Engine, error) {
 	router.GET("/user/profileMedia", endpoints.GetUserProfileMediaHandler)
 	router.PUT("/user/advanced-filters", endpoints.UpdateAdvancedFilterHandler)
 	router.GET("/user/matches", endpoints.GetUserMatchHandler)
+	router.POST("/user/matches/:match_id/extend", endpoints.ExtendMatchHandler)
+	router.DELETE("/user/matches/:match_id/first-move", endpoints.OpenFirstMoveHandler)
 	router.GET("/user/likes", endpoints.GetUserLikesHandler)
 
 	//Public use APIS
 	router.GET("/searchProfile", endpoints.SearchProfileHandler)
 	router.POST("/user/swipe", endpoints.SwipeHandler)
+	router.POST("/user/swipe/undo", endpoints.UndoSwipeHandler)
+	router.POST("/user/blocks", endpoints.BlockUserHandler)
+	router.GET("/user/blocks", endpoints.GetBlockedUsersHandler)
+	router.DELETE("/user/blocks/:user_id", endpoints.UnblockUserHandler)
+	router.POST("/user/reports", endpoints.ReportContentHandler)
 	router.GET("/interests", endpoints.GetInterests)
 	router.POST("/user/interests", endpoints.CreateUserInterests)
 	router.GET("/user/interests", endpoints.GetUserInterests)
@@ -90,7 +128,6 @@ func ConfigRouter(deps *RouterDeps) (*gin.Engine, error) {
 	router.GET("/user/stories/search/location", endpoints.GetUserStoriesByLocation)
 
 	//event APIs
-	router.POST("/event/index", endpoints.CreateEventIndexHandler)
 	router.POST("/user/event", endpoints.CreateUserEventsHandler)
 	router.GET("/user/event", endpoints.GetUserEventsHandler)
 	router.PUT("/user/event", endpoints.UpdateUserEventHandler)
@@ -98,7 +135,22 @@ func ConfigRouter(deps *RouterDeps) (*gin.Engine, error) {
 	router.GET("/events/search", endpoints.SearchEventsHandler)
 
 	router.POST("/user/device/token", endpoints.GetDeviceToken)
-	router.POST("/user/send/notification", endpoints.SendNotificationToUser)
+
+	//admin APIs, only for sessions signed in with two factor authentication. every call is written to the audit log,
+	//the ones refused by the role and two factor checks too
+	admin := router.Group("/admin", middleware.AuditLog(), middleware.RequireRole(model.RoleAdmin), middleware.RequireMFA())
+	admin.POST("/create/user_profile/index", endpoints.CreateProfileIndex)
+	admin.POST("/create/user_stories/index", endpoints.CreateStoriesIndex)
+	admin.POST("/event/index", endpoints.CreateEventIndexHandler)
+	admin.POST("/user/send/notification", endpoints.SendNotificationToUser)
+	admin.PUT("/users/:user_id/role", endpoints.UpdateUserRoleHandler)
+	admin.GET("/account-deletions/:deletion_id", endpoints.GetAccountDeletionReceiptHandler)
+
+	//moderation queue, open to moderators as well as admins
+	reports := router.Group("/admin/reports", middleware.AuditLog(), middleware.RequireRole(model.RoleModerator), middleware.RequireMFA())
+	reports.GET("", endpoints.GetReportsHandler)
+	reports.GET("/:report_id", endpoints.GetReportReviewHandler)
+	reports.POST("/:report_id/resolve", endpoints.ResolveReportHandler)
 
 	return router, nil
 }
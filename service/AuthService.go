@@ -2,49 +2,86 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"github.com/SuperMatch/utilities"
 	"github.com/SuperMatch/zapLogger"
-	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"time"
 
 	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
 	"github.com/SuperMatch/pkg/db"
 	"github.com/SuperMatch/pkg/db/dao"
 	"github.com/golang-jwt/jwt"
 )
 
 type JWTService interface {
-	GenerateToken(userId int64, email string, isUser bool) (string, error)
+	GenerateToken(userId int, email string, role string) (string, time.Time, error)
 	ValidateToken(token string) (*jwt.Token, error)
-	SaveToken(user model.User, token string, expiresAt time.Time) error
-	ValidateTokenFromDatabase(token string) (model.UserToken, error)
+	GenerateRefreshToken() (string, time.Time, error)
+	SaveToken(user model.User, authToken dto.AuthToken, session LoginSession, device dto.SessionDevice) error
+	ValidateTokenFromDatabase(claims AuthClaims, token string) (model.UserToken, error)
+	ConsumeRefreshToken(refreshToken string) (model.UserToken, error)
+	TouchToken(userToken model.UserToken) error
 }
 
-const SecretKey = "secretKey"
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+
+	tokenTouchInterval = time.Minute
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenExpired = errors.New("refresh token expired")
+	ErrRefreshTokenReused  = errors.New("refresh token already used, all sessions of this login are revoked")
+)
 
 type JWTImpl struct {
+	UserDao  dao.UserRepository
+	TokenDao dao.UserTokenRepository
+}
+
+func NewJWTService() *JWTImpl {
+	return &JWTImpl{
+		UserDao:  &dao.UserDao{Connection: *db.GlobalOrm},
+		TokenDao: &dao.UserTokenDao{Connection: *db.GlobalOrm},
+	}
 }
 
 type AuthClaims struct {
 	jwt.StandardClaims
-	UserID int `json:"userdId"`
+	UserID int    `json:"userdId"`
+	Role   string `json:"role,omitempty"`
 }
 
-func (j *JWTImpl) GenerateToken(userId int, email string, isUser bool) (string, time.Time, error) {
+func (j *JWTImpl) GenerateToken(userId int, email string, role string) (string, time.Time, error) {
+
+	if SigningKeys == nil {
+		return "", time.Now(), errors.New("signing keys are not loaded")
+	}
 
-	expiresAt := time.Now().Add(100000000 * time.Minute)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, AuthClaims{
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenTTL)
+
+	signedToken, err := SigningKeys.Sign(AuthClaims{
 		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.NewString(),
 			Subject:   email,
+			IssuedAt:  now.Unix(),
 			ExpiresAt: expiresAt.Unix(),
 		},
 		UserID: int(userId),
+		Role:   role,
 	})
 
-	signedToken, err := token.SignedString([]byte(SecretKey))
-
 	if err != nil {
 		return "", time.Now(), err
 	}
@@ -55,16 +92,11 @@ func (j *JWTImpl) ValidateToken(token string) (AuthClaims, error) {
 
 	var claims AuthClaims
 
-	keyFunc := func(token *jwt.Token) (interface{}, error) {
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
-			return nil, errors.New("invalid token")
-		}
-
-		return []byte(SecretKey), nil
+	if SigningKeys == nil {
+		return AuthClaims{}, errors.New("signing keys are not loaded")
 	}
 
-	_, err := jwt.ParseWithClaims(token, &claims, keyFunc)
+	_, err := jwt.ParseWithClaims(token, &claims, SigningKeys.KeyFunc)
 
 	if err != nil {
 		return AuthClaims{}, err
@@ -73,49 +105,111 @@ func (j *JWTImpl) ValidateToken(token string) (AuthClaims, error) {
 	return claims, nil
 }
 
-func (j *JWTImpl) ValidateTokenFromDatabase(c *gin.Context, claims AuthClaims, token string) {
-
-	newUserRepo := dao.UserDao{
-		Connection: *db.GlobalOrm,
-	}
+func (j *JWTImpl) ValidateTokenFromDatabase(claims AuthClaims, token string) (model.UserToken, error) {
 
-	_, err := newUserRepo.FindById(claims.UserID)
+	_, err := j.UserDao.FindById(claims.UserID)
 
 	if err != nil {
-		c.AbortWithStatusJSON(400, gin.H{"message": "no active user found."})
-		return
-	}
-
-	tokenDao := &dao.UserTokenDao{
-		Connection: *db.GlobalOrm,
+		zapLogger.Logger.Debug("no active user found for token", zap.Error(err))
+		return model.UserToken{}, errors.New("no active user found")
 	}
 
-	userToken, err := tokenDao.FindByTokenAndUserId(context.Background(), token, claims.UserID)
+	userToken, err := j.TokenDao.FindByTokenAndUserId(context.Background(), token, claims.UserID)
 
 	if err != nil || !userToken.IsActive {
 		zapLogger.Logger.Debug("no user found with active token in database")
-		c.AbortWithStatusJSON(401, gin.H{"message": "token expird."})
-		return
+		return userToken, errors.New("token expired")
 	}
-}
 
-func (j *JWTImpl) SaveToken(user model.User, token string, expiresAt time.Time) error {
+	return userToken, nil
+}
 
-	tokenDao := &dao.UserTokenDao{
-		Connection: *db.GlobalOrm,
+// GenerateRefreshToken returns an opaque random refresh token and its expiry.
+func (j *JWTImpl) GenerateRefreshToken() (string, time.Time, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
 	}
 
+	return base64.RawURLEncoding.EncodeToString(b), time.Now().Add(RefreshTokenTTL), nil
+}
+
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func (j *JWTImpl) SaveToken(user model.User, authToken dto.AuthToken, session LoginSession, device dto.SessionDevice) error {
+
+	now := time.Now()
 	userToken := model.UserToken{
-		UserId:    int(user.ID),
-		Token:     token,
-		IsActive:  true,
-		ExpiresAt: expiresAt,
+		UserId:           int(user.ID),
+		Token:            authToken.Token,
+		IsActive:         true,
+		ExpiresAt:        authToken.ExpiresAt,
+		RefreshToken:     hashRefreshToken(authToken.RefreshToken),
+		RefreshExpiresAt: authToken.RefreshExpiresAt,
+		FamilyID:         session.FamilyID,
+		UserAgent:        utilities.TruncateString(device.UserAgent, 255),
+		IPAddress:        device.IPAddress,
+		LastUsedAt:       &now,
+		MFAVerified:      session.MFAVerified,
+	}
+	if !session.AuthenticatedAt.IsZero() {
+		userToken.AuthenticatedAt = &session.AuthenticatedAt
 	}
 
-	_, err := tokenDao.Insert(context.Background(), userToken)
+	_, err := j.TokenDao.Insert(context.Background(), userToken)
 	if err != nil {
 		zapLogger.Logger.Error("userToken is not inserted", zap.Error(err))
 		return err
 	}
 	return nil
 }
+
+// ConsumeRefreshToken deactivates the token pair a refresh token belongs to and returns it, so the
+// caller can issue the next pair in the same family. A refresh token can only be consumed once;
+// presenting it again revokes every active token of its family.
+func (j *JWTImpl) ConsumeRefreshToken(refreshToken string) (model.UserToken, error) {
+	ctx := context.Background()
+
+	userToken, err := j.TokenDao.FindByRefreshToken(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		zapLogger.Logger.Debug("no token found for refresh token", zap.Error(err))
+		return model.UserToken{}, ErrInvalidRefreshToken
+	}
+
+	consumed := false
+	if userToken.IsActive {
+		consumed, err = j.TokenDao.DeactivateToken(ctx, userToken.ID)
+		if err != nil {
+			zapLogger.Logger.Error("error in deactivating refreshed token", zap.Error(err))
+			return model.UserToken{}, err
+		}
+	}
+
+	if !consumed {
+		zapLogger.Logger.Warn("refresh token reused, revoking token family", zap.Int("user_id", userToken.UserId), zap.String("family_id", userToken.FamilyID))
+		if err := j.TokenDao.RevokeFamily(ctx, userToken.FamilyID); err != nil {
+			zapLogger.Logger.Error("error in revoking token family", zap.Error(err))
+			return model.UserToken{}, err
+		}
+		return model.UserToken{}, ErrRefreshTokenReused
+	}
+
+	if time.Now().After(userToken.RefreshExpiresAt) {
+		return model.UserToken{}, ErrRefreshTokenExpired
+	}
+
+	return userToken, nil
+}
+
+// TouchToken records that the token was just used. The write is skipped when the token was used recently.
+func (j *JWTImpl) TouchToken(userToken model.UserToken) error {
+	now := time.Now()
+	if userToken.LastUsedAt != nil && now.Sub(*userToken.LastUsedAt) < tokenTouchInterval {
+		return nil
+	}
+
+	return j.TokenDao.TouchToken(context.Background(), userToken.ID, now)
+}
@@ -7,11 +7,10 @@ import (
 	"github.com/SuperMatch/config"
 	"github.com/SuperMatch/zapLogger"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"io"
 	"mime/multipart"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/ses"
 	"go.uber.org/zap"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -25,8 +24,9 @@ type S3ServiceInterface interface {
 	GetFilesInFolder(bucket string, folder string) ([]string, error)
 	SignS3FilesUrl(bucket string, url string) (string, error)
 	DeleteFile(bucket string, key string) error
-	SendEmailInput(receiverEmail, htmlBody, title string) *ses.SendEmailInput
-	SendEmail(input *ses.SendEmailInput) error
+	DeleteFolder(bucket string, folder string) (int64, error)
+	ListFiles(bucket string, folder string) ([]string, error)
+	DownloadFile(bucket string, key string, w io.Writer) error
 }
 
 type S3Service struct {
@@ -36,10 +36,6 @@ func NewS3Service() *S3Service {
 	return &S3Service{}
 }
 
-const (
-	sender = "whonishchal@gmail.com"
-)
-
 func (s *S3Service) UploadFileToS3(bucket string, path string, file multipart.File, fileName string) (string, error) {
 
 	sess, err := session.NewSession(&aws.Config{
@@ -155,64 +151,119 @@ func (s *S3Service) DeleteFile(bucket string, key string) error {
 	return nil
 }
 
-func (s *S3Service) SendEmailInput(receiverEmail, htmlBody, title string) *ses.SendEmailInput {
-	return &ses.SendEmailInput{
-		Destination: &ses.Destination{
-			CcAddresses: []*string{},
-			ToAddresses: []*string{
-				aws.String(receiverEmail),
-			},
-		},
-		Message: &ses.Message{
-			Body: &ses.Body{
-				Html: &ses.Content{
-					Charset: aws.String("utf-8"),
-					Data:    aws.String(htmlBody),
-				},
-			},
-			Subject: &ses.Content{
-				Charset: aws.String("utf-8"),
-				Data:    aws.String(title),
-			},
-		},
-		Source: aws.String(sender),
+// DeleteFolder deletes every object whose key starts with folder and returns how many were deleted.
+func (s *S3Service) DeleteFolder(bucket string, folder string) (int64, error) {
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(config.AppConfig.AWSConfig.Region),
+		Credentials: credentials.NewStaticCredentials(config.AppConfig.AWSConfig.AccessKeyID, config.AppConfig.AWSConfig.AccessKeySecret, ""),
+	})
+
+	if err != nil {
+		zapLogger.Logger.Error("Failed to create session:", zap.Error(err))
+		return 0, errors.New("failed to connect s3")
 	}
 
+	svc := s3.New(sess)
+	zapLogger.Logger.Debug(fmt.Sprintf("deleting folder from s3 bucket=%s for prefix=%s", bucket, folder))
+
+	var deleted int64
+	var deleteErr error
+	err = svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(folder),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		if len(page.Contents) == 0 {
+			return true
+		}
+
+		objects := make([]*s3.ObjectIdentifier, 0, len(page.Contents))
+		for _, object := range page.Contents {
+			objects = append(objects, &s3.ObjectIdentifier{Key: object.Key})
+		}
+
+		output, err := svc.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			deleteErr = err
+			return false
+		}
+		if len(output.Errors) > 0 {
+			deleteErr = fmt.Errorf("error in deleting %d objects from s3: %s", len(output.Errors), aws.StringValue(output.Errors[0].Message))
+			return false
+		}
+
+		deleted += int64(len(objects))
+		return true
+	})
+	if err == nil {
+		err = deleteErr
+	}
+	if err != nil {
+		zapLogger.Logger.Error("error in deleting folder from s3", zap.Error(err))
+		return deleted, err
+	}
+	return deleted, nil
 }
 
-func (s *S3Service) SendEmail(input *ses.SendEmailInput) error {
+// ListFiles returns the keys of every object whose key starts with folder.
+func (s *S3Service) ListFiles(bucket string, folder string) ([]string, error) {
 
 	sess, err := session.NewSession(&aws.Config{
-		Region:      aws.String(config.AppConfig.AWSConfig.Region), // Replace with your desired region
+		Region:      aws.String(config.AppConfig.AWSConfig.Region),
 		Credentials: credentials.NewStaticCredentials(config.AppConfig.AWSConfig.AccessKeyID, config.AppConfig.AWSConfig.AccessKeySecret, ""),
 	})
 
 	if err != nil {
 		zapLogger.Logger.Error("Failed to create session:", zap.Error(err))
-		return errors.New("failed to connect email service")
+		return nil, errors.New("failed to connect s3")
 	}
 
-	svc := ses.New(sess)
+	svc := s3.New(sess)
 
-	_, err = svc.SendEmail(input)
-	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case ses.ErrCodeMessageRejected:
-				zapLogger.Logger.Error(fmt.Sprintf(ses.ErrCodeMessageRejected+": %s", aerr.Error()))
-			case ses.ErrCodeMailFromDomainNotVerifiedException:
-				zapLogger.Logger.Error(fmt.Sprintf(ses.ErrCodeMailFromDomainNotVerifiedException+": %s", aerr.Error()))
-			case ses.ErrCodeConfigurationSetDoesNotExistException:
-				zapLogger.Logger.Error(fmt.Sprintf(ses.ErrCodeConfigurationSetDoesNotExistException+": %s", aerr.Error()))
-			default:
-				zapLogger.Logger.Error(fmt.Sprintf(aerr.Error()))
-			}
-		} else {
-			// Print the error, cast err to awserr.Error to get the Code and
-			// Message from an error.
-			zapLogger.Logger.Error(fmt.Sprintf(err.Error()))
+	keys := make([]string, 0)
+	err = svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(folder),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
 		}
+		return true
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in listing folder from s3", zap.Error(err))
+		return nil, err
 	}
-	zapLogger.Logger.Info("Email sent successfully.")
-	return nil
+	return keys, nil
+}
+
+// DownloadFile copies the object stored at key to w.
+func (s *S3Service) DownloadFile(bucket string, key string, w io.Writer) error {
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(config.AppConfig.AWSConfig.Region),
+		Credentials: credentials.NewStaticCredentials(config.AppConfig.AWSConfig.AccessKeyID, config.AppConfig.AWSConfig.AccessKeySecret, ""),
+	})
+
+	if err != nil {
+		zapLogger.Logger.Error("Failed to create session:", zap.Error(err))
+		return errors.New("failed to connect s3")
+	}
+
+	svc := s3.New(sess)
+	output, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in downloading file from s3", zap.String("key", key), zap.Error(err))
+		return err
+	}
+	defer output.Body.Close()
+
+	_, err = io.Copy(w, output.Body)
+	return err
 }
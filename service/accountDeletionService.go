@@ -0,0 +1,295 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/pkg/db/dao"
+	pkg "github.com/SuperMatch/pkg/elasticSeach"
+	"github.com/SuperMatch/pkg/redis"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// accountDeletionInterval is how often the worker looks for accounts whose grace period is over.
+	accountDeletionInterval  = 10 * time.Minute
+	accountDeletionBatchSize = 20
+)
+
+var (
+	ErrAccountDeletionNotFound   = errors.New("no account deletion found")
+	ErrAccountDeletionInProgress = errors.New("the account is already being erased and cannot be restored")
+)
+
+type AccountDeletionServiceInterface interface {
+	ScheduleDeletion(userID int, authenticatedAt time.Time) (dto.AccountDeletion, error)
+	GetScheduledDeletion(userID int) (dto.AccountDeletion, error)
+	RestoreAccount(userID int) error
+	GetDeletion(deletionID uint) (dto.AccountDeletion, error)
+	ProcessDueDeletions() (int, error)
+}
+
+type AccountDeletionService struct {
+	AccountDeletionDao  dao.AccountDeletionRepository
+	ProfileIndex        pkg.ElasticSearchIndexer
+	StoriesIndex        pkg.UserStoriesIndexer
+	EventIndex          pkg.EventIndexer
+	LikeDislikeCache    redis.LikeDislikeCacheInterface
+	S3Service           S3ServiceInterface
+	SessionService      SessionServiceInterface
+	NotificationService NotificationServiceInterface
+	GracePeriod         time.Duration
+}
+
+func NewAccountDeletionService() *AccountDeletionService {
+	return &AccountDeletionService{
+		AccountDeletionDao:  &dao.AccountDeletionDao{Connection: *db.GlobalOrm},
+		ProfileIndex:        pkg.NewElasticSearchIndexerImpl(),
+		StoriesIndex:        pkg.NewUserStoriesIndexerImpl(),
+		EventIndex:          pkg.NewEventIndexerImpl(),
+		LikeDislikeCache:    redis.LikeDislikeCacheConstructor(),
+		S3Service:           NewS3Service(),
+		SessionService:      NewSessionService(),
+		NotificationService: NewNotificationService(),
+		GracePeriod:         config.ConfigValue.AccountDeletionConfig.GracePeriod,
+	}
+}
+
+// ScheduleDeletion schedules the account to be erased once the grace period is over and signs it
+// out everywhere. Until then the user can sign in again and restore it. The session has to be
+// signed in recently.
+func (a *AccountDeletionService) ScheduleDeletion(userID int, authenticatedAt time.Time) (dto.AccountDeletion, error) {
+	if err := checkRecentAuthentication(authenticatedAt); err != nil {
+		return dto.AccountDeletion{}, err
+	}
+
+	deletion, err := a.AccountDeletionDao.FindScheduledByUserId(userID)
+	if err == nil {
+		return accountDeletionDTO(deletion), nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		zapLogger.Logger.Error("error in finding scheduled account deletion", zap.Error(err))
+		return dto.AccountDeletion{}, err
+	}
+
+	deletion, err = a.AccountDeletionDao.Insert(model.AccountDeletion{
+		UserId:     userID,
+		Status:     model.AccountDeletionScheduled,
+		PurgeAfter: time.Now().Add(a.GracePeriod),
+	})
+	if err != nil {
+		return dto.AccountDeletion{}, err
+	}
+
+	err = a.SessionService.RevokeAllSessions(userID)
+	if err != nil {
+		return dto.AccountDeletion{}, err
+	}
+
+	return accountDeletionDTO(deletion), nil
+}
+
+func (a *AccountDeletionService) GetScheduledDeletion(userID int) (dto.AccountDeletion, error) {
+	deletion, err := a.AccountDeletionDao.FindScheduledByUserId(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.AccountDeletion{}, ErrAccountDeletionNotFound
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding scheduled account deletion", zap.Error(err))
+		return dto.AccountDeletion{}, err
+	}
+
+	return accountDeletionDTO(deletion), nil
+}
+
+// RestoreAccount cancels the scheduled deletion of the account while it is in its grace period.
+func (a *AccountDeletionService) RestoreAccount(userID int) error {
+	deletion, err := a.AccountDeletionDao.FindScheduledByUserId(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrAccountDeletionNotFound
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding scheduled account deletion", zap.Error(err))
+		return err
+	}
+
+	cancelled, err := a.AccountDeletionDao.Cancel(deletion.ID)
+	if err != nil {
+		zapLogger.Logger.Error("error in cancelling account deletion", zap.Error(err))
+		return err
+	}
+	if !cancelled {
+		return ErrAccountDeletionInProgress
+	}
+
+	return nil
+}
+
+// GetDeletion returns a deletion with its receipt.
+func (a *AccountDeletionService) GetDeletion(deletionID uint) (dto.AccountDeletion, error) {
+	deletion, err := a.AccountDeletionDao.FindById(deletionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.AccountDeletion{}, ErrAccountDeletionNotFound
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding account deletion", zap.Error(err))
+		return dto.AccountDeletion{}, err
+	}
+
+	return accountDeletionDTO(deletion), nil
+}
+
+// ProcessDueDeletions erases the accounts whose grace period is over and returns how many were
+// erased. A deletion that fails is retried on the next run.
+func (a *AccountDeletionService) ProcessDueDeletions() (int, error) {
+	deletions, err := a.AccountDeletionDao.FindDue(time.Now(), accountDeletionBatchSize)
+	if err != nil {
+		zapLogger.Logger.Error("error in finding due account deletions", zap.Error(err))
+		return 0, err
+	}
+
+	erased := 0
+	for _, deletion := range deletions {
+		claimed, err := a.AccountDeletionDao.Claim(deletion.ID)
+		if err != nil {
+			zapLogger.Logger.Error("error in claiming account deletion", zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		receipt, err := a.eraseAccount(deletion)
+		if err != nil {
+			zapLogger.Logger.Error("error in erasing account", zap.Uint("deletion_id", deletion.ID), zap.Error(err))
+			if err := a.AccountDeletionDao.Fail(deletion.ID, err.Error()); err != nil {
+				zapLogger.Logger.Error("error in recording failed account deletion", zap.Error(err))
+			}
+			continue
+		}
+
+		receiptJSON, err := json.Marshal(receipt)
+		if err != nil {
+			return erased, err
+		}
+
+		err = a.AccountDeletionDao.Complete(deletion.ID, string(receiptJSON))
+		if err != nil {
+			return erased, err
+		}
+
+		zapLogger.Logger.Info("account erased", zap.Uint("deletion_id", deletion.ID), zap.Int("user_id", deletion.UserId))
+		erased++
+	}
+
+	return erased, nil
+}
+
+// eraseAccount purges the account from every store. Search, cache and files are purged first, so
+// the rows they are found by are still there when a failed erasure is retried.
+func (a *AccountDeletionService) eraseAccount(deletion model.AccountDeletion) (model.ErasureReceipt, error) {
+	userID := deletion.UserId
+	receipt := model.ErasureReceipt{
+		DeletionID:      deletion.ID,
+		UserID:          userID,
+		SearchDocuments: map[string]int64{},
+		S3Objects:       map[string]int64{},
+	}
+
+	profileIDs, err := a.AccountDeletionDao.FindProfileIds(userID)
+	if err != nil {
+		return receipt, err
+	}
+
+	for _, profileID := range profileIDs {
+		deleted, err := a.ProfileIndex.DeleteUserProfile(profileID)
+		if err != nil {
+			return receipt, err
+		}
+		if deleted {
+			receipt.SearchDocuments["user_profile"]++
+		}
+
+		stories, err := a.StoriesIndex.DeleteUserStories(profileID)
+		if err != nil {
+			return receipt, err
+		}
+		receipt.SearchDocuments["user_stories"] += stories
+	}
+
+	events, err := a.EventIndex.DeleteUserEvents(userID)
+	if err != nil {
+		return receipt, err
+	}
+	receipt.SearchDocuments["events"] = events
+
+	receipt.RedisKeys, err = a.LikeDislikeCache.DeleteUserData(strconv.Itoa(userID))
+	if err != nil {
+		return receipt, err
+	}
+
+	for _, bucket := range []string{user_profile_S3_bucket, user_stories_S3_bucket} {
+		objects, err := a.S3Service.DeleteFolder(bucket, strconv.Itoa(userID)+"/")
+		if err != nil {
+			return receipt, err
+		}
+		receipt.S3Objects[bucket] = objects
+	}
+
+	// unregister the push endpoints before their rows are gone
+	err = a.NotificationService.RemoveAllDeviceTokens(userID)
+	if err != nil {
+		return receipt, err
+	}
+
+	receipt.Rows, err = a.AccountDeletionDao.EraseUserData(userID)
+	if err != nil {
+		return receipt, err
+	}
+
+	receipt.CompletedAt = time.Now()
+	return receipt, nil
+}
+
+func accountDeletionDTO(deletion model.AccountDeletion) dto.AccountDeletion {
+	result := dto.AccountDeletion{
+		ID:          deletion.ID,
+		UserID:      deletion.UserId,
+		Status:      deletion.Status,
+		RequestedAt: deletion.CreatedAt,
+		PurgeAfter:  deletion.PurgeAfter,
+		CancelledAt: deletion.CancelledAt,
+		CompletedAt: deletion.CompletedAt,
+	}
+
+	if deletion.Receipt != "" {
+		var receipt model.ErasureReceipt
+		if err := json.Unmarshal([]byte(deletion.Receipt), &receipt); err == nil {
+			result.Receipt = &receipt
+		}
+	}
+	return result
+}
+
+// StartAccountDeletionWorker erases accounts whose grace period is over in the background, for as long as the process runs.
+func StartAccountDeletionWorker() {
+	go func() {
+		ticker := time.NewTicker(accountDeletionInterval)
+		defer ticker.Stop()
+
+		for {
+			erased, err := NewAccountDeletionService().ProcessDueDeletions()
+			if err != nil {
+				zapLogger.Logger.Error("error in processing account deletions", zap.Error(err))
+			} else if erased > 0 {
+				zapLogger.Logger.Info("processed account deletions", zap.Int("erased", erased))
+			}
+
+			<-ticker.C
+		}
+	}()
+}
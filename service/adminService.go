@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/pkg/db/dao"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidRole  = errors.New("invalid role")
+	ErrUserNotFound = errors.New("user not found")
+	// ErrTOTPEnrollmentRequired is returned when a role that needs two factor authentication is
+	// granted to a user who has not enabled it yet.
+	ErrTOTPEnrollmentRequired = errors.New("the user has to enable two factor authentication before getting this role")
+)
+
+type AdminServiceInterface interface {
+	RecordAuditLog(auditLog model.AdminAuditLog) error
+	UpdateUserRole(userID int, role string) error
+}
+
+type AdminService struct {
+	AuditLogDao      dao.AdminAuditLogRepository
+	UserDao          dao.UserRepository
+	SessionService   SessionServiceInterface
+	TwoFactorService TwoFactorServiceInterface
+}
+
+func NewAdminService() *AdminService {
+	return &AdminService{
+		AuditLogDao:      &dao.AdminAuditLogDao{Connection: *db.GlobalOrm},
+		UserDao:          &dao.UserDao{Connection: *db.GlobalOrm},
+		SessionService:   NewSessionService(),
+		TwoFactorService: NewTwoFactorService(),
+	}
+}
+
+func (a *AdminService) RecordAuditLog(auditLog model.AdminAuditLog) error {
+	return a.AuditLogDao.Insert(auditLog)
+}
+
+// UpdateUserRole changes the role of a user. Tokens carry the role, so every session of the user is
+// revoked and the new role applies from their next sign in. The admin role is only granted to users
+// who enabled two factor authentication.
+func (a *AdminService) UpdateUserRole(userID int, role string) error {
+	if !model.IsValidRole(role) {
+		return ErrInvalidRole
+	}
+
+	if IsTOTPRequired(role) {
+		enrolled, err := a.TwoFactorService.IsEnrolled(userID)
+		if err != nil {
+			return err
+		}
+		if !enrolled {
+			return ErrTOTPEnrollmentRequired
+		}
+	}
+
+	found, err := a.UserDao.UpdateRole(userID, role)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrUserNotFound
+	}
+
+	err = a.SessionService.RevokeAllSessions(userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in revoking sessions after role change", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/jwks"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	appleJWKSURL = "https://appleid.apple.com/auth/keys"
+	appleIssuer  = "https://appleid.apple.com"
+)
+
+// AppleKeySource is the cached Apple JWKS shared by every AppleTokenVerifier.
+var AppleKeySource jwks.KeySource = jwks.NewRemoteKeySource(appleJWKSURL)
+
+var ErrInvalidAppleToken = errors.New("invalid apple identity token")
+
+// AppleTokenVerifier checks Sign in with Apple identity tokens against Apple's published signing keys.
+type AppleTokenVerifier struct {
+	keySource jwks.KeySource
+	clientIDs []string
+}
+
+func NewAppleTokenVerifier(keySource jwks.KeySource, clientIDs []string) *AppleTokenVerifier {
+	return &AppleTokenVerifier{
+		keySource: keySource,
+		clientIDs: clientIDs,
+	}
+}
+
+// Verify checks the identity token. When nonce is not empty the token must carry its sha256 hash.
+func (a *AppleTokenVerifier) Verify(identityToken string, nonce string) (*dto.AppleTokenInfo, error) {
+	tokenInfo := &dto.AppleTokenInfo{}
+
+	_, err := jwt.ParseWithClaims(identityToken, tokenInfo, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, ErrInvalidAppleToken
+		}
+		kid, _ := token.Header["kid"].(string)
+		return a.keySource.Key(kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !tokenInfo.VerifyIssuer(appleIssuer, true) {
+		return nil, errors.New("apple identity token has an invalid issuer")
+	}
+
+	audienceValid := false
+	for _, clientID := range a.clientIDs {
+		if clientID != "" && tokenInfo.VerifyAudience(clientID, true) {
+			audienceValid = true
+			break
+		}
+	}
+	if !audienceValid {
+		return nil, errors.New("apple identity token was issued for another client")
+	}
+
+	if tokenInfo.Subject == "" {
+		return nil, ErrInvalidAppleToken
+	}
+
+	if nonce != "" {
+		sum := sha256.Sum256([]byte(nonce))
+		if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(tokenInfo.Nonce)) != 1 {
+			return nil, errors.New("apple identity token nonce does not match")
+		}
+	}
+
+	return tokenInfo, nil
+}
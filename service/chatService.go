@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/SuperMatch/config"
 	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/pkg/db/dao"
 	"github.com/SuperMatch/zapLogger"
@@ -16,10 +17,11 @@ import (
 
 type ChatServiceInterface interface {
 	SaveMessage(senderID, receiverID int, message string, media []*multipart.FileHeader) error
-	RetrieveUserChats(chatID string) ([]model.ChatDetails, error)
+	RetrieveUserChats(userID int, chatID string) ([]model.ChatDetails, error)
 	GetUserChatsList(userID int) ([]model.ChatValues, error)
-	UpdateMessagesStatus(messageIDs []int) error
-	RetrieveLastMessages(chatIDs []string) ([]model.ChatDetails, error)
+	UpdateMessagesStatus(userID int, messageIDs []int) error
+	RetrieveLastMessages(userID int, chatIDs []string) ([]model.ChatDetails, error)
+	OpenFirstMove(userID, matchID int) error
 }
 
 type ChatService struct {
@@ -29,6 +31,8 @@ type ChatService struct {
 	userMatchDao       dao.UserMatchDao
 	userProfileDao     dao.UserProfileRepository
 	userMedia          dao.UserMediaRepository
+	userBlockDao       dao.UserBlockRepository
+	firstMoveConfig    config.FirstMoveConfig
 }
 
 func NewChatService() *ChatService {
@@ -39,10 +43,39 @@ func NewChatService() *ChatService {
 		userMatchDao:       dao.NewUserMatchDaoImpl(),
 		userProfileDao:     dao.NewUserProfileRepository(),
 		userMedia:          dao.NewUserMediaRepository(),
+		userBlockDao:       dao.NewUserBlockRepository(),
+		firstMoveConfig:    config.ConfigValue.FirstMoveConfig,
 	}
 }
 
+// SaveMessage stores a message of the sender to a match, unless either of them blocked the other, the
+// match expired, or the other user has to make the first move.
 func (c *ChatService) SaveMessage(senderID, receiverID int, message string, media []*multipart.FileHeader) error {
+	blocked, err := c.userBlockDao.IsBlocked(senderID, receiverID)
+	if err != nil {
+		zapLogger.Logger.Error("error in checking user block", zap.Error(err))
+		return err
+	}
+	if blocked {
+		return ErrUserBlocked
+	}
+
+	userMatch, err := c.userMatchDao.FindByUserIdMatchId(context.Background(), senderID, receiverID)
+	if err != nil {
+		zapLogger.Logger.Error("error in finding user match")
+		return err
+	}
+
+	firstMessage := userMatch.FirstMessageAt == nil
+	if firstMessage {
+		if userMatch.ExpiresAt != nil && !userMatch.ExpiresAt.After(time.Now()) {
+			return ErrMatchExpired
+		}
+		if turnOf(c.firstMoveConfig.Mode, senderID, userMatch.FirstMoveBy, nil) == TurnThem {
+			return ErrNotFirstMove
+		}
+	}
+
 	mediaUrl := ""
 	if media != nil {
 		fileExt := filepath.Ext(media[0].Filename)
@@ -63,12 +96,6 @@ func (c *ChatService) SaveMessage(senderID, receiverID int, message string, medi
 		mediaUrl = result
 	}
 
-	userMatch, err := c.userMatchDao.FindByUserIdMatchId(context.Background(), senderID, receiverID)
-	if err != nil {
-		zapLogger.Logger.Error("error in finding user match")
-		return err
-	}
-
 	chatDetails := model.ChatDetails{
 		SenderID:   senderID,
 		ReceiverID: receiverID,
@@ -83,10 +110,29 @@ func (c *ChatService) SaveMessage(senderID, receiverID int, message string, medi
 		return err
 	}
 
+	// the first message keeps the match for good, once it is stored
+	if firstMessage {
+		err = c.userMatchDao.MarkFirstMessage(context.Background(), senderID, receiverID, time.Now())
+		if err != nil {
+			zapLogger.Logger.Error("error in marking first message of match", zap.Error(err))
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (c *ChatService) RetrieveUserChats(chatID string) ([]model.ChatDetails, error) {
+func (c *ChatService) RetrieveUserChats(userID int, chatID string) ([]model.ChatDetails, error) {
+	isMember, err := c.isChatMember(userID, chatID)
+	if err != nil {
+		zapLogger.Logger.Error("error in checking chat membership", zap.Error(err))
+		return nil, err
+	}
+	if !isMember {
+		zapLogger.Logger.Error(fmt.Sprintf("user %d is not a member of chat %s", userID, chatID))
+		return nil, errors.New("chat not found")
+	}
+
 	chats, err := c.chatDao.RetrieveUserChats(chatID)
 	if err != nil {
 		zapLogger.Logger.Error("error in retrieving chats")
@@ -159,8 +205,8 @@ func (c *ChatService) GetUserChatsList(userID int) ([]model.ChatValues, error) {
 	return chatList, nil
 }
 
-func (c *ChatService) UpdateMessagesStatus(messageIDs []int) error {
-	err := c.chatDao.UpdateMessageReadStatus(messageIDs)
+func (c *ChatService) UpdateMessagesStatus(userID int, messageIDs []int) error {
+	err := c.chatDao.UpdateMessageReadStatus(userID, messageIDs)
 	if err != nil {
 		zapLogger.Logger.Error("error in updating messages status")
 		return err
@@ -169,8 +215,8 @@ func (c *ChatService) UpdateMessagesStatus(messageIDs []int) error {
 	return nil
 }
 
-func (c *ChatService) RetrieveLastMessages(chatIDs []string) ([]model.ChatDetails, error) {
-	lastMessages, err := c.chatDao.RetrieveLastMessages(chatIDs)
+func (c *ChatService) RetrieveLastMessages(userID int, chatIDs []string) ([]model.ChatDetails, error) {
+	lastMessages, err := c.chatDao.RetrieveLastMessages(userID, chatIDs)
 	if err != nil {
 		zapLogger.Logger.Error("error in retrieving last messages")
 		return nil, err
@@ -190,3 +236,32 @@ func (c *ChatService) RetrieveLastMessages(chatIDs []string) ([]model.ChatDetail
 
 	return lastMessages, nil
 }
+
+// OpenFirstMove lets either user of a match send the first message, when the user had the first move
+// and has not used it yet.
+func (c *ChatService) OpenFirstMove(userID, matchID int) error {
+	opened, err := c.userMatchDao.OpenFirstMove(context.Background(), userID, matchID)
+	if err != nil {
+		zapLogger.Logger.Error("error in opening first move of match", zap.Error(err))
+		return err
+	}
+	if !opened {
+		return ErrNoFirstMove
+	}
+	return nil
+}
+
+// isChatMember reports whether the user is one of the two matched users owning the chat.
+func (c *ChatService) isChatMember(userID int, chatID string) (bool, error) {
+	userMatches, err := c.userMatchDao.FindByUserId(context.Background(), userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, match := range userMatches {
+		if match.ChatID != nil && *match.ChatID == chatID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
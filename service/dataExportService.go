@@ -0,0 +1,309 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/pkg/db/dao"
+	pkg "github.com/SuperMatch/pkg/elasticSeach"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// dataExportInterval is how often the worker builds requested exports and deletes expired ones.
+	dataExportInterval  = time.Minute
+	dataExportBatchSize = 5
+	// dataExportRetention is how long a built archive can be downloaded before it is deleted.
+	dataExportRetention   = 7 * 24 * time.Hour
+	dataExportMaxAttempts = 3
+	dataExportFolder      = "data_export"
+)
+
+var ErrDataExportNotFound = errors.New("no data export found")
+
+type DataExportServiceInterface interface {
+	RequestExport(userID int) (dto.DataExport, error)
+	GetExport(userID int, exportID uint) (dto.DataExport, error)
+	ProcessPendingExports() (int, error)
+	DeleteExpiredExports() (int, error)
+}
+
+type DataExportService struct {
+	DataExportDao dao.DataExportRepository
+	StoriesIndex  pkg.UserStoriesIndexer
+	S3Service     S3ServiceInterface
+}
+
+func NewDataExportService() *DataExportService {
+	return &DataExportService{
+		DataExportDao: &dao.DataExportDao{Connection: *db.GlobalOrm},
+		StoriesIndex:  pkg.NewUserStoriesIndexerImpl(),
+		S3Service:     NewS3Service(),
+	}
+}
+
+// RequestExport queues an export of the data of the user. While an export is being built it is
+// returned instead of queueing another one.
+func (d *DataExportService) RequestExport(userID int) (dto.DataExport, error) {
+	export, err := d.DataExportDao.FindActiveByUserId(userID)
+	if err == nil {
+		return d.dataExportDTO(export), nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		zapLogger.Logger.Error("error in finding active data export", zap.Error(err))
+		return dto.DataExport{}, err
+	}
+
+	export, err = d.DataExportDao.Insert(model.DataExport{
+		UserId: userID,
+		Status: model.DataExportPending,
+	})
+	if err != nil {
+		return dto.DataExport{}, err
+	}
+
+	return d.dataExportDTO(export), nil
+}
+
+// GetExport returns an export of the user, with a fresh download link when it is ready.
+func (d *DataExportService) GetExport(userID int, exportID uint) (dto.DataExport, error) {
+	export, err := d.DataExportDao.FindById(userID, exportID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.DataExport{}, ErrDataExportNotFound
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding data export", zap.Error(err))
+		return dto.DataExport{}, err
+	}
+
+	result := d.dataExportDTO(export)
+	if export.Status == model.DataExportReady && export.ExpiresAt != nil && time.Now().Before(*export.ExpiresAt) {
+		result.DownloadURL, err = d.S3Service.SignS3FilesUrl(user_profile_S3_bucket, export.S3Key)
+		if err != nil {
+			zapLogger.Logger.Error("error in signing data export url", zap.Error(err))
+			return dto.DataExport{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// ProcessPendingExports builds the requested exports and returns how many were built. An export
+// that fails is retried on the next run, up to dataExportMaxAttempts times.
+func (d *DataExportService) ProcessPendingExports() (int, error) {
+	exports, err := d.DataExportDao.FindPending(dataExportBatchSize)
+	if err != nil {
+		zapLogger.Logger.Error("error in finding pending data exports", zap.Error(err))
+		return 0, err
+	}
+
+	built := 0
+	for _, export := range exports {
+		claimed, err := d.DataExportDao.Claim(export.ID)
+		if err != nil {
+			zapLogger.Logger.Error("error in claiming data export", zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		key, err := d.buildArchive(export)
+		if err != nil {
+			zapLogger.Logger.Error("error in building data export", zap.Uint("export_id", export.ID), zap.Error(err))
+			retry := export.Attempts+1 < dataExportMaxAttempts
+			if err := d.DataExportDao.Fail(export.ID, err.Error(), retry); err != nil {
+				zapLogger.Logger.Error("error in recording failed data export", zap.Error(err))
+			}
+			continue
+		}
+
+		err = d.DataExportDao.Complete(export.ID, key, time.Now().Add(dataExportRetention))
+		if err != nil {
+			return built, err
+		}
+
+		zapLogger.Logger.Info("data export built", zap.Uint("export_id", export.ID), zap.Int("user_id", export.UserId))
+		built++
+	}
+
+	return built, nil
+}
+
+// DeleteExpiredExports deletes the archives that can no longer be downloaded and returns how many were deleted.
+func (d *DataExportService) DeleteExpiredExports() (int, error) {
+	exports, err := d.DataExportDao.FindExpired(time.Now(), dataExportBatchSize*10)
+	if err != nil {
+		zapLogger.Logger.Error("error in finding expired data exports", zap.Error(err))
+		return 0, err
+	}
+
+	deleted := 0
+	for _, export := range exports {
+		if err := d.S3Service.DeleteFile(user_profile_S3_bucket, export.S3Key); err != nil {
+			continue
+		}
+		if err := d.DataExportDao.MarkExpired(export.ID); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// buildArchive writes the data of the user as JSON files, with their media files, into a zip and
+// uploads it next to the media of the user, so that erasing the account erases the export too.
+func (d *DataExportService) buildArchive(export model.DataExport) (string, error) {
+	file, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	if err := d.writeData(archive, export.UserId); err != nil {
+		return "", err
+	}
+	if err := d.writeMedia(archive, export.UserId); err != nil {
+		return "", err
+	}
+	if err := archive.Close(); err != nil {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, 0); err != nil {
+		return "", err
+	}
+
+	fileName := fmt.Sprintf("%d.zip", export.ID)
+	key := DataExportKey(export.UserId, export.ID)
+	_, err = d.S3Service.UploadFileToS3(user_profile_S3_bucket, key, file, fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (d *DataExportService) writeData(archive *zip.Writer, userID int) error {
+	data, err := d.DataExportDao.FindUserData(userID)
+	if err != nil {
+		return err
+	}
+
+	for table, rows := range data {
+		if err := writeJSON(archive, "data/"+table+".json", rows); err != nil {
+			return err
+		}
+	}
+
+	profileIDs, err := d.DataExportDao.FindProfileIds(userID)
+	if err != nil {
+		return err
+	}
+
+	stories := make([]interface{}, 0)
+	for _, profileID := range profileIDs {
+		userStories, err := d.StoriesIndex.GetUserStoriesByProfileID(profileID)
+		if err != nil {
+			return err
+		}
+		for _, story := range userStories {
+			stories = append(stories, story)
+		}
+	}
+
+	return writeJSON(archive, "data/user_stories.json", stories)
+}
+
+// writeMedia copies every file the user uploaded into the archive, leaving out earlier exports.
+func (d *DataExportService) writeMedia(archive *zip.Writer, userID int) error {
+	folder := strconv.Itoa(userID) + "/"
+
+	for _, bucket := range []string{user_profile_S3_bucket, user_stories_S3_bucket} {
+		keys, err := d.S3Service.ListFiles(bucket, folder)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if strings.HasPrefix(key, folder+dataExportFolder+"/") || strings.HasSuffix(key, "/") {
+				continue
+			}
+
+			w, err := archive.Create("media/" + bucket + "/" + strings.TrimPrefix(key, folder))
+			if err != nil {
+				return err
+			}
+			if err := d.S3Service.DownloadFile(bucket, key, w); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(archive *zip.Writer, name string, value interface{}) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// DataExportKey is where the archive of an export is stored in the profile bucket.
+func DataExportKey(userID int, exportID uint) string {
+	return fmt.Sprintf("%d/%s/%d.zip", userID, dataExportFolder, exportID)
+}
+
+func (d *DataExportService) dataExportDTO(export model.DataExport) dto.DataExport {
+	return dto.DataExport{
+		ID:          export.ID,
+		Status:      export.Status,
+		RequestedAt: export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+}
+
+// StartDataExportWorker builds requested exports and deletes expired ones in the background, for as long as the process runs.
+func StartDataExportWorker() {
+	go func() {
+		ticker := time.NewTicker(dataExportInterval)
+		defer ticker.Stop()
+
+		for {
+			dataExportService := NewDataExportService()
+			built, err := dataExportService.ProcessPendingExports()
+			if err != nil {
+				zapLogger.Logger.Error("error in processing data exports", zap.Error(err))
+			} else if built > 0 {
+				zapLogger.Logger.Info("processed data exports", zap.Int("built", built))
+			}
+
+			deleted, err := dataExportService.DeleteExpiredExports()
+			if err != nil {
+				zapLogger.Logger.Error("error in deleting expired data exports", zap.Error(err))
+			} else if deleted > 0 {
+				zapLogger.Logger.Info("deleted expired data exports", zap.Int("deleted", deleted))
+			}
+
+			<-ticker.C
+		}
+	}()
+}
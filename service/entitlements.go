@@ -0,0 +1,28 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/SuperMatch/model"
+)
+
+// Entitlement is a feature that only some plans include.
+type Entitlement string
+
+const (
+	EntitlementRewind         Entitlement = "rewind"
+	EntitlementMatchExtension Entitlement = "match_extension"
+)
+
+var ErrEntitlementRequired = errors.New("your plan does not include this feature")
+
+// premiumEntitlements are the features included with premium.
+var premiumEntitlements = map[Entitlement]bool{
+	EntitlementRewind:         true,
+	EntitlementMatchExtension: true,
+}
+
+// HasEntitlement reports whether the plan of the profile includes the feature.
+func HasEntitlement(profile model.UserProfile, entitlement Entitlement) bool {
+	return profile.IsPremium && premiumEntitlements[entitlement]
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
+)
+
+// Whose turn it is to send the first message of a match, as seen by the user listing their matches.
+const (
+	TurnYou    = "you"
+	TurnThem   = "them"
+	TurnAnyone = "anyone"
+)
+
+var (
+	ErrNotFirstMove = errors.New("the other user of the match makes the first move")
+	ErrNoFirstMove  = errors.New("you do not have the first move of this match")
+)
+
+// FirstMoveBy returns the user of the match who has to send the first message in the mode, or nil
+// when either can. In the women mode the woman moves first in a match with a man, unless either
+// of them is not heterosexual. A profile without an orientation counts as heterosexual.
+func FirstMoveBy(mode string, profile, matchProfile model.UserProfile) *int {
+	if mode != config.FirstMoveWomen || !isHeterosexual(profile) || !isHeterosexual(matchProfile) {
+		return nil
+	}
+
+	switch {
+	case isGender(profile, elasticsearchPkg.FEMALE) && isGender(matchProfile, elasticsearchPkg.MALE):
+		return &profile.UserId
+	case isGender(profile, elasticsearchPkg.MALE) && isGender(matchProfile, elasticsearchPkg.FEMALE):
+		return &matchProfile.UserId
+	default:
+		return nil
+	}
+}
+
+func isGender(profile model.UserProfile, gender elasticsearchPkg.Gender) bool {
+	return profile.Gender != nil && *profile.Gender == gender
+}
+
+func isHeterosexual(profile model.UserProfile) bool {
+	return profile.SexualOrientation == nil || *profile.SexualOrientation == elasticsearchPkg.Heterosexual
+}
+
+// turnOf tells the user whose turn it is to send the first message of a match in the mode, or "" once
+// the conversation has started. The first move kept with a match only counts while the mode is on.
+func turnOf(mode string, userID int, firstMoveBy *int, firstMessageAt *time.Time) string {
+	switch {
+	case firstMessageAt != nil:
+		return ""
+	case mode != config.FirstMoveWomen || firstMoveBy == nil:
+		return TurnAnyone
+	case *firstMoveBy == userID:
+		return TurnYou
+	default:
+		return TurnThem
+	}
+}
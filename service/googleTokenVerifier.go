@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/jwks"
+	"github.com/golang-jwt/jwt"
+)
+
+const googleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+// clockSkew is the leeway allowed when checking the expiry of third party tokens.
+const clockSkew = time.Minute
+
+var googleIssuers = map[string]bool{
+	"accounts.google.com":         true,
+	"https://accounts.google.com": true,
+}
+
+// GoogleKeySource is the cached Google JWKS shared by every GoogleTokenVerifier.
+var GoogleKeySource jwks.KeySource = jwks.NewRemoteKeySource(googleJWKSURL)
+
+var (
+	ErrInvalidGoogleToken     = errors.New("invalid google id token")
+	ErrGoogleEmailNotVerified = errors.New("google account email is not verified")
+)
+
+// GoogleTokenVerifier checks Google ID tokens locally against Google's published signing keys.
+type GoogleTokenVerifier struct {
+	keySource jwks.KeySource
+	clientID  string
+}
+
+func NewGoogleTokenVerifier(keySource jwks.KeySource, clientID string) *GoogleTokenVerifier {
+	return &GoogleTokenVerifier{
+		keySource: keySource,
+		clientID:  clientID,
+	}
+}
+
+func (g *GoogleTokenVerifier) Verify(idToken string) (*dto.TokenInfo, error) {
+	tokenInfo := &dto.TokenInfo{}
+
+	_, err := jwt.ParseWithClaims(idToken, tokenInfo, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, ErrInvalidGoogleToken
+		}
+		kid, _ := token.Header["kid"].(string)
+		return g.keySource.Key(kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !googleIssuers[tokenInfo.Iss] {
+		return nil, errors.New("google id token has an invalid issuer")
+	}
+
+	if g.clientID == "" || tokenInfo.Aud != g.clientID {
+		return nil, errors.New("google id token was issued for another client")
+	}
+
+	if time.Now().Add(-clockSkew).Unix() > tokenInfo.Exp {
+		return nil, errors.New("google id token is expired")
+	}
+
+	if !tokenInfo.EmailVerified {
+		return nil, ErrGoogleEmailNotVerified
+	}
+
+	return tokenInfo, nil
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/pkg/db/dao"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// recentAuthenticationWindow is how long after signing in a session may change the sign in methods of the account.
+const recentAuthenticationWindow = 10 * time.Minute
+
+var (
+	ErrIdentityNotFound         = errors.New("sign in method not found")
+	ErrIdentityAlreadyLinked    = errors.New("sign in method is already linked to another account")
+	ErrProviderAlreadyLinked    = errors.New("a sign in method of this kind is already linked, unlink it first")
+	ErrLastIdentity             = errors.New("the last sign in method of an account cannot be unlinked")
+	ErrReauthenticationRequired = errors.New("sign in again to change the sign in methods of your account")
+)
+
+// AccountExistsError is returned when a sign in method that is not linked to any account matches the
+// email or phone number of an existing account. The owner has to sign in with one of Providers and
+// link the new method, so nobody can take over an account by signing up with its details.
+type AccountExistsError struct {
+	Providers []string
+}
+
+func (e *AccountExistsError) Error() string {
+	if len(e.Providers) == 0 {
+		return "an account already exists with these details"
+	}
+	return fmt.Sprintf("an account already exists with these details. please sign in using %s and link this sign in method", strings.Join(e.Providers, " or "))
+}
+
+type IdentityServiceInterface interface {
+	SignIn(identity model.UserIdentity, newUser model.User, device dto.SessionDevice) (model.User, dto.AuthToken, error)
+	SignUp(identity model.UserIdentity, newUser model.User, device dto.SessionDevice) (model.User, dto.AuthToken, error)
+	FindIdentity(provider string, subject string) (model.UserIdentity, error)
+	GetIdentities(userID int) ([]dto.Identity, error)
+	LinkIdentity(userID int, authenticatedAt time.Time, identity model.UserIdentity) (dto.Identity, error)
+	LinkPassword(userID int, authenticatedAt time.Time, email string, password string) (dto.Identity, error)
+	UnlinkIdentity(userID int, authenticatedAt time.Time, identityID uint) error
+}
+
+type IdentityService struct {
+	UserIdentityDao dao.UserIdentityRepository
+	UserDao         dao.UserRepository
+	LoginService    LoginInterface
+}
+
+func NewIdentityService() *IdentityService {
+	return &IdentityService{
+		UserIdentityDao: &dao.UserIdentityDao{Connection: *db.GlobalOrm},
+		UserDao:         &dao.UserDao{Connection: *db.GlobalOrm},
+		LoginService:    NewLoginService(),
+	}
+}
+
+// SignIn signs in the account the identity is linked to, or signs up newUser with it when the
+// identity is not linked yet.
+func (i *IdentityService) SignIn(identity model.UserIdentity, newUser model.User, device dto.SessionDevice) (model.User, dto.AuthToken, error) {
+	linked, err := i.findIdentity(identity)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return i.SignUp(identity, newUser, device)
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding user identity", zap.Error(err))
+		return model.User{}, dto.AuthToken{}, err
+	}
+
+	user, err := i.UserDao.FindById(linked.UserId)
+	if err != nil {
+		zapLogger.Logger.Error("error in finding user of identity", zap.Error(err))
+		return model.User{}, dto.AuthToken{}, err
+	}
+
+	authToken, err := i.LoginService.UserSignIN(user, device)
+	if err != nil {
+		return model.User{}, dto.AuthToken{}, err
+	}
+
+	return user, authToken, nil
+}
+
+// SignUp creates newUser with the identity as its first sign in method and signs it in.
+func (i *IdentityService) SignUp(identity model.UserIdentity, newUser model.User, device dto.SessionDevice) (model.User, dto.AuthToken, error) {
+	if identity.Subject == "" {
+		return model.User{}, dto.AuthToken{}, errors.New("sign in method has no subject")
+	}
+
+	_, err := i.findIdentity(identity)
+	if err == nil {
+		return model.User{}, dto.AuthToken{}, ErrIdentityAlreadyLinked
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.User{}, dto.AuthToken{}, err
+	}
+
+	if err := i.checkAccountExists(newUser); err != nil {
+		return model.User{}, dto.AuthToken{}, err
+	}
+
+	user, created, err := i.UserIdentityDao.InsertWithUser(newUser, identity)
+	if err != nil {
+		return model.User{}, dto.AuthToken{}, err
+	}
+	if !created {
+		// signed up with the same identity concurrently
+		return model.User{}, dto.AuthToken{}, ErrIdentityAlreadyLinked
+	}
+
+	authToken, err := i.LoginService.UserSignIN(user, device)
+	if err != nil {
+		return model.User{}, dto.AuthToken{}, err
+	}
+
+	return user, authToken, nil
+}
+
+func (i *IdentityService) FindIdentity(provider string, subject string) (model.UserIdentity, error) {
+	return i.UserIdentityDao.FindByProviderSubject(provider, subject)
+}
+
+// findIdentity looks the identity up by provider and subject. Google and Apple identities of accounts
+// created before identities were stored use the email as subject; they get the real subject on
+// their first sign in.
+func (i *IdentityService) findIdentity(identity model.UserIdentity) (model.UserIdentity, error) {
+	linked, err := i.UserIdentityDao.FindByProviderSubject(identity.Provider, identity.Subject)
+	if !errors.Is(err, gorm.ErrRecordNotFound) || identity.Email == "" {
+		return linked, err
+	}
+	if identity.Provider != model.IdentityProviderGoogle && identity.Provider != model.IdentityProviderApple {
+		return linked, err
+	}
+
+	linked, err = i.UserIdentityDao.FindByProviderSubject(identity.Provider, identity.Email)
+	if err != nil {
+		return linked, err
+	}
+
+	err = i.UserIdentityDao.UpdateSubject(linked.ID, identity.Subject)
+	if err != nil {
+		return linked, err
+	}
+
+	linked.Subject = identity.Subject
+	return linked, nil
+}
+
+// checkAccountExists returns an AccountExistsError when an account already uses the email or phone number of newUser.
+func (i *IdentityService) checkAccountExists(newUser model.User) error {
+	var user model.User
+	err := gorm.ErrRecordNotFound
+	if newUser.Email != "" {
+		user, err = i.UserDao.FindByEmail(newUser.Email)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) && newUser.Mobile != "" {
+		user, err = i.UserDao.FindByMobile(newUser.Mobile)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding existing account", zap.Error(err))
+		return err
+	}
+
+	identities, err := i.UserIdentityDao.FindByUserId(int(user.ID))
+	if err != nil {
+		return err
+	}
+
+	providers := make([]string, 0, len(identities))
+	for _, identity := range identities {
+		providers = append(providers, identity.Provider)
+	}
+
+	return &AccountExistsError{Providers: providers}
+}
+
+func (i *IdentityService) GetIdentities(userID int) ([]dto.Identity, error) {
+	identities, err := i.UserIdentityDao.FindByUserId(userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in getting user identities from DB", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]dto.Identity, 0, len(identities))
+	for _, identity := range identities {
+		result = append(result, identityDTO(identity))
+	}
+
+	return result, nil
+}
+
+func identityDTO(identity model.UserIdentity) dto.Identity {
+	result := dto.Identity{
+		ID:       identity.ID,
+		Provider: identity.Provider,
+		Email:    identity.Email,
+		LinkedAt: identity.CreatedAt,
+	}
+	if identity.Provider == model.IdentityProviderPhone {
+		result.PhoneNumber = identity.Subject
+	}
+	return result
+}
+
+func checkRecentAuthentication(authenticatedAt time.Time) error {
+	if authenticatedAt.IsZero() || time.Since(authenticatedAt) > recentAuthenticationWindow {
+		return ErrReauthenticationRequired
+	}
+	return nil
+}
+
+// LinkIdentity adds a verified sign in method to the account. The session has to be signed in recently.
+func (i *IdentityService) LinkIdentity(userID int, authenticatedAt time.Time, identity model.UserIdentity) (dto.Identity, error) {
+	if err := checkRecentAuthentication(authenticatedAt); err != nil {
+		return dto.Identity{}, err
+	}
+
+	return i.linkIdentity(userID, identity)
+}
+
+func (i *IdentityService) linkIdentity(userID int, identity model.UserIdentity) (dto.Identity, error) {
+	if identity.Subject == "" {
+		return dto.Identity{}, errors.New("sign in method has no subject")
+	}
+
+	linked, err := i.findIdentity(identity)
+	if err == nil {
+		if linked.UserId != userID {
+			return dto.Identity{}, ErrIdentityAlreadyLinked
+		}
+		return identityDTO(linked), nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.Identity{}, err
+	}
+
+	identities, err := i.UserIdentityDao.FindByUserId(userID)
+	if err != nil {
+		return dto.Identity{}, err
+	}
+
+	for _, existing := range identities {
+		if existing.Provider == identity.Provider {
+			return dto.Identity{}, ErrProviderAlreadyLinked
+		}
+	}
+
+	identity.UserId = userID
+	identity, err = i.UserIdentityDao.Insert(identity)
+	if err != nil {
+		return dto.Identity{}, err
+	}
+
+	return identityDTO(identity), nil
+}
+
+// LinkPassword adds an email and password sign in method to the account.
+func (i *IdentityService) LinkPassword(userID int, authenticatedAt time.Time, email string, password string) (dto.Identity, error) {
+	if err := checkRecentAuthentication(authenticatedAt); err != nil {
+		return dto.Identity{}, err
+	}
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return dto.Identity{}, err
+	}
+
+	if err := ValidatePasswordStrength(password, email); err != nil {
+		return dto.Identity{}, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		zapLogger.Logger.Error("error in hashing password", zap.Error(err))
+		return dto.Identity{}, err
+	}
+
+	identity, err := i.linkIdentity(userID, model.UserIdentity{
+		Provider: model.IdentityProviderPassword,
+		Subject:  email,
+		Email:    email,
+	})
+	if err != nil {
+		return dto.Identity{}, err
+	}
+
+	err = i.UserDao.UpdatePassword(userID, string(hashedPassword))
+	if err != nil {
+		return dto.Identity{}, err
+	}
+
+	return identity, nil
+}
+
+// UnlinkIdentity removes a sign in method from the account. The last one cannot be removed, so
+// nobody locks themselves out. The session has to be signed in recently.
+func (i *IdentityService) UnlinkIdentity(userID int, authenticatedAt time.Time, identityID uint) error {
+	if err := checkRecentAuthentication(authenticatedAt); err != nil {
+		return err
+	}
+
+	identities, err := i.UserIdentityDao.FindByUserId(userID)
+	if err != nil {
+		return err
+	}
+
+	var identity *model.UserIdentity
+	for index := range identities {
+		if identities[index].ID == identityID {
+			identity = &identities[index]
+		}
+	}
+	if identity == nil {
+		return ErrIdentityNotFound
+	}
+	if len(identities) == 1 {
+		return ErrLastIdentity
+	}
+
+	deleted, err := i.UserIdentityDao.Delete(userID, identityID)
+	if err != nil {
+		zapLogger.Logger.Error("error in deleting user identity", zap.Error(err))
+		return err
+	}
+	if !deleted {
+		return ErrIdentityNotFound
+	}
+
+	if identity.Provider == model.IdentityProviderPassword {
+		err = i.UserDao.UpdatePassword(userID, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,101 +1,165 @@
 package service
 
 import (
-	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"github.com/SuperMatch/config"
 	"github.com/SuperMatch/model/dto"
 	"github.com/SuperMatch/utilities"
 	"github.com/SuperMatch/zapLogger"
-	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
-	"github.com/twilio/twilio-go"
 	"go.uber.org/zap"
-	"google.golang.org/api/googleapi"
-	"google.golang.org/api/option"
-	"log"
-	"math/rand"
-	"strings"
+	"math/big"
 	"time"
 
-	twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
 	"gorm.io/gorm"
 
 	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/pkg/db"
 	"github.com/SuperMatch/pkg/db/dao"
-	"google.golang.org/api/oauth2/v2"
+	"github.com/SuperMatch/pkg/mail"
+	"github.com/SuperMatch/pkg/redis"
+	"github.com/SuperMatch/pkg/sms"
+	"golang.org/x/crypto/bcrypt"
 )
 
+//go:generate mockgen -package mocks -destination mocks/login_service_mock.go github.com/SuperMatch/service LoginInterface
 type LoginInterface interface {
 	GoogleLogin(idToken string) (*dto.TokenInfo, error)
-	UserSignIN(user model.User) (string, time.Time, error)
-	UserSignUP(userModel model.User) (int, string, time.Time, error)
-	GenerateAndSaveToken(user model.User) (string, time.Time, error)
-	SendOTPService(phoneNumber string) (string, error)
-	VerifyOTPService(phoneNumber string, OTP string) (bool, error)
+	AppleLogin(request dto.AppleLoginRequest) (*dto.AppleTokenInfo, error)
+	UserSignIN(user model.User, device dto.SessionDevice) (dto.AuthToken, error)
+	VerifyMFA(mfaToken string, code string, device dto.SessionDevice) (dto.AuthToken, error)
+	UserSignUP(userModel model.User, device dto.SessionDevice) (int, dto.AuthToken, error)
+	GenerateAndSaveToken(user model.User, device dto.SessionDevice) (dto.AuthToken, error)
+	RefreshToken(refreshToken string, device dto.SessionDevice) (dto.AuthToken, error)
+	SendOTPService(phoneNumber string, clientIP string) (string, error)
+	VerifyOTPService(phoneNumber string, OTP string, clientIP string) (bool, error)
 	GetUserDetailsFromGoogle(googleResponse *dto.TokenInfo) model.User
-	CheckUserExistOrNot(userMobile string) (string, time.Time, error)
+	GetUserDetailsFromApple(appleResponse *dto.AppleTokenInfo, request dto.AppleLoginRequest) model.User
+	CheckUserExistOrNot(userMobile string, device dto.SessionDevice) (dto.AuthToken, error)
+	PasswordSignUp(registration dto.UserRegistrationDTO, device dto.SessionDevice) (int, dto.AuthToken, error)
+	PasswordSignIn(email string, password string, device dto.SessionDevice) (model.User, dto.AuthToken, error)
+	ForgotPassword(email string) error
+	ResetPassword(resetToken string, password string) error
 	SendVerificationEmail(emailId string) error
 	VerifyEmailService(verificationCode string) error
-	DeleteUser(userID int, email string) error
 }
 
-type LoginService struct{}
+const (
+	otpTTL               = 10 * time.Minute
+	otpResendInterval    = 30 * time.Second
+	otpSendWindow        = time.Hour
+	otpSendLimitPerPhone = 5
+	otpSendLimitPerIP    = 20
+	otpVerifyWindow      = time.Hour
+	otpVerifyLimitPerIP  = 50
+	otpMaxFailedAttempts = 5
+	otpLockoutDuration   = 30 * time.Minute
+	otpResendKey         = "otp:resend:"
+	otpSendPhoneKey      = "otp:send:phone:"
+	otpSendIPKey         = "otp:send:ip:"
+	otpVerifyIPKey       = "otp:verify:ip:"
+	otpFailedAttemptsKey = "otp:failed:"
+)
 
-func NewLoginService() *LoginService {
-	return &LoginService{}
+// RateLimitError is returned when a caller has to wait before trying again.
+type RateLimitError struct {
+	RetryAfter time.Duration
+	// Locked is set when the wait is a lockout caused by too many failed attempts.
+	Locked bool
 }
 
-func twilioClient() *twilio.RestClient {
-	client := twilio.NewRestClientWithParams(twilio.ClientParams{
-		Username: config.ConfigValue.TwilioConfig.AccountSID,
-		Password: config.ConfigValue.TwilioConfig.AuthToken,
-	})
+func (e *RateLimitError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed attempts, try again in %s", e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("too many requests, try again in %s", e.RetryAfter.Round(time.Second))
+}
 
-	return client
+// LoginSession describes the login a token pair belongs to. Refreshed tokens keep the session of
+// the tokens they replace.
+type LoginSession struct {
+	// FamilyID identifies the login across refreshes.
+	FamilyID string
+	// AuthenticatedAt is when the user last proved who they are.
+	AuthenticatedAt time.Time
+	// MFAVerified is set when the login passed two factor authentication.
+	MFAVerified bool
+}
+
+type LoginService struct {
+	RateLimiter      redis.RateLimiterInterface
+	SMSSender        sms.SMSSender
+	GoogleVerifier   *GoogleTokenVerifier
+	AppleVerifier    *AppleTokenVerifier
+	Mailer           mail.Mailer
+	OTPDao           dao.UserVerificationOTPRepository
+	UserDao          dao.UserRepository
+	UserIdentityDao  dao.UserIdentityRepository
+	PasswordResetDao dao.PasswordResetRepository
+}
+
+func NewLoginService() *LoginService {
+	return &LoginService{
+		RateLimiter:      redis.RateLimiterConstructor(),
+		SMSSender:        sms.Sender,
+		GoogleVerifier:   NewGoogleTokenVerifier(GoogleKeySource, config.ConfigValue.GoogleConfig.ClientID),
+		AppleVerifier:    NewAppleTokenVerifier(AppleKeySource, config.ConfigValue.AppleConfig.ClientIDs),
+		Mailer:           mail.Sender,
+		OTPDao:           &dao.UserVerificationOTPDao{Connection: *db.GlobalOrm},
+		UserDao:          &dao.UserDao{Connection: *db.GlobalOrm},
+		UserIdentityDao:  &dao.UserIdentityDao{Connection: *db.GlobalOrm},
+		PasswordResetDao: &dao.PasswordResetDao{Connection: *db.GlobalOrm},
+	}
 }
 
 func (l *LoginService) GoogleLogin(idToken string) (*dto.TokenInfo, error) {
-	authService, err := oauth2.NewService(context.Background(), option.WithoutAuthentication())
+	tokenInfo, err := l.GoogleVerifier.Verify(idToken)
 	if err != nil {
-		zapLogger.Logger.Error("oauth2.NewService failed", zap.Error(err))
+		zapLogger.Logger.Debug("google id token verification failed", zap.Error(err))
 		return nil, err
 	}
 
-	tokenInfoCall := authService.Tokeninfo()
-	tokenInfoCall.IdToken(idToken)
-	ctx, cancelFunc := context.WithTimeout(context.Background(), 1*time.Minute)
-	defer cancelFunc()
-	tokenInfoCall.Context(ctx)
-	_, err = tokenInfoCall.Do()
+	return tokenInfo, nil
+}
+
+func (l *LoginService) AppleLogin(request dto.AppleLoginRequest) (*dto.AppleTokenInfo, error) {
+	tokenInfo, err := l.AppleVerifier.Verify(request.IdentityToken, request.Nonce)
 	if err != nil {
-		var e *googleapi.Error
-		_ = errors.As(err, &e)
-		return nil, e
+		zapLogger.Logger.Debug("apple identity token verification failed", zap.Error(err))
+		return nil, err
 	}
 
-	token, _, err := new(jwt.Parser).ParseUnverified(idToken, &dto.TokenInfo{})
-	if tokenInfo, ok := token.Claims.(*dto.TokenInfo); ok {
-		return tokenInfo, nil
-	} else {
-		zapLogger.Logger.Error("parse token.payload failed", zap.Error(err))
-		return nil, err
+	if tokenInfo.Email == "" {
+		return nil, errors.New("apple identity token has no email, the user has to share an email address")
 	}
+
+	return tokenInfo, nil
 }
 
-func (l *LoginService) UserSignIN(user model.User) (string, time.Time, error) {
-	token, expiresAt, err := l.GenerateAndSaveToken(user)
+// UserSignIN issues tokens for a user who passed the first sign in step. Users with two factor
+// authentication get a MFARequiredError instead and are signed in by VerifyMFA.
+func (l *LoginService) UserSignIN(user model.User, device dto.SessionDevice) (dto.AuthToken, error) {
+	twoFactorService := NewTwoFactorService()
+	enrolled, err := twoFactorService.IsEnrolled(int(user.ID))
+	if err != nil {
+		return dto.AuthToken{}, err
+	}
+	if enrolled {
+		return dto.AuthToken{}, twoFactorService.StartChallenge(int(user.ID))
+	}
+
+	authToken, err := l.GenerateAndSaveToken(user, device)
 	if err != nil {
 		zapLogger.Logger.Error("error in generating and saving auth token for user", zap.Error(err))
-		return token, expiresAt, err
+		return authToken, err
 	}
 
-	return token, expiresAt, nil
+	return authToken, nil
 }
 
-func (l *LoginService) UserSignUP(userModel model.User) (int, string, time.Time, error) {
+func (l *LoginService) UserSignUP(userModel model.User, device dto.SessionDevice) (int, dto.AuthToken, error) {
 	userDao := &dao.UserDao{
 		Connection: *db.GlobalOrm,
 	}
@@ -103,132 +167,310 @@ func (l *LoginService) UserSignUP(userModel model.User) (int, string, time.Time,
 	user, err := userDao.Insert(userModel)
 	if err != nil {
 		zapLogger.Logger.Error("error in creating user", zap.Error(err))
-		return 0, "", time.Time{}, err
+		return 0, dto.AuthToken{}, err
 	}
 
-	token, expiresAt, err := l.GenerateAndSaveToken(user)
+	authToken, err := l.GenerateAndSaveToken(user, device)
 	if err != nil {
 		zapLogger.Logger.Error("error in generating and saving auth token for user", zap.Error(err))
-		return 0, token, expiresAt, err
+		return 0, authToken, err
+	}
+
+	return int(user.ID), authToken, nil
+}
+
+// GenerateAndSaveToken issues an access and refresh token pair for a new login.
+func (l *LoginService) GenerateAndSaveToken(user model.User, device dto.SessionDevice) (dto.AuthToken, error) {
+	return l.generateAndSaveToken(user, LoginSession{FamilyID: uuid.NewString(), AuthenticatedAt: time.Now()}, device)
+}
+
+// VerifyMFA completes a sign in that needed two factor authentication.
+func (l *LoginService) VerifyMFA(mfaToken string, code string, device dto.SessionDevice) (dto.AuthToken, error) {
+	userID, err := NewTwoFactorService().CompleteChallenge(mfaToken, code)
+	if err != nil {
+		return dto.AuthToken{}, err
+	}
+
+	userDao := &dao.UserDao{
+		Connection: *db.GlobalOrm,
+	}
+
+	user, err := userDao.FindById(userID)
+	if err != nil {
+		zapLogger.Logger.Error("no active user found for mfa token", zap.Error(err))
+		return dto.AuthToken{}, ErrInvalidMFAToken
+	}
+
+	session := LoginSession{
+		FamilyID:        uuid.NewString(),
+		AuthenticatedAt: time.Now(),
+		MFAVerified:     true,
+	}
+
+	return l.generateAndSaveToken(user, session, device)
+}
+
+// RefreshToken consumes a refresh token and issues the next token pair of the same login.
+func (l *LoginService) RefreshToken(refreshToken string, device dto.SessionDevice) (dto.AuthToken, error) {
+	JWTService := NewJWTService()
+	userToken, err := JWTService.ConsumeRefreshToken(refreshToken)
+	if err != nil {
+		return dto.AuthToken{}, err
+	}
+
+	userDao := &dao.UserDao{
+		Connection: *db.GlobalOrm,
+	}
+
+	user, err := userDao.FindById(userToken.UserId)
+	if err != nil {
+		zapLogger.Logger.Error("no active user found for refresh token", zap.Error(err))
+		return dto.AuthToken{}, ErrInvalidRefreshToken
+	}
+
+	// a refreshed session keeps the time its user last proved who they are
+	session := LoginSession{
+		FamilyID:    userToken.FamilyID,
+		MFAVerified: userToken.MFAVerified,
+	}
+	if userToken.AuthenticatedAt != nil {
+		session.AuthenticatedAt = *userToken.AuthenticatedAt
 	}
 
-	return int(user.ID), token, expiresAt, nil
+	return l.generateAndSaveToken(user, session, device)
 }
 
-func (l *LoginService) GenerateAndSaveToken(user model.User) (string, time.Time, error) {
-	JWTService := JWTImpl{}
-	token, expiresAt, err := JWTService.GenerateToken(int(user.ID), user.Email, true)
+func (l *LoginService) generateAndSaveToken(user model.User, session LoginSession, device dto.SessionDevice) (dto.AuthToken, error) {
+	JWTService := NewJWTService()
+	token, expiresAt, err := JWTService.GenerateToken(int(user.ID), user.Email, user.Role)
 	if err != nil {
 		zapLogger.Logger.Error("error in generating auth token", zap.Error(err))
-		return token, expiresAt, err
+		return dto.AuthToken{}, err
+	}
+
+	refreshToken, refreshExpiresAt, err := JWTService.GenerateRefreshToken()
+	if err != nil {
+		zapLogger.Logger.Error("error in generating refresh token", zap.Error(err))
+		return dto.AuthToken{}, err
 	}
 
-	err = JWTService.SaveToken(user, token, expiresAt)
+	authToken := dto.AuthToken{
+		Token:            token,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}
+
+	err = JWTService.SaveToken(user, authToken, session, device)
 	if err != nil {
 		zapLogger.Logger.Error("error in saving auth token", zap.Error(err))
-		return token, expiresAt, err
+		return dto.AuthToken{}, err
+	}
+
+	return authToken, nil
+}
+
+// generateOTP returns a uniformly random six digit code.
+func generateOTP() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(900000))
+	if err != nil {
+		return "", err
 	}
 
-	return token, expiresAt, nil
+	otp := utilities.ConvertIntToString(int(n.Int64()) + 100000)
+
+	return otp, nil
 }
 
-func generateOTP() string {
-	rand.New(rand.NewSource(time.Now().UnixNano()))
+// otpRateLimit allows limit events against key in each window.
+type otpRateLimit struct {
+	key    string
+	limit  int64
+	window time.Duration
+}
 
-	r := rand.Intn(900000) + 100000
-	otp := utilities.ConvertIntToString(r)
+// checkOTPRateLimits counts an event against every limit and fails once one of them is exceeded. A
+// rejected event is taken back from every counter, so it does not use up the other limits.
+func (l *LoginService) checkOTPRateLimits(limits ...otpRateLimit) error {
+	for i, limit := range limits {
+		count, ttl, err := l.RateLimiter.Increment(limit.key, limit.window)
+		if err != nil {
+			l.releaseOTPRateLimits(limits[:i])
+			return err
+		}
 
-	return otp
+		if count > limit.limit {
+			l.releaseOTPRateLimits(limits[:i+1])
+			return &RateLimitError{RetryAfter: ttl}
+		}
+	}
+	return nil
 }
 
-func (l *LoginService) SendOTPService(phoneNumber string) (string, error) {
-	client := twilioClient()
-	otp := generateOTP()
-	body := fmt.Sprintf("Your Pluto verification code is %s. This code will expire in 10 minutes.", otp)
-	newPhoneNumber := fmt.Sprintf("+91" + phoneNumber)
-	params := &twilioApi.CreateMessageParams{}
-	params.SetTo(newPhoneNumber)
-	params.SetFrom(config.ConfigValue.TwilioConfig.PhoneNumber)
-	params.SetBody(body)
+func (l *LoginService) releaseOTPRateLimits(limits []otpRateLimit) {
+	for _, limit := range limits {
+		if err := l.RateLimiter.Decrement(limit.key); err != nil {
+			zapLogger.Logger.Warn("error in releasing otp rate limit", zap.String("key", limit.key), zap.Error(err))
+		}
+	}
+}
 
-	resp, err := client.Api.CreateMessage(params)
+// checkOTPLockout fails while the phone number is locked out after too many failed verifications.
+func (l *LoginService) checkOTPLockout(phoneNumber string) error {
+	failures, ttl, err := l.RateLimiter.Count(otpFailedAttemptsKey + phoneNumber)
+	if err != nil {
+		return err
+	}
+
+	if failures >= otpMaxFailedAttempts {
+		return &RateLimitError{RetryAfter: ttl, Locked: true}
+	}
+	return nil
+}
+
+func (l *LoginService) SendOTPService(phoneNumber string, clientIP string) (string, error) {
+	if err := l.checkOTPLockout(phoneNumber); err != nil {
+		return "", err
+	}
+	err := l.checkOTPRateLimits(
+		otpRateLimit{key: otpResendKey + phoneNumber, limit: 1, window: otpResendInterval},
+		otpRateLimit{key: otpSendPhoneKey + phoneNumber, limit: otpSendLimitPerPhone, window: otpSendWindow},
+		otpRateLimit{key: otpSendIPKey + clientIP, limit: otpSendLimitPerIP, window: otpSendWindow},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	otp, err := generateOTP()
+	if err != nil {
+		zapLogger.Logger.Error("error in generating otp", zap.Error(err))
+		return "", err
+	}
+	body, err := sms.RenderOTPMessage(sms.OTPMessage{
+		AppName:          config.ConfigValue.SMSConfig.AppName,
+		Code:             otp,
+		ExpiresInMinutes: int(otpTTL.Minutes()),
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in rendering otp message", zap.Error(err))
+		return "", err
+	}
+
+	messageID, err := l.SMSSender.Send(phoneNumber, body)
 	if err != nil {
 		zapLogger.Logger.Error("failed to send verification SMS", zap.Error(err))
 		return "", err
 	}
 
-	otpDao := &dao.UserVerificationOTPDao{
-		Connection: *db.GlobalOrm,
+	hashedOTP, err := bcrypt.GenerateFromPassword([]byte(otp), bcrypt.DefaultCost)
+	if err != nil {
+		zapLogger.Logger.Error("error in hashing otp", zap.Error(err))
+		return "", err
+	}
+
+	// only the latest code sent to a phone number can be used
+	err = l.OTPDao.ConsumeAllByPhone(phoneNumber)
+	if err != nil {
+		zapLogger.Logger.Error("error in consuming previous otps of phone number", zap.Error(err))
+		return "", err
 	}
+
 	userOtp := model.UserVerificationOTP{
 		PhoneNumber: phoneNumber,
-		OTP:         otp,
-		ExpiresAt:   time.Now().Add(10 * time.Minute),
+		OTP:         string(hashedOTP),
+		ExpiresAt:   time.Now().Add(otpTTL),
 	}
-	err = otpDao.Insert(userOtp)
+	err = l.OTPDao.Insert(userOtp)
 	if err != nil {
 		zapLogger.Logger.Error("error in inserting user otp to DB.", zap.Error(err))
 		return "", err
 	}
 
-	return *resp.Sid, nil
+	return messageID, nil
 }
 
-func (l *LoginService) VerifyOTPService(phoneNumber string, OTP string) (bool, error) {
-	otpDao := &dao.UserVerificationOTPDao{
-		Connection: *db.GlobalOrm,
+func (l *LoginService) VerifyOTPService(phoneNumber string, OTP string, clientIP string) (bool, error) {
+	if err := l.checkOTPLockout(phoneNumber); err != nil {
+		return false, err
+	}
+	if err := l.checkOTPRateLimits(otpRateLimit{key: otpVerifyIPKey + clientIP, limit: otpVerifyLimitPerIP, window: otpVerifyWindow}); err != nil {
+		return false, err
 	}
 
-	userOtpDetails, err := otpDao.FindByPhoneAndOTP(phoneNumber, OTP)
+	userOtpDetails, err := l.OTPDao.FindLatestByPhone(phoneNumber)
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		zapLogger.Logger.Error("no record found, wrong otp")
-		return false, errors.New("no record found, wrong otp")
+		return false, l.recordFailedOTPAttempt(phoneNumber, errors.New("no record found, wrong otp"))
 	} else if err != nil {
 		zapLogger.Logger.Error(fmt.Sprintf("error in finding otp details for phone number: %s", phoneNumber))
 		return false, err
 	}
 
-	if time.Now().Before(userOtpDetails.ExpiresAt) {
-		return true, nil
-	} else {
+	if time.Now().After(userOtpDetails.ExpiresAt) {
 		return false, errors.New("verification OTP expired")
 	}
 
+	if bcrypt.CompareHashAndPassword([]byte(userOtpDetails.OTP), []byte(OTP)) != nil {
+		return false, l.recordFailedOTPAttempt(phoneNumber, errors.New("no record found, wrong otp"))
+	}
+
+	consumed, err := l.OTPDao.Consume(userOtpDetails.ID)
+	if err != nil {
+		zapLogger.Logger.Error("error in consuming otp", zap.Error(err))
+		return false, err
+	}
+	if !consumed {
+		return false, errors.New("verification OTP already used")
+	}
+
+	err = l.RateLimiter.Reset(otpFailedAttemptsKey + phoneNumber)
+	if err != nil {
+		zapLogger.Logger.Error("error in resetting failed otp attempts", zap.Error(err))
+	}
+
+	return true, nil
 }
 
-func (l *LoginService) CheckUserExistOrNot(userMobile string) (string, time.Time, error) {
-	userDao := &dao.UserDao{
-		Connection: *db.GlobalOrm,
+// recordFailedOTPAttempt counts a failed verification. The attempt that reaches the limit locks
+// the phone number out and burns its current code.
+func (l *LoginService) recordFailedOTPAttempt(phoneNumber string, verifyErr error) error {
+	failures, ttl, err := l.RateLimiter.Increment(otpFailedAttemptsKey+phoneNumber, otpLockoutDuration)
+	if err != nil {
+		return err
 	}
 
-	user, err := userDao.FindByMobile(userMobile)
-	if err == nil {
-		if strings.ToLower(user.SignUpType) != "otp" {
-			return "", time.Time{}, fmt.Errorf("otp signin didn't exists for this account. please sign in using %s method", user.SignUpType)
+	if failures >= otpMaxFailedAttempts {
+		zapLogger.Logger.Warn("too many failed otp attempts, locking phone number", zap.String("phone_number", phoneNumber))
+		if err := l.OTPDao.ConsumeAllByPhone(phoneNumber); err != nil {
+			zapLogger.Logger.Error("error in consuming otps of locked phone number", zap.Error(err))
 		}
+		return &RateLimitError{RetryAfter: ttl, Locked: true}
+	}
 
-		token, expiresAt, err := l.UserSignIN(user)
-		if err != nil {
-			log.Fatalln("error in user signin")
-			return token, expiresAt, err
-		} else {
-			return token, expiresAt, nil
-		}
-	} else if errors.Is(err, gorm.ErrRecordNotFound) {
-		userDetails := model.User{
-			Mobile:     userMobile,
-			SignUpType: "otp",
-			IsActive:   true,
-		}
-		_, token, expiresAt, err := l.UserSignUP(userDetails)
-		if err != nil {
-			zapLogger.Logger.Error("error in registering user: ", zap.Error(err))
-		}
-		return token, expiresAt, nil
+	return verifyErr
+}
+
+// CheckUserExistOrNot signs in the account the phone number is linked to, or signs up a new one.
+func (l *LoginService) CheckUserExistOrNot(userMobile string, device dto.SessionDevice) (dto.AuthToken, error) {
+	identity := model.UserIdentity{
+		Provider: model.IdentityProviderPhone,
+		Subject:  userMobile,
 	}
 
-	return "", time.Time{}, err
+	userDetails := model.User{
+		Mobile:     userMobile,
+		SignUpType: "otp",
+		IsActive:   true,
+	}
+
+	_, authToken, err := NewIdentityService().SignIn(identity, userDetails, device)
+	if err != nil {
+		zapLogger.Logger.Error("error in signing in with phone number", zap.Error(err))
+		return dto.AuthToken{}, err
+	}
+
+	return authToken, nil
 }
 
 func (l *LoginService) GetUserDetailsFromGoogle(tokenInfo *dto.TokenInfo) model.User {
@@ -246,6 +488,24 @@ func (l *LoginService) GetUserDetailsFromGoogle(tokenInfo *dto.TokenInfo) model.
 	return userDetails
 }
 
+func (l *LoginService) GetUserDetailsFromApple(tokenInfo *dto.AppleTokenInfo, request dto.AppleLoginRequest) model.User {
+	userDetails := model.User{
+		FirstName: request.FirstName,
+		LastName:  request.LastName,
+		Mobile:    "",
+		Code:      "",
+		Email:     tokenInfo.Email,
+		Password:  "",
+		IsActive:  true,
+		// apple only hands out addresses it has verified, relay addresses included
+		EmailVerified: bool(tokenInfo.EmailVerified) || bool(tokenInfo.IsPrivateEmail),
+		PrivateEmail:  bool(tokenInfo.IsPrivateEmail),
+		SignUpType:    "apple",
+	}
+
+	return userDetails
+}
+
 func (l *LoginService) SendVerificationEmail(emailId string) error {
 	userDao := &dao.UserDao{
 		Connection: *db.GlobalOrm,
@@ -261,15 +521,16 @@ func (l *LoginService) SendVerificationEmail(emailId string) error {
 	}
 
 	verificationCode := uuid.New().String()
-	name := user.FirstName
 	verifyUrl := fmt.Sprintf("%s/user/verify/email?verification_code=%s", config.ConfigValue.BaseURL.URL, verificationCode)
-	htmlBody := fmt.Sprintf(`<h1>Email Verification</h1>
-    <p>Hello %s,</p>
-    <p>Thank you for registering with Pluto. To complete your registration, please verify your email address by clicking the button below:</p>
-    <a href="%s" style="display: inline-block; padding: 12px 24px; background-color: #4CAF50; color: white; text-decoration: none; border-radius: 4px;">Verify Email</a>
-    <p>Best regards,</p>
-    <p>Pluto</p>`, name, verifyUrl)
-	title := "Verify you Pluto account"
+	message, err := mail.Render(mail.VerifyEmailTemplate, emailId, mail.VerifyEmailData{
+		AppName:   config.ConfigValue.MailConfig.AppName,
+		Name:      user.FirstName,
+		VerifyURL: verifyUrl,
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in rendering verification email", zap.Error(err))
+		return err
+	}
 
 	verificationDetails := model.EmailVerification{
 		UserId:           int(user.ID),
@@ -287,12 +548,9 @@ func (l *LoginService) SendVerificationEmail(emailId string) error {
 		return err
 	}
 
-	s3Service := S3Service{}
-	input := s3Service.SendEmailInput(emailId, htmlBody, title)
-
-	err = s3Service.SendEmail(input)
+	_, err = l.Mailer.Send(message)
 	if err != nil {
-		zapLogger.Logger.Error(fmt.Sprintf("Error sending email to account: %s", emailId))
+		zapLogger.Logger.Error(fmt.Sprintf("Error sending email to account: %s", emailId), zap.Error(err))
 		return err
 	}
 
@@ -349,17 +607,3 @@ func (l *LoginService) VerifyEmailService(verificationCode string) error {
 
 	return nil
 }
-
-func (l *LoginService) DeleteUser(userID int, email string) error {
-	userDao := &dao.UserDao{
-		Connection: *db.GlobalOrm,
-	}
-
-	err := userDao.DeleteUser(userID, email)
-	if err != nil {
-		zapLogger.Logger.Error("error deleting user")
-		return err
-	}
-
-	return nil
-}
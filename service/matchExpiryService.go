@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/pkg/db/dao"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// matchExpiryInterval is how often the worker reminds users of expiring matches and removes expired ones.
+	matchExpiryInterval  = 5 * time.Minute
+	matchExpiryBatchSize = 100
+)
+
+var (
+	ErrMatchNotFound        = errors.New("match not found")
+	ErrMatchExpired         = errors.New("the match has expired")
+	ErrMatchNotExpiring     = errors.New("the match does not expire once a message is sent")
+	ErrMatchAlreadyExtended = errors.New("the match has already been extended")
+)
+
+type MatchExpiryServiceInterface interface {
+	ExtendMatch(userID, matchID int) (time.Time, error)
+	SendExpiryReminders() (int, error)
+	ExpireMatches() (int, error)
+	BackfillExpiry() (int64, error)
+}
+
+type MatchExpiryService struct {
+	UserMatchDao        dao.UserMatchDao
+	UserProfileDao      dao.UserProfileRepository
+	NotificationService NotificationServiceInterface
+	MatchExpiryConfig   config.MatchExpiryConfig
+}
+
+func NewMatchExpiryService() *MatchExpiryService {
+	return &MatchExpiryService{
+		UserMatchDao:        dao.NewUserMatchDaoImpl(),
+		UserProfileDao:      dao.NewUserProfileRepository(),
+		NotificationService: NewNotificationService(),
+		MatchExpiryConfig:   config.ConfigValue.MatchExpiryConfig,
+	}
+}
+
+// ExtendMatch gives a match nobody has messaged yet more time, once per match, and returns the new
+// expiry. Extending is a premium feature.
+func (m *MatchExpiryService) ExtendMatch(userID, matchID int) (time.Time, error) {
+	profile, err := m.UserProfileDao.FindByUserId(context.Background(), userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in getting user profile for match extension", zap.Error(err))
+		return time.Time{}, err
+	}
+	if !HasEntitlement(profile, EntitlementMatchExtension) {
+		return time.Time{}, ErrEntitlementRequired
+	}
+
+	match, err := m.UserMatchDao.FindByUserIdMatchId(context.Background(), userID, matchID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return time.Time{}, ErrMatchNotFound
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding match to extend", zap.Error(err))
+		return time.Time{}, err
+	}
+
+	now := time.Now()
+	if match.ExpiresAt == nil {
+		return time.Time{}, ErrMatchNotExpiring
+	}
+	if !match.ExpiresAt.After(now) {
+		return time.Time{}, ErrMatchExpired
+	}
+	if match.ExtendedBy != nil {
+		return time.Time{}, ErrMatchAlreadyExtended
+	}
+
+	extended, err := m.UserMatchDao.Extend(context.Background(), userID, matchID, m.MatchExpiryConfig.Extension, now)
+	if err != nil {
+		zapLogger.Logger.Error("error in extending match", zap.Error(err))
+		return time.Time{}, err
+	}
+	if !extended {
+		// extended by the other user, messaged or expired meanwhile
+		return time.Time{}, ErrMatchAlreadyExtended
+	}
+
+	return match.ExpiresAt.Add(m.MatchExpiryConfig.Extension), nil
+}
+
+// SendExpiryReminders notifies users of their matches that expire soon without a first message, and
+// returns how many were reminded. Each side of a match is reminded once, or once again after an
+// extension.
+func (m *MatchExpiryService) SendExpiryReminders() (int, error) {
+	now := time.Now()
+	matches, err := m.UserMatchDao.FindExpiringUnnotified(context.Background(), now, now.Add(m.MatchExpiryConfig.ReminderBefore), matchExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	reminded := 0
+	for _, match := range matches {
+		due, err := m.UserMatchDao.MarkExpiryNotified(context.Background(), match.ID)
+		if err != nil {
+			return reminded, err
+		}
+		if !due {
+			// reminded by another worker
+			continue
+		}
+
+		err = m.NotificationService.SendNotificationToUser(match.UserID, model.NotificationData{
+			Title: "Your match is about to expire",
+			Body:  "Say hello before it's gone. Matches without a message expire after " + m.MatchExpiryConfig.Window.String() + ".",
+		})
+		if err != nil {
+			zapLogger.Logger.Warn("error in sending match expiry reminder", zap.Int("user_id", match.UserID), zap.Error(err))
+			continue
+		}
+		reminded++
+	}
+
+	return reminded, nil
+}
+
+// ExpireMatches removes matches nobody messaged before they expired, and returns how many match rows
+// were removed. The rows are soft deleted.
+func (m *MatchExpiryService) ExpireMatches() (int, error) {
+	expired := 0
+	for {
+		matches, err := m.UserMatchDao.ExpireDue(context.Background(), time.Now(), matchExpiryBatchSize)
+		if err != nil {
+			return expired, err
+		}
+		expired += len(matches)
+
+		if len(matches) < matchExpiryBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// BackfillExpiry gives the matches made before matches expired, that nobody has messaged yet, the
+// expiry window from now, so nobody loses one without a reminder.
+func (m *MatchExpiryService) BackfillExpiry() (int64, error) {
+	return m.UserMatchDao.BackfillExpiry(context.Background(), time.Now().Add(m.MatchExpiryConfig.Window))
+}
+
+// StartMatchExpiryWorker reminds users of expiring matches and removes expired ones in the background, for as long as the process runs.
+func StartMatchExpiryWorker() {
+	go func() {
+		backfilled, err := NewMatchExpiryService().BackfillExpiry()
+		if err != nil {
+			zapLogger.Logger.Error("error in backfilling match expiry", zap.Error(err))
+		} else if backfilled > 0 {
+			zapLogger.Logger.Info("backfilled match expiry", zap.Int64("matches", backfilled))
+		}
+
+		ticker := time.NewTicker(matchExpiryInterval)
+		defer ticker.Stop()
+
+		for {
+			matchExpiryService := NewMatchExpiryService()
+			reminded, err := matchExpiryService.SendExpiryReminders()
+			if err != nil {
+				zapLogger.Logger.Error("error in sending match expiry reminders", zap.Error(err))
+			} else if reminded > 0 {
+				zapLogger.Logger.Info("sent match expiry reminders", zap.Int("reminded", reminded))
+			}
+
+			expired, err := matchExpiryService.ExpireMatches()
+			if err != nil {
+				zapLogger.Logger.Error("error in expiring matches", zap.Error(err))
+			} else if expired > 0 {
+				zapLogger.Logger.Info("expired matches", zap.Int("expired", expired))
+			}
+
+			<-ticker.C
+		}
+	}()
+}
@@ -2,11 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/SuperMatch/zapLogger"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/SuperMatch/config"
 	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/model/dto"
 	"github.com/SuperMatch/pkg/db/dao"
@@ -16,18 +22,29 @@ import (
 
 const TIME_FORMAT = "2006-01-02T15:04:05"
 
+// swipeUndoWindow is how long after a swipe it can still be undone.
+const swipeUndoWindow = 5 * time.Minute
+
+var (
+	ErrNoSwipeToUndo    = errors.New("no swipe to undo")
+	ErrSwipeUndoExpired = errors.New("the last swipe is too old to undo")
+	ErrSwipeMatched     = errors.New("the last swipe made a match and cannot be undone")
+)
+
 type SwipeServiceInterface interface {
-	Swipe(userActionDTO dto.UserLikeDTO) (bool, error)
+	Swipe(userActionDTO dto.UserLikeDTO) (dto.SwipeResult, error)
 	Like(userId int, likedUserId int) error
 	Dislike(userId int, dislikedUserId int) error
 	checkForExistingResponse(userId, likeeID int) (bool, error)
-	addToUserMatchList(userActionDTO dto.UserLikeDTO) error
+	addToUserMatchList(userActionDTO dto.UserLikeDTO, matchType int) (bool, error)
 	GetUserMatchListFromCache(userID int) ([]int, error)
 	GetUserMatchListFromDB(userID int) ([]dto.UserMatchDTO, error)
 	RemoveMatch(userActionDTO dto.UserLikeDTO) error
 	GetUserLikes(userID int) ([]model.UserLikers, error)
 	PutLiker(likerID, likeeID int) error
 	RemoveLikerFromLikeeList(likerID, likeeID int) error
+	UndoLastSwipe(userID int) (dto.UserLikeDTO, error)
+	BackfillSwipeLedger() (int64, error)
 }
 
 type SwipeService struct {
@@ -35,6 +52,14 @@ type SwipeService struct {
 	UserMatchDao        dao.UserMatchDao
 	UserMediaRepository dao.UserMediaRepository
 	S3Service           S3ServiceInterface
+	SearchProfileDao    dao.UserSearchProfileRepository
+	SwipeDao            dao.UserSwipeRepository
+	UserProfileDao      dao.UserProfileRepository
+	RateLimiter         redis.RateLimiterInterface
+	SwipeConfig         config.SwipeConfig
+	BlockDao            dao.UserBlockRepository
+	MatchExpiryConfig   config.MatchExpiryConfig
+	FirstMoveConfig     config.FirstMoveConfig
 }
 
 func NewSwipeService() *SwipeService {
@@ -43,87 +68,189 @@ func NewSwipeService() *SwipeService {
 		UserMatchDao:        dao.NewUserMatchDaoImpl(),
 		UserMediaRepository: dao.NewUserMediaRepository(),
 		S3Service:           NewS3Service(),
+		SearchProfileDao:    dao.NewUserSearchProfile(),
+		SwipeDao:            dao.NewUserSwipeRepository(),
+		UserProfileDao:      dao.NewUserProfileRepository(),
+		RateLimiter:         redis.RateLimiterConstructor(),
+		SwipeConfig:         config.ConfigValue.SwipeConfig,
+		BlockDao:            dao.NewUserBlockRepository(),
+		MatchExpiryConfig:   config.ConfigValue.MatchExpiryConfig,
+		FirstMoveConfig:     config.ConfigValue.FirstMoveConfig,
 	}
 }
 
-func (s *SwipeService) Swipe(userActionDTO dto.UserLikeDTO) (bool, error) {
+// Swipe records a like, super like or dislike and reports whether it made a match. The swipe is written to
+// the ledger first, then the cache records it and checks for the reciprocal like in one atomic
+// step, so concurrent likes produce exactly one match. Likes and super likes count against the
+// daily quotas of the plan of the liker. The quota is given back when the ledger write fails, but
+// once the swipe is in the ledger it stays charged, even if making the match fails. Users who blocked
+// each other cannot swipe on each other.
+func (s *SwipeService) Swipe(userActionDTO dto.UserLikeDTO) (dto.SwipeResult, error) {
+	var swipeResult dto.SwipeResult
+
+	// a block removes the match, a swipe must not make it again
+	blocked, err := s.BlockDao.IsBlocked(userActionDTO.LikerID, userActionDTO.LikeeID)
+	if err != nil {
+		zapLogger.Logger.Error("error in checking user block:", zap.Error(err))
+		return swipeResult, err
+	}
+	if blocked {
+		return swipeResult, ErrUserBlocked
+	}
 
 	isLiked, err := s.checkAlreadyLiked(userActionDTO.LikerID, userActionDTO.LikeeID)
 	if err != nil {
 		zapLogger.Logger.Error("error in checking liker already liked the likee:", zap.Error(err))
-		return false, err
+		return swipeResult, err
 	}
-	if isLiked && userActionDTO.Type == 1 {
+	swipeType := swipeTypeOf(userActionDTO.Type)
+	if isLiked && swipeType != model.SwipeDislike {
 		zapLogger.Logger.Error("liker already liked the likee")
-		return false, fmt.Errorf("liker: %d already liked the likee: %d", userActionDTO.LikerID, userActionDTO.LikeeID)
+		return swipeResult, fmt.Errorf("liker: %d already liked the likee: %d", userActionDTO.LikerID, userActionDTO.LikeeID)
 	}
 
-	response, err := s.checkForExistingResponse(userActionDTO.LikeeID, userActionDTO.LikerID)
-	zapLogger.Logger.Info("swipe response:", zap.Any("response", response))
+	// also caches a reciprocal like that is only in the ledger, for the atomic check below
+	responseType, err := s.getSwipe(userActionDTO.LikeeID, userActionDTO.LikerID)
 	if err != nil {
 		zapLogger.Logger.Error("error in checking likee already liked the liker:", zap.Error(err))
-		return false, err
+		return swipeResult, err
 	}
+	response := responseType != nil && *responseType != model.SwipeDislike
+	zapLogger.Logger.Info("swipe response:", zap.Any("response", response))
 
-	if userActionDTO.Type == 1 {
-		//if already disiked by other user,just don't match
-		if !response {
-			zapLogger.Logger.Debug("already not liked")
-			err := s.Like(userActionDTO.LikerID, userActionDTO.LikeeID)
-			if err != nil {
-				zapLogger.Logger.Error("error in liking:", zap.Error(err))
-				return false, err
-			}
-			err = s.PutLiker(userActionDTO.LikerID, userActionDTO.LikeeID)
-			if err != nil {
-				zapLogger.Logger.Error("error in putting liker:", zap.Error(err))
-				return false, err
-			}
+	if err := s.useSwipeQuota(userActionDTO.LikerID, swipeType, &swipeResult); err != nil {
+		return swipeResult, err
+	}
 
-		} else {
-			zapLogger.Logger.Debug("already liked")
-			//match if already liked by other user & create chat room
-			err := s.addToUserMatchList(userActionDTO)
-			if err != nil {
-				zapLogger.Logger.Error("error in adding to user match list:", zap.Error(err))
-				return false, err
-			}
-			err = s.Like(userActionDTO.LikerID, userActionDTO.LikeeID)
-			if err != nil {
-				zapLogger.Logger.Error("error in liking:", zap.Error(err))
-				return false, err
-			}
+	err = s.SwipeDao.Upsert(model.UserSwipe{
+		UserId:    userActionDTO.LikerID,
+		TargetId:  userActionDTO.LikeeID,
+		SwipeType: swipeType,
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in recording swipe:", zap.Error(err))
+		s.refundSwipeQuota(userActionDTO.LikerID, swipeType)
+		return swipeResult, err
+	}
 
-			err = s.RemoveLikerFromLikeeList(userActionDTO.LikerID, userActionDTO.LikeeID)
-			if err != nil {
-				zapLogger.Logger.Error("error in removing liker from likee list:", zap.Error(err))
-				return false, err
-			}
+	result, err := s.LikeDislikeCache.RecordSwipe(utilities.ConvertIntToString(userActionDTO.LikerID), utilities.ConvertIntToString(userActionDTO.LikeeID), swipeType)
+	if err != nil {
+		// the ledger has the swipe, decide from it and let the unique match rows settle a race
+		zapLogger.Logger.Warn("error in recording swipe in cache, matching from db", zap.Error(err))
+		result = redis.SwipeResult{Matched: swipeType != model.SwipeDislike && response, LikersCached: true}
+	}
+
+	if swipeType == model.SwipeDislike {
+		return swipeResult, nil
+	}
 
-			return true, nil
+	if !result.Matched {
+		if !result.LikersCached {
+			if _, err := s.loadUserLikes(userActionDTO.LikeeID); err != nil {
+				zapLogger.Logger.Error("error in putting liker:", zap.Error(err))
+				return swipeResult, err
+			}
 		}
-	} else {
-		zapLogger.Logger.Debug("Dislike")
-		err := s.Dislike(userActionDTO.LikerID, userActionDTO.LikeeID)
-		if err != nil {
-			zapLogger.Logger.Error("error in disliking:", zap.Error(err))
-			return false, err
+		return swipeResult, nil
+	}
+
+	// a like recorded concurrently was not seen above
+	if responseType == nil {
+		if responseType, err = s.getSwipe(userActionDTO.LikeeID, userActionDTO.LikerID); err != nil {
+			zapLogger.Logger.Warn("error in getting the swipe that matched", zap.Error(err))
 		}
-		if response {
-			err = s.RemoveLikerFromLikeeList(userActionDTO.LikerID, userActionDTO.LikeeID)
-			if err != nil {
-				zapLogger.Logger.Error("error in removing liker from likee list:", zap.Error(err))
-				return false, err
-			}
+	}
+
+	matchType := model.MatchTypeLike
+	if swipeType == model.SwipeSuperLike || (responseType != nil && *responseType == model.SwipeSuperLike) {
+		matchType = model.MatchTypeSuperLike
+	}
+
+	//match if already liked by other user & create chat room
+	isMatch, err := s.addToUserMatchList(userActionDTO, matchType)
+	if err != nil {
+		zapLogger.Logger.Error("error in adding to user match list:", zap.Error(err))
+		return swipeResult, err
+	}
+
+	swipeResult.IsMatch = isMatch
+	return swipeResult, nil
+}
+
+// UndoLastSwipe reverses the most recent swipe of the user, if it is recent enough and did not
+// make a match, and returns it. A rewound like or super like no longer counts against the daily
+// quota. Rewinding is a premium feature.
+func (s *SwipeService) UndoLastSwipe(userID int) (dto.UserLikeDTO, error) {
+	profile, err := s.UserProfileDao.FindByUserId(context.Background(), userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in getting user profile for rewind", zap.Error(err))
+		return dto.UserLikeDTO{}, err
+	}
+	if !HasEntitlement(profile, EntitlementRewind) {
+		return dto.UserLikeDTO{}, ErrEntitlementRequired
+	}
+
+	swipe, err := s.SwipeDao.FindLatestByUserId(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.UserLikeDTO{}, ErrNoSwipeToUndo
+	} else if err != nil {
+		zapLogger.Logger.Error("error in getting last swipe", zap.Error(err))
+		return dto.UserLikeDTO{}, err
+	}
+	if time.Since(swipe.SwipedAt) > swipeUndoWindow {
+		return dto.UserLikeDTO{}, ErrSwipeUndoExpired
+	}
+
+	if swipe.IsLike() {
+		_, err := s.UserMatchDao.FindByUserIdMatchId(context.Background(), userID, swipe.TargetId)
+		if err == nil {
+			return dto.UserLikeDTO{}, ErrSwipeMatched
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			zapLogger.Logger.Error("error in checking match of last swipe", zap.Error(err))
+			return dto.UserLikeDTO{}, err
 		}
+	}
+
+	deleted, err := s.SwipeDao.Delete(swipe)
+	if err != nil {
+		return dto.UserLikeDTO{}, err
+	}
+	if !deleted {
+		// swiped again meanwhile
+		return dto.UserLikeDTO{}, ErrNoSwipeToUndo
+	}
+
+	err = s.LikeDislikeCache.RemoveSwipe(utilities.ConvertIntToString(userID), utilities.ConvertIntToString(swipe.TargetId))
+	if err != nil {
+		return dto.UserLikeDTO{}, err
+	}
 
+	// the like is given back, unless the quota reset since the swipe
+	location := userLocation(profile)
+	if nextDailyReset(swipe.SwipedAt.In(location)).Equal(nextDailyReset(time.Now().In(location))) {
+		s.refundSwipeQuota(userID, swipe.SwipeType)
 	}
-	return false, nil
+
+	// a like of the target that the swipe answered is pending again
+	response, err := s.checkForExistingResponse(swipe.TargetId, userID)
+	if err != nil {
+		return dto.UserLikeDTO{}, err
+	}
+	if response {
+		if _, err := s.loadUserLikes(userID); err != nil {
+			return dto.UserLikeDTO{}, err
+		}
+	}
+
+	return dto.UserLikeDTO{
+		LikerID: userID,
+		LikeeID: swipe.TargetId,
+		Type:    swipe.SwipeType,
+	}, nil
 }
 
 func (s *SwipeService) Like(userId int, likedUserId int) error {
-	key := utilities.ConvertIntToString(userId) + ":" + utilities.ConvertIntToString(likedUserId)
-	err := s.LikeDislikeCache.PutLikeDislike(key, utilities.ConvertIntToString(1))
+	err := s.recordSwipe(userId, likedUserId, model.SwipeLike)
 	if err != nil {
 		zapLogger.Logger.Error("error in putting like", zap.Error(err))
 		return err
@@ -132,8 +259,16 @@ func (s *SwipeService) Like(userId int, likedUserId int) error {
 	return nil
 }
 
+// PutLiker adds the liker to the likee's likers list. A list that is not cached is rebuilt from
+// the swipes, which already hold this like.
 func (s *SwipeService) PutLiker(likerID, likeeID int) error {
-	err := s.LikeDislikeCache.PutLiker(utilities.ConvertIntToString(likeeID), utilities.ConvertIntToString(likerID))
+	cached, err := s.LikeDislikeCache.HasUserLikes(utilities.ConvertIntToString(likeeID))
+	if err != nil || !cached {
+		_, err = s.loadUserLikes(likeeID)
+		return err
+	}
+
+	err = s.LikeDislikeCache.PutLiker(utilities.ConvertIntToString(likeeID), utilities.ConvertIntToString(likerID))
 	if err != nil {
 		zapLogger.Logger.Error("error in putting likerID into the likee's likers list", zap.Error(err))
 		return err
@@ -153,56 +288,157 @@ func (s *SwipeService) RemoveLikerFromLikeeList(likerID, likeeID int) error {
 }
 
 func (s *SwipeService) Dislike(userId int, dislikedUserId int) error {
-	key := utilities.ConvertIntToString(userId) + ":" + utilities.ConvertIntToString(dislikedUserId)
-	err := s.LikeDislikeCache.PutLikeDislike(key, utilities.ConvertIntToString(0))
-	return err
+	return s.recordSwipe(userId, dislikedUserId, model.SwipeDislike)
 }
 
-func (s *SwipeService) checkForExistingResponse(dislikerUserId, dislikedUserId int) (bool, error) {
-	key := utilities.ConvertIntToString(dislikerUserId) + ":" + utilities.ConvertIntToString(dislikedUserId)
+// recordSwipe writes the swipe to the ledger, then to the cache.
+func (s *SwipeService) recordSwipe(userID, targetID, swipeType int) error {
+	err := s.SwipeDao.Upsert(model.UserSwipe{
+		UserId:    userID,
+		TargetId:  targetID,
+		SwipeType: swipeType,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.LikeDislikeCache.PutLikeDislike(swipeKey(userID, targetID), utilities.ConvertIntToString(swipeType))
+}
+
+// BackfillSwipeLedger copies the swipes cached in redis into the ledger, for swipes made before the
+// ledger existed, and returns how many were added. Swipes the ledger already has are kept as they are.
+func (s *SwipeService) BackfillSwipeLedger() (int64, error) {
+	var added int64
+	_, err := s.LikeDislikeCache.ScanSwipes(func(cached []redis.CachedSwipe) error {
+		swipes := make([]model.UserSwipe, 0, len(cached))
+		for _, swipe := range cached {
+			switch swipe.SwipeType {
+			case model.SwipeDislike, model.SwipeLike, model.SwipeSuperLike:
+			default:
+				continue
+			}
+			swipes = append(swipes, model.UserSwipe{
+				UserId:    swipe.LikerID,
+				TargetId:  swipe.LikeeID,
+				SwipeType: swipe.SwipeType,
+				SwipedAt:  swipe.SwipedAt,
+			})
+		}
+
+		inserted, err := s.SwipeDao.InsertMissing(swipes)
+		added += inserted
+		return err
+	})
+	return added, err
+}
+
+// getSwipe returns the type of the swipe of the user on the target, or nil when there is none.
+// Redis only caches the ledger, so a miss or an unavailable cache is answered from the database.
+func (s *SwipeService) getSwipe(userID, targetID int) (*int, error) {
+	key := swipeKey(userID, targetID)
 	val, err := s.LikeDislikeCache.GetLikeDislike(key)
+	if err != nil {
+		zapLogger.Logger.Warn("error in getting swipe from cache, reading it from db", zap.Error(err))
+	} else if val != nil {
+		if swipeType, err := strconv.Atoi(*val); err == nil {
+			return &swipeType, nil
+		}
+	}
+
+	swipe, err := s.SwipeDao.FindByUserIdTargetId(userID, targetID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		zapLogger.Logger.Error("Error in getting swipe from db", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.LikeDislikeCache.PutLikeDislike(key, utilities.ConvertIntToString(swipe.SwipeType))
+	if err != nil {
+		zapLogger.Logger.Warn("error in caching swipe", zap.Error(err))
+	}
+	return &swipe.SwipeType, nil
+}
+
+// swipeTypeOf maps the type of a swipe request to the swipe type it records.
+func swipeTypeOf(requestType int) int {
+	switch requestType {
+	case model.SwipeLike, model.SwipeSuperLike:
+		return requestType
+	default:
+		return model.SwipeDislike
+	}
+}
 
+func swipeKey(userID, targetID int) string {
+	return utilities.ConvertIntToString(userID) + ":" + utilities.ConvertIntToString(targetID)
+}
+
+func (s *SwipeService) checkForExistingResponse(dislikerUserId, dislikedUserId int) (bool, error) {
+	swipeType, err := s.getSwipe(dislikerUserId, dislikedUserId)
 	if err != nil {
 		zapLogger.Logger.Error("Error in checking existing like dislike", zap.Error(err))
 		return false, err
 	}
 
-	if val == nil {
-		return false, nil
-	} else if *val == "0" {
-		return false, nil
-	} else {
-		return true, nil
-	}
+	return swipeType != nil && *swipeType != model.SwipeDislike, nil
 }
 
-func (s *SwipeService) addToUserMatchList(userActionDTO dto.UserLikeDTO) error {
+// addToUserMatchList saves both sides of the match together and reports whether the match is new.
+// The match expires unless someone messages within the expiry window.
+func (s *SwipeService) addToUserMatchList(userActionDTO dto.UserLikeDTO, matchType int) (bool, error) {
 
-	// matches := make([]model.UserMatch, 2)
 	chatId := utilities.ConvertStringToStringPointer(fmt.Sprintf("%d_%d", userActionDTO.LikerID, userActionDTO.LikeeID))
+	expiresAt := time.Now().Add(s.MatchExpiryConfig.Window)
+
+	firstMoveBy := s.firstMoveBy(userActionDTO.LikerID, userActionDTO.LikeeID)
+
 	match1 := model.UserMatch{
-		UserID:     userActionDTO.LikerID,
-		MatchID:    userActionDTO.LikeeID,
-		Match_type: 1, // right now it's 1 but depends on matchtype
-		ChatID:     chatId,
+		UserID:      userActionDTO.LikerID,
+		MatchID:     userActionDTO.LikeeID,
+		Match_type:  matchType,
+		ChatID:      chatId,
+		ExpiresAt:   &expiresAt,
+		FirstMoveBy: firstMoveBy,
 	}
 
 	match2 := model.UserMatch{
-		UserID:     userActionDTO.LikeeID,
-		MatchID:    userActionDTO.LikerID,
-		Match_type: 1,
-		ChatID:     chatId,
+		UserID:      userActionDTO.LikeeID,
+		MatchID:     userActionDTO.LikerID,
+		Match_type:  matchType,
+		ChatID:      chatId,
+		ExpiresAt:   &expiresAt,
+		FirstMoveBy: firstMoveBy,
 	}
 
-	_, _ = s.UserMatchDao.Insert(context.Background(), match1)
-	_, err := s.UserMatchDao.Insert(context.Background(), match2)
-
+	created, err := s.UserMatchDao.InsertPair(context.Background(), match1, match2)
 	if err != nil {
 		zapLogger.Logger.Error("Error in adding to user match list", zap.Error(err))
-		return err
+		return false, err
 	}
 
-	return nil
+	return created, nil
+}
+
+// firstMoveBy returns who of the two matched users has to send the first message, or nil when either
+// can. Either can when a profile cannot be read, rather than failing the match.
+func (s *SwipeService) firstMoveBy(userID, matchID int) *int {
+	if s.FirstMoveConfig.Mode != config.FirstMoveWomen {
+		return nil
+	}
+
+	profile, err := s.UserProfileDao.FindByUserId(context.Background(), userID)
+	if err != nil {
+		zapLogger.Logger.Warn("error in getting user profile for first move", zap.Error(err))
+		return nil
+	}
+	matchProfile, err := s.UserProfileDao.FindByUserId(context.Background(), matchID)
+	if err != nil {
+		zapLogger.Logger.Warn("error in getting user profile for first move", zap.Error(err))
+		return nil
+	}
+
+	return FirstMoveBy(s.FirstMoveConfig.Mode, profile, matchProfile)
 }
 
 func (s *SwipeService) GetUserMatchListFromCache(userID int) ([]int, error) {
@@ -232,7 +468,7 @@ func (s *SwipeService) GetUserMatchListFromDB(userID int) ([]dto.UserMatchDTO, e
 		return userMatches, nil
 	}
 
-	matchListMedia, err := s.UserMediaRepository.FindFirstGroupByUserID(context.Background(), matchIdS)
+	matchListMedia, err := s.UserMediaRepository.FindFirstGroupByUserID(context.Background(), userID, matchIdS)
 
 	if err != nil {
 		zapLogger.Logger.Error("Error in getting match list from db", zap.Error(err))
@@ -256,6 +492,9 @@ func (s *SwipeService) GetUserMatchListFromDB(userID int) ([]dto.UserMatchDTO, e
 			ChatId:    match.ChatId,
 			CreatedAt: match.CreatedAt,
 			DeletedAt: match.DeletedAt,
+			ExpiresAt: match.ExpiresAt,
+			Extended:  match.ExtendedBy != nil,
+			Turn:      turnOf(s.FirstMoveConfig.Mode, userID, match.FirstMoveBy, match.FirstMessageAt),
 		}
 		userMatches = append(userMatches, x)
 	}
@@ -289,30 +528,47 @@ func (s *SwipeService) RemoveMatch(userActionDTO dto.UserLikeDTO) error {
 }
 
 func (s *SwipeService) checkAlreadyLiked(likerID, likeeID int) (bool, error) {
-	key := utilities.ConvertIntToString(likerID) + ":" + utilities.ConvertIntToString(likeeID)
-	val, err := s.LikeDislikeCache.GetLikeDislike(key)
+	swipeType, err := s.getSwipe(likerID, likeeID)
 	if err != nil {
 		zapLogger.Logger.Error("Error in checking existing like dislike", zap.Error(err))
 		return false, err
 	}
 
-	if val == nil {
-		return false, nil
-	} else if *val == "0" {
-		return false, nil
-	} else {
-		return true, nil
-	}
+	return swipeType != nil && *swipeType != model.SwipeDislike, nil
 }
 
 func (s *SwipeService) GetUserLikes(userID int) ([]model.UserLikers, error) {
-	key := utilities.ConvertIntToString(userID)
-	likes, err := s.LikeDislikeCache.GetUserLikes(key)
+	likes, err := s.getUserLikes(userID)
 	if err != nil {
 		zapLogger.Logger.Error("Error in getting user likes", zap.Error(err))
 		return nil, err
 	}
 
+	// paused likers are hidden until they resume
+	pausedIDs, err := s.SearchProfileDao.FindPausedUserIds(context.Background(), likes)
+	if err != nil {
+		zapLogger.Logger.Error("Error in getting paused likers", zap.Error(err))
+		return nil, err
+	}
+	likes = utilities.ExcludeInts(likes, pausedIDs)
+
+	blockedIDs, err := s.BlockDao.FindBlockedUserIds(userID)
+	if err != nil {
+		zapLogger.Logger.Error("Error in getting blocked likers", zap.Error(err))
+		return nil, err
+	}
+	likes = utilities.ExcludeInts(likes, blockedIDs)
+
+	superLikers, err := s.SwipeDao.FindSuperLikers(userID, likes)
+	if err != nil {
+		zapLogger.Logger.Error("Error in getting super likers", zap.Error(err))
+		return nil, err
+	}
+	superLiked := make(map[int]bool, len(superLikers))
+	for _, id := range superLikers {
+		superLiked[id] = true
+	}
+
 	userMedia, err := s.UserMediaRepository.FindByUserIDs(likes)
 	if err != nil {
 		zapLogger.Logger.Error("Error in getting user media", zap.Error(err))
@@ -329,11 +585,42 @@ func (s *SwipeService) GetUserLikes(userID int) ([]model.UserLikers, error) {
 		}
 
 		userLikers = append(userLikers, model.UserLikers{
-			UserID: media.UserId,
-			Image:  signedURL,
+			UserID:    media.UserId,
+			Image:     signedURL,
+			SuperLike: superLiked[media.UserId],
 		})
 
 	}
 
+	// super likes are highlighted first
+	sort.SliceStable(userLikers, func(i, j int) bool {
+		return userLikers[i].SuperLike && !userLikers[j].SuperLike
+	})
+
 	return userLikers, err
 }
+
+// getUserLikes returns the likers list of the user, rebuilding it from the swipes when it is not cached.
+func (s *SwipeService) getUserLikes(userID int) ([]int, error) {
+	key := utilities.ConvertIntToString(userID)
+	cached, err := s.LikeDislikeCache.HasUserLikes(key)
+	if err == nil && cached {
+		return s.LikeDislikeCache.GetUserLikes(key)
+	}
+
+	return s.loadUserLikes(userID)
+}
+
+// loadUserLikes rebuilds the cached likers list of the user from the swipes.
+func (s *SwipeService) loadUserLikes(userID int) ([]int, error) {
+	likes, err := s.SwipeDao.FindPendingLikers(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.LikeDislikeCache.SetUserLikes(utilities.ConvertIntToString(userID), likes)
+	if err != nil {
+		zapLogger.Logger.Warn("error in caching user likes", zap.Error(err))
+	}
+	return likes, nil
+}
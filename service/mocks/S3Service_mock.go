@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	io "io"
 	multipart "mime/multipart"
 	reflect "reflect"
 
@@ -48,6 +49,35 @@ func (mr *MockS3ServiceInterfaceMockRecorder) DeleteFile(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockS3ServiceInterface)(nil).DeleteFile), arg0, arg1)
 }
 
+// DeleteFolder mocks base method.
+func (m *MockS3ServiceInterface) DeleteFolder(arg0, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockS3ServiceInterfaceMockRecorder) DeleteFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockS3ServiceInterface)(nil).DeleteFolder), arg0, arg1)
+}
+
+// DownloadFile mocks base method.
+func (m *MockS3ServiceInterface) DownloadFile(arg0, arg1 string, arg2 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadFile indicates an expected call of DownloadFile.
+func (mr *MockS3ServiceInterfaceMockRecorder) DownloadFile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadFile", reflect.TypeOf((*MockS3ServiceInterface)(nil).DownloadFile), arg0, arg1, arg2)
+}
+
 // GetFilesInFolder mocks base method.
 func (m *MockS3ServiceInterface) GetFilesInFolder(arg0, arg1 string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +93,21 @@ func (mr *MockS3ServiceInterfaceMockRecorder) GetFilesInFolder(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilesInFolder", reflect.TypeOf((*MockS3ServiceInterface)(nil).GetFilesInFolder), arg0, arg1)
 }
 
+// ListFiles mocks base method.
+func (m *MockS3ServiceInterface) ListFiles(arg0, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiles indicates an expected call of ListFiles.
+func (mr *MockS3ServiceInterfaceMockRecorder) ListFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockS3ServiceInterface)(nil).ListFiles), arg0, arg1)
+}
+
 // SignS3FilesUrl mocks base method.
 func (m *MockS3ServiceInterface) SignS3FilesUrl(arg0, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
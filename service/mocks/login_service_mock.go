@@ -5,9 +5,7 @@
 package mocks
 
 import (
-	http "net/http"
 	reflect "reflect"
-	time "time"
 
 	model "github.com/SuperMatch/model"
 	dto "github.com/SuperMatch/model/dto"
@@ -37,40 +35,81 @@ func (m *MockLoginInterface) EXPECT() *MockLoginInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AppleLogin mocks base method.
+func (m *MockLoginInterface) AppleLogin(arg0 dto.AppleLoginRequest) (*dto.AppleTokenInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppleLogin", arg0)
+	ret0, _ := ret[0].(*dto.AppleTokenInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppleLogin indicates an expected call of AppleLogin.
+func (mr *MockLoginInterfaceMockRecorder) AppleLogin(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppleLogin", reflect.TypeOf((*MockLoginInterface)(nil).AppleLogin), arg0)
+}
+
 // CheckUserExistOrNot mocks base method.
-func (m *MockLoginInterface) CheckUserExistOrNot(arg0 string) (string, time.Time, error) {
+func (m *MockLoginInterface) CheckUserExistOrNot(arg0 string, arg1 dto.SessionDevice) (dto.AuthToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckUserExistOrNot", arg0)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(time.Time)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "CheckUserExistOrNot", arg0, arg1)
+	ret0, _ := ret[0].(dto.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CheckUserExistOrNot indicates an expected call of CheckUserExistOrNot.
-func (mr *MockLoginInterfaceMockRecorder) CheckUserExistOrNot(arg0 interface{}) *gomock.Call {
+func (mr *MockLoginInterfaceMockRecorder) CheckUserExistOrNot(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExistOrNot", reflect.TypeOf((*MockLoginInterface)(nil).CheckUserExistOrNot), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExistOrNot", reflect.TypeOf((*MockLoginInterface)(nil).CheckUserExistOrNot), arg0, arg1)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockLoginInterface) ForgotPassword(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockLoginInterfaceMockRecorder) ForgotPassword(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockLoginInterface)(nil).ForgotPassword), arg0)
 }
 
 // GenerateAndSaveToken mocks base method.
-func (m *MockLoginInterface) GenerateAndSaveToken(arg0 model.User) (string, time.Time, error) {
+func (m *MockLoginInterface) GenerateAndSaveToken(arg0 model.User, arg1 dto.SessionDevice) (dto.AuthToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateAndSaveToken", arg0)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(time.Time)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GenerateAndSaveToken", arg0, arg1)
+	ret0, _ := ret[0].(dto.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GenerateAndSaveToken indicates an expected call of GenerateAndSaveToken.
-func (mr *MockLoginInterfaceMockRecorder) GenerateAndSaveToken(arg0 interface{}) *gomock.Call {
+func (mr *MockLoginInterfaceMockRecorder) GenerateAndSaveToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAndSaveToken", reflect.TypeOf((*MockLoginInterface)(nil).GenerateAndSaveToken), arg0, arg1)
+}
+
+// GetUserDetailsFromApple mocks base method.
+func (m *MockLoginInterface) GetUserDetailsFromApple(arg0 *dto.AppleTokenInfo, arg1 dto.AppleLoginRequest) model.User {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDetailsFromApple", arg0, arg1)
+	ret0, _ := ret[0].(model.User)
+	return ret0
+}
+
+// GetUserDetailsFromApple indicates an expected call of GetUserDetailsFromApple.
+func (mr *MockLoginInterfaceMockRecorder) GetUserDetailsFromApple(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAndSaveToken", reflect.TypeOf((*MockLoginInterface)(nil).GenerateAndSaveToken), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDetailsFromApple", reflect.TypeOf((*MockLoginInterface)(nil).GetUserDetailsFromApple), arg0, arg1)
 }
 
 // GetUserDetailsFromGoogle mocks base method.
-func (m *MockLoginInterface) GetUserDetailsFromGoogle(arg0 dto.GoogleLoginResponse) model.User {
+func (m *MockLoginInterface) GetUserDetailsFromGoogle(arg0 *dto.TokenInfo) model.User {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserDetailsFromGoogle", arg0)
 	ret0, _ := ret[0].(model.User)
@@ -83,93 +122,182 @@ func (mr *MockLoginInterfaceMockRecorder) GetUserDetailsFromGoogle(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDetailsFromGoogle", reflect.TypeOf((*MockLoginInterface)(nil).GetUserDetailsFromGoogle), arg0)
 }
 
-// GoogleCallback mocks base method.
-func (m *MockLoginInterface) GoogleCallback(arg0, arg1 string) (*http.Response, error) {
+// GoogleLogin mocks base method.
+func (m *MockLoginInterface) GoogleLogin(arg0 string) (*dto.TokenInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GoogleLogin", arg0, arg1)
-	ret0, _ := ret[0].(*http.Response)
+	ret := m.ctrl.Call(m, "GoogleLogin", arg0)
+	ret0, _ := ret[0].(*dto.TokenInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GoogleCallback indicates an expected call of GoogleCallback.
-func (mr *MockLoginInterfaceMockRecorder) GoogleCallback(arg0, arg1 interface{}) *gomock.Call {
+// GoogleLogin indicates an expected call of GoogleLogin.
+func (mr *MockLoginInterfaceMockRecorder) GoogleLogin(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoogleLogin", reflect.TypeOf((*MockLoginInterface)(nil).GoogleCallback), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoogleLogin", reflect.TypeOf((*MockLoginInterface)(nil).GoogleLogin), arg0)
 }
 
-// GoogleLogin mocks base method.
-func (m *MockLoginInterface) GoogleLogin() string {
+// PasswordSignIn mocks base method.
+func (m *MockLoginInterface) PasswordSignIn(arg0, arg1 string, arg2 dto.SessionDevice) (model.User, dto.AuthToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GoogleLogin")
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "PasswordSignIn", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(dto.AuthToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PasswordSignIn indicates an expected call of PasswordSignIn.
+func (mr *MockLoginInterfaceMockRecorder) PasswordSignIn(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordSignIn", reflect.TypeOf((*MockLoginInterface)(nil).PasswordSignIn), arg0, arg1, arg2)
+}
+
+// PasswordSignUp mocks base method.
+func (m *MockLoginInterface) PasswordSignUp(arg0 dto.UserRegistrationDTO, arg1 dto.SessionDevice) (int, dto.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordSignUp", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(dto.AuthToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PasswordSignUp indicates an expected call of PasswordSignUp.
+func (mr *MockLoginInterfaceMockRecorder) PasswordSignUp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordSignUp", reflect.TypeOf((*MockLoginInterface)(nil).PasswordSignUp), arg0, arg1)
+}
+
+// RefreshToken mocks base method.
+func (m *MockLoginInterface) RefreshToken(arg0 string, arg1 dto.SessionDevice) (dto.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(dto.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockLoginInterfaceMockRecorder) RefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockLoginInterface)(nil).RefreshToken), arg0, arg1)
+}
+
+// ResetPassword mocks base method.
+func (m *MockLoginInterface) ResetPassword(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GoogleLogin indicates an expected call of GoogleLogin.
-func (mr *MockLoginInterfaceMockRecorder) GoogleLogin() *gomock.Call {
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockLoginInterfaceMockRecorder) ResetPassword(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoogleLogin", reflect.TypeOf((*MockLoginInterface)(nil).GoogleLogin))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockLoginInterface)(nil).ResetPassword), arg0, arg1)
 }
 
 // SendOTPService mocks base method.
-func (m *MockLoginInterface) SendOTPService(arg0 string) (string, error) {
+func (m *MockLoginInterface) SendOTPService(arg0, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendOTPService", arg0)
+	ret := m.ctrl.Call(m, "SendOTPService", arg0, arg1)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SendOTPService indicates an expected call of SendOTPService.
-func (mr *MockLoginInterfaceMockRecorder) SendOTPService(arg0 interface{}) *gomock.Call {
+func (mr *MockLoginInterfaceMockRecorder) SendOTPService(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOTPService", reflect.TypeOf((*MockLoginInterface)(nil).SendOTPService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOTPService", reflect.TypeOf((*MockLoginInterface)(nil).SendOTPService), arg0, arg1)
+}
+
+// SendVerificationEmail mocks base method.
+func (m *MockLoginInterface) SendVerificationEmail(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerificationEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerificationEmail indicates an expected call of SendVerificationEmail.
+func (mr *MockLoginInterfaceMockRecorder) SendVerificationEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerificationEmail", reflect.TypeOf((*MockLoginInterface)(nil).SendVerificationEmail), arg0)
 }
 
 // UserSignIN mocks base method.
-func (m *MockLoginInterface) UserSignIN(arg0 model.User) (string, time.Time, error) {
+func (m *MockLoginInterface) UserSignIN(arg0 model.User, arg1 dto.SessionDevice) (dto.AuthToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserSignIN", arg0)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(time.Time)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "UserSignIN", arg0, arg1)
+	ret0, _ := ret[0].(dto.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UserSignIN indicates an expected call of UserSignIN.
-func (mr *MockLoginInterfaceMockRecorder) UserSignIN(arg0 interface{}) *gomock.Call {
+func (mr *MockLoginInterfaceMockRecorder) UserSignIN(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignIN", reflect.TypeOf((*MockLoginInterface)(nil).UserSignIN), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignIN", reflect.TypeOf((*MockLoginInterface)(nil).UserSignIN), arg0, arg1)
 }
 
 // UserSignUP mocks base method.
-func (m *MockLoginInterface) UserSignUP(arg0 model.User) (string, time.Time, error) {
+func (m *MockLoginInterface) UserSignUP(arg0 model.User, arg1 dto.SessionDevice) (int, dto.AuthToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserSignUP", arg0)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(time.Time)
+	ret := m.ctrl.Call(m, "UserSignUP", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(dto.AuthToken)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // UserSignUP indicates an expected call of UserSignUP.
-func (mr *MockLoginInterfaceMockRecorder) UserSignUP(arg0 interface{}) *gomock.Call {
+func (mr *MockLoginInterfaceMockRecorder) UserSignUP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignUP", reflect.TypeOf((*MockLoginInterface)(nil).UserSignUP), arg0, arg1)
+}
+
+// VerifyEmailService mocks base method.
+func (m *MockLoginInterface) VerifyEmailService(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmailService", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmailService indicates an expected call of VerifyEmailService.
+func (mr *MockLoginInterfaceMockRecorder) VerifyEmailService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailService", reflect.TypeOf((*MockLoginInterface)(nil).VerifyEmailService), arg0)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockLoginInterface) VerifyMFA(arg0, arg1 string, arg2 dto.SessionDevice) (dto.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockLoginInterfaceMockRecorder) VerifyMFA(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSignUP", reflect.TypeOf((*MockLoginInterface)(nil).UserSignUP), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockLoginInterface)(nil).VerifyMFA), arg0, arg1, arg2)
 }
 
 // VerifyOTPService mocks base method.
-func (m *MockLoginInterface) VerifyOTPService(arg0, arg1 string) (bool, error) {
+func (m *MockLoginInterface) VerifyOTPService(arg0, arg1, arg2 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifyOTPService", arg0, arg1)
+	ret := m.ctrl.Call(m, "VerifyOTPService", arg0, arg1, arg2)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // VerifyOTPService indicates an expected call of VerifyOTPService.
-func (mr *MockLoginInterfaceMockRecorder) VerifyOTPService(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockLoginInterfaceMockRecorder) VerifyOTPService(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyOTPService", reflect.TypeOf((*MockLoginInterface)(nil).VerifyOTPService), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyOTPService", reflect.TypeOf((*MockLoginInterface)(nil).VerifyOTPService), arg0, arg1, arg2)
 }
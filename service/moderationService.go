@@ -0,0 +1,463 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/pkg/db/dao"
+	pkg "github.com/SuperMatch/pkg/elasticSeach"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	reportPageSize = 50
+	// reportContextMessages is how many messages on each side of a reported message are shown to moderators.
+	reportContextMessages = 10
+)
+
+var (
+	ErrInvalidReport            = errors.New("invalid report")
+	ErrReportedContentNotFound  = errors.New("reported content not found")
+	ErrCannotReportSelf         = errors.New("users cannot report their own content")
+	ErrReportNotFound           = errors.New("report not found")
+	ErrReportResolved           = errors.New("report is already resolved")
+	ErrInvalidModerationAction  = errors.New("action cannot be taken on the reported content")
+	ErrInvalidReportQueueStatus = errors.New("invalid report status")
+)
+
+type ModerationServiceInterface interface {
+	ReportContent(reporterID int, report dto.CreateReport) (dto.Report, error)
+	GetReports(status string, page int) ([]dto.Report, error)
+	GetReportReview(reportID uint) (dto.ReportReview, error)
+	ResolveReport(moderatorID int, reportID uint, resolution dto.ResolveReport) (dto.Report, error)
+}
+
+type ModerationService struct {
+	ReportDao            dao.UserReportRepository
+	UserProfileDao       dao.UserProfileRepository
+	UserSearchProfileDao dao.UserSearchProfileRepository
+	UserMedia            dao.UserMediaRepository
+	UserNudgesDao        dao.UserNudgesDao
+	ChatDao              dao.ChatDao
+	EventRepository      dao.EventRepository
+	StoriesIndex         pkg.UserStoriesIndexer
+	EventService         EventService
+	UserProfileService   UserProfileInterface
+	SessionService       SessionServiceInterface
+	NotificationService  NotificationServiceInterface
+	S3Service            S3ServiceInterface
+	UserDao              dao.UserRepository
+}
+
+func NewModerationService() *ModerationService {
+	return &ModerationService{
+		ReportDao:            dao.NewUserReportRepository(),
+		UserProfileDao:       dao.NewUserProfileRepository(),
+		UserSearchProfileDao: dao.NewUserSearchProfile(),
+		UserMedia:            dao.NewUserMediaRepository(),
+		UserNudgesDao:        dao.NewUserNudgesDaoImpl(),
+		ChatDao:              dao.NewChatDaoImpl(),
+		EventRepository:      dao.NewEventRepositoryImpl(),
+		StoriesIndex:         pkg.NewUserStoriesIndexerImpl(),
+		EventService:         NewEventServiceImpl(),
+		UserProfileService:   NewUserProfileService(),
+		SessionService:       NewSessionService(),
+		NotificationService:  NewNotificationService(),
+		S3Service:            NewS3Service(),
+		UserDao:              &dao.UserDao{Connection: *db.GlobalOrm},
+	}
+}
+
+// ReportContent queues a report of the reporter about content of another user for moderation. A
+// report of the same content that is still open is returned instead of queueing another one.
+func (m *ModerationService) ReportContent(reporterID int, request dto.CreateReport) (dto.Report, error) {
+	if !model.IsValidReportContentType(request.ContentType) || !model.IsValidReportReason(request.Reason) {
+		return dto.Report{}, ErrInvalidReport
+	}
+
+	reportedID, err := m.findContentOwner(reporterID, request.ContentType, request.ContentID)
+	if err != nil {
+		return dto.Report{}, err
+	}
+	if reportedID == reporterID {
+		return dto.Report{}, ErrCannotReportSelf
+	}
+
+	report, err := m.ReportDao.FindOpenByReporter(reporterID, request.ContentType, request.ContentID)
+	if err == nil {
+		return reportDTO(report), nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		zapLogger.Logger.Error("error in finding open report", zap.Error(err))
+		return dto.Report{}, err
+	}
+
+	report, err = m.ReportDao.Insert(model.UserReport{
+		ReporterId:  reporterID,
+		ReportedId:  reportedID,
+		ContentType: request.ContentType,
+		ContentId:   request.ContentID,
+		Reason:      request.Reason,
+		Details:     request.Details,
+		Status:      model.ReportOpen,
+	})
+	if err != nil {
+		return dto.Report{}, err
+	}
+
+	return reportDTO(report), nil
+}
+
+// findContentOwner returns the user who owns the reported content. Messages can only be reported by
+// the users in the chat.
+func (m *ModerationService) findContentOwner(reporterID int, contentType, contentID string) (int, error) {
+	if contentType == model.ReportContentStory {
+		story, found, err := m.StoriesIndex.GetUserStory(contentID)
+		if err != nil {
+			return 0, err
+		}
+		if !found {
+			return 0, ErrReportedContentNotFound
+		}
+		profile, err := m.UserProfileDao.FindById(context.Background(), story.UserProfileID)
+		if err != nil {
+			return 0, notFoundAsReportedContent(err)
+		}
+		return profile.UserId, nil
+	}
+
+	id, err := strconv.Atoi(contentID)
+	if err != nil {
+		return 0, ErrReportedContentNotFound
+	}
+
+	switch contentType {
+	case model.ReportContentProfile:
+		profile, err := m.UserProfileDao.FindByUserId(context.Background(), id)
+		if err != nil {
+			return 0, notFoundAsReportedContent(err)
+		}
+		return profile.UserId, nil
+	case model.ReportContentPhoto:
+		media, err := m.UserMedia.FindById(context.Background(), id)
+		if err != nil {
+			return 0, err
+		}
+		if media.ID == 0 {
+			return 0, ErrReportedContentNotFound
+		}
+		return media.UserId, nil
+	case model.ReportContentNudge:
+		nudge, err := m.UserNudgesDao.GetUserNudgeById(id)
+		if err != nil {
+			return 0, notFoundAsReportedContent(err)
+		}
+		return nudge.UserID, nil
+	case model.ReportContentMessage:
+		message, err := m.ChatDao.FindById(id)
+		if err != nil {
+			return 0, notFoundAsReportedContent(err)
+		}
+		if message.SenderID != reporterID && message.ReceiverID != reporterID {
+			return 0, ErrReportedContentNotFound
+		}
+		return message.SenderID, nil
+	case model.ReportContentEvent:
+		event, err := m.EventRepository.GetEventById(id)
+		if err != nil {
+			return 0, err
+		}
+		if event.ID == 0 {
+			return 0, ErrReportedContentNotFound
+		}
+		return event.UserId, nil
+	default:
+		return 0, ErrInvalidReport
+	}
+}
+
+func notFoundAsReportedContent(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrReportedContentNotFound
+	}
+	return err
+}
+
+// GetReports returns a page of the moderation queue with the status, oldest first.
+func (m *ModerationService) GetReports(status string, page int) ([]dto.Report, error) {
+	if status != model.ReportOpen && status != model.ReportActioned && status != model.ReportDismissed {
+		return nil, ErrInvalidReportQueueStatus
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	reports, err := m.ReportDao.FindByStatus(status, reportPageSize, page*reportPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	reportDTOs := make([]dto.Report, 0, len(reports))
+	for _, report := range reports {
+		reportDTOs = append(reportDTOs, reportDTO(report))
+	}
+	return reportDTOs, nil
+}
+
+// GetReportReview returns a report with the reported content, what surrounds it and the profile of
+// the reported user.
+func (m *ModerationService) GetReportReview(reportID uint) (dto.ReportReview, error) {
+	report, err := m.findReport(reportID)
+	if err != nil {
+		return dto.ReportReview{}, err
+	}
+
+	review := dto.ReportReview{Report: reportDTO(report)}
+
+	review.OpenReports, err = m.ReportDao.CountOpenByReportedId(report.ReportedId)
+	if err != nil {
+		zapLogger.Logger.Error("error in counting open reports of user", zap.Error(err))
+		return dto.ReportReview{}, err
+	}
+
+	profile, err := m.UserProfileDao.FindByUserId(context.Background(), report.ReportedId)
+	if err == nil {
+		review.ReportedUser = profile
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.ReportReview{}, err
+	}
+
+	review.Content, review.Context, err = m.loadReportedContent(report)
+	if err != nil {
+		zapLogger.Logger.Error("error in loading reported content", zap.Error(err))
+		return dto.ReportReview{}, err
+	}
+
+	return review, nil
+}
+
+// loadReportedContent returns the reported content and its context. The content is nil when it was
+// removed since it was reported.
+func (m *ModerationService) loadReportedContent(report model.UserReport) (interface{}, interface{}, error) {
+	if report.ContentType == model.ReportContentStory {
+		story, found, err := m.StoriesIndex.GetUserStory(report.ContentId)
+		if err != nil || !found {
+			return nil, nil, err
+		}
+		if story.MediaURL != "" {
+			key := strings.ReplaceAll(strings.TrimPrefix(story.MediaURL, user_Stories_S3_Bucket_Path), "%3A", ":")
+			if story.MediaURL, err = m.S3Service.SignS3FilesUrl(user_stories_S3_bucket, key); err != nil {
+				return nil, nil, err
+			}
+		}
+		return story, nil, nil
+	}
+
+	id, err := strconv.Atoi(report.ContentId)
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	switch report.ContentType {
+	case model.ReportContentProfile:
+		media, err := m.UserProfileService.GetProfileMediaByUserId(report.ReportedId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, err
+		}
+		nudges, err := m.UserNudgesDao.GetUserNudgesDB(report.ReportedId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, err
+		}
+		return media, nudges, nil
+	case model.ReportContentPhoto:
+		media, err := m.UserMedia.FindById(context.Background(), id)
+		if err != nil || media.ID == 0 {
+			return nil, nil, err
+		}
+		key := strings.ReplaceAll(strings.TrimPrefix(media.URL, S3_BUCKET_PATH), "%3A", ":")
+		if media.URL, err = m.S3Service.SignS3FilesUrl(user_profile_S3_bucket, key); err != nil {
+			return nil, nil, err
+		}
+		return media, nil, nil
+	case model.ReportContentNudge:
+		nudge, err := m.UserNudgesDao.GetUserNudgeById(id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+		return nudge, nil, err
+	case model.ReportContentMessage:
+		message, err := m.ChatDao.FindById(id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+		conversation, err := m.ChatDao.RetrieveMessagesAround(message, reportContextMessages)
+		return message, conversation, err
+	case model.ReportContentEvent:
+		event, err := m.EventRepository.GetEventById(id)
+		if err != nil || event.ID == 0 {
+			return nil, nil, err
+		}
+		return event, nil, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+// ResolveReport takes the action of the moderator on the reported content and closes the report.
+// Acting on content also closes the other open reports of the same content. The reporters are
+// notified of the outcome.
+func (m *ModerationService) ResolveReport(moderatorID int, reportID uint, resolution dto.ResolveReport) (dto.Report, error) {
+	report, err := m.findReport(reportID)
+	if err != nil {
+		return dto.Report{}, err
+	}
+	if report.Status != model.ReportOpen {
+		return dto.Report{}, ErrReportResolved
+	}
+	if !model.IsValidModerationAction(resolution.Action, report.ContentType) {
+		return dto.Report{}, ErrInvalidModerationAction
+	}
+
+	status := model.ReportActioned
+	reports := []model.UserReport{report}
+	if resolution.Action == model.ModerationDismiss {
+		status = model.ReportDismissed
+	} else {
+		if err := m.takeModerationAction(report, resolution.Action); err != nil {
+			zapLogger.Logger.Error("error in taking moderation action", zap.String("action", resolution.Action), zap.Error(err))
+			return dto.Report{}, err
+		}
+
+		reports, err = m.ReportDao.FindOpenByContent(report.ContentType, report.ContentId)
+		if err != nil {
+			return dto.Report{}, err
+		}
+	}
+
+	reportIDs := make([]uint, 0, len(reports))
+	for _, r := range reports {
+		reportIDs = append(reportIDs, r.ID)
+	}
+	resolved, err := m.ReportDao.Resolve(reportIDs, status, resolution.Action, moderatorID, resolution.Note)
+	if err != nil {
+		return dto.Report{}, err
+	}
+
+	resolvedIDs := make(map[uint]bool, len(resolved))
+	for _, id := range resolved {
+		resolvedIDs[id] = true
+	}
+	if !resolvedIDs[report.ID] {
+		return dto.Report{}, ErrReportResolved
+	}
+	for _, r := range reports {
+		if resolvedIDs[r.ID] {
+			m.notifyReporter(r.ReporterId, status)
+		}
+	}
+
+	report, err = m.findReport(reportID)
+	if err != nil {
+		return dto.Report{}, err
+	}
+	return reportDTO(report), nil
+}
+
+func (m *ModerationService) takeModerationAction(report model.UserReport, action string) error {
+	switch action {
+	case model.ModerationSuspendAccount:
+		return m.suspendAccount(report.ReportedId)
+	case model.ModerationDeleteStory:
+		_, err := m.StoriesIndex.DeleteUserStory(report.ContentId)
+		return err
+	}
+
+	id, err := strconv.Atoi(report.ContentId)
+	if err != nil {
+		return ErrReportedContentNotFound
+	}
+
+	switch action {
+	case model.ModerationHideMedia:
+		return m.UserMedia.DeleteById(context.Background(), id, report.ReportedId)
+	case model.ModerationDeleteNudge:
+		return m.UserNudgesDao.DeleteUserNudge(id)
+	case model.ModerationDeleteEvent:
+		return m.EventService.DeleteUserEvent(report.ReportedId, id)
+	default:
+		return ErrInvalidModerationAction
+	}
+}
+
+// suspendAccount deactivates the account so it can no longer sign in, signs it out everywhere and
+// hides it from other users.
+func (m *ModerationService) suspendAccount(userID int) error {
+	if _, err := m.UserDao.Suspend(userID); err != nil {
+		return err
+	}
+
+	if err := m.SessionService.RevokeAllSessions(userID); err != nil {
+		return err
+	}
+
+	profile, err := m.UserProfileDao.FindByUserId(context.Background(), userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return m.UserSearchProfileDao.UpdatePause(context.Background(), int(profile.ID), true, nil)
+}
+
+// notifyReporter tells the reporter the outcome of their report. The report is resolved either way,
+// so a failed notification is only logged.
+func (m *ModerationService) notifyReporter(reporterID int, status string) {
+	notification := model.NotificationData{
+		Title: "Update on your report",
+		Body:  "Thanks for your report. We reviewed it and took action on the content you reported.",
+	}
+	if status == model.ReportDismissed {
+		notification.Body = "Thanks for your report. We reviewed it and found that the content does not go against our guidelines."
+	}
+
+	if err := m.NotificationService.SendNotificationToUser(reporterID, notification); err != nil {
+		zapLogger.Logger.Warn("error in notifying reporter of report outcome", zap.Int("user_id", reporterID), zap.Error(err))
+	}
+}
+
+func (m *ModerationService) findReport(reportID uint) (model.UserReport, error) {
+	report, err := m.ReportDao.FindById(reportID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.UserReport{}, ErrReportNotFound
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding report", zap.Error(err))
+		return model.UserReport{}, err
+	}
+	return report, nil
+}
+
+func reportDTO(report model.UserReport) dto.Report {
+	return dto.Report{
+		ID:            report.ID,
+		ReporterID:    report.ReporterId,
+		ReportedID:    report.ReportedId,
+		ContentType:   report.ContentType,
+		ContentID:     report.ContentId,
+		Reason:        report.Reason,
+		Details:       report.Details,
+		Status:        report.Status,
+		Action:        report.Action,
+		ModeratorID:   report.ModeratorId,
+		ModeratorNote: report.ModeratorNote,
+		ReportedAt:    report.CreatedAt,
+		ResolvedAt:    report.ResolvedAt,
+	}
+}
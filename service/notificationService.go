@@ -12,30 +12,35 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"go.uber.org/zap"
 )
 
 type NotificationServiceInterface interface {
 	InsertDeviceToken(deviceToken model.UserDeviceToken) error
 	SendNotificationToUser(userID int, message model.NotificationData) error
+	RemoveSessionDeviceTokens(userID int, sessionID string) error
+	RemoveAllDeviceTokens(userID int) error
 }
 
 type NotificationService struct {
-	s3Service          S3ServiceInterface
-	userDeviceTokenDao dao.UserDeviceTokenDao
-	userProfileService UserProfileInterface
+	S3Service          S3ServiceInterface
+	UserDeviceTokenDao dao.UserDeviceTokenDao
+	UserProfileService UserProfileInterface
+	// SNS is the client of the push notification service, a new one from the AWS config when nil.
+	SNS snsiface.SNSAPI
 }
 
 func NewNotificationService() *NotificationService {
 	return &NotificationService{
-		s3Service:          NewS3Service(),
-		userDeviceTokenDao: dao.NewUserDeviceTokenDaoImpl(),
-		userProfileService: NewUserProfileService(),
+		S3Service:          NewS3Service(),
+		UserDeviceTokenDao: dao.NewUserDeviceTokenDaoImpl(),
+		UserProfileService: NewUserProfileService(),
 	}
 }
 
 func (n *NotificationService) InsertDeviceToken(deviceToken model.UserDeviceToken) error {
-	deviceTokens, err := n.userDeviceTokenDao.GetDeviceTokensByUserId(deviceToken.UserID)
+	deviceTokens, err := n.UserDeviceTokenDao.GetDeviceTokensByUserId(deviceToken.UserID)
 	if err != nil {
 		zapLogger.Logger.Error("error getting user device tokens from DB", zap.Error(err))
 		return err
@@ -43,20 +48,23 @@ func (n *NotificationService) InsertDeviceToken(deviceToken model.UserDeviceToke
 	if err == nil && len(deviceTokens) > 0 {
 		for _, token := range deviceTokens {
 			if token.DeviceToken == deviceToken.DeviceToken {
+				if token.SessionID != deviceToken.SessionID {
+					return n.UserDeviceTokenDao.UpdateSessionId(token.ID, deviceToken.SessionID)
+				}
 				zapLogger.Logger.Info("device token already exists for user")
 				return errors.New("device token already exists for user")
 			}
 		}
 	}
 
-	endpointARN, err := createPlatformEndpoint(deviceToken.DeviceToken)
+	endpointARN, err := n.createPlatformEndpoint(deviceToken.DeviceToken)
 	if err != nil {
 		zapLogger.Logger.Error("error creating platform endpoint", zap.Error(err))
 		return err
 	}
 	deviceToken.EndpointARN = endpointARN
 
-	err = n.userDeviceTokenDao.Insert(deviceToken)
+	err = n.UserDeviceTokenDao.Insert(deviceToken)
 	if err != nil {
 		zapLogger.Logger.Error("error inserting user device token in DB", zap.Error(err))
 		return err
@@ -66,23 +74,17 @@ func (n *NotificationService) InsertDeviceToken(deviceToken model.UserDeviceToke
 }
 
 func (n *NotificationService) SendNotificationToUser(userID int, message model.NotificationData) error {
-	deviceTokens, err := n.userDeviceTokenDao.GetDeviceTokensByUserId(userID)
+	deviceTokens, err := n.UserDeviceTokenDao.GetDeviceTokensByUserId(userID)
 	if err != nil {
 		zapLogger.Logger.Error("error getting user device tokens from DB", zap.Error(err))
 		return err
 	}
 
-	sess, err := session.NewSession(&aws.Config{
-		Region:      aws.String(config.AppConfig.AWSConfig.Region),
-		Credentials: credentials.NewStaticCredentials(config.AppConfig.AWSConfig.AccessKeyID, config.AppConfig.AWSConfig.AccessKeySecret, ""),
-	})
+	svc, err := n.snsClient()
 	if err != nil {
-		zapLogger.Logger.Error("Failed to create session:", zap.Error(err))
 		return err
 	}
 
-	svc := sns.New(sess)
-
 	payload := convertMessageToPayload(message)
 
 	for _, deviceToken := range deviceTokens {
@@ -100,17 +102,56 @@ func (n *NotificationService) SendNotificationToUser(userID int, message model.N
 	return nil
 }
 
-func createPlatformEndpoint(deviceToken string) (string, error) {
-	sess, err := session.NewSession(&aws.Config{
-		Region:      aws.String(config.AppConfig.AWSConfig.Region),
-		Credentials: credentials.NewStaticCredentials(config.AppConfig.AWSConfig.AccessKeyID, config.AppConfig.AWSConfig.AccessKeySecret, ""),
-	})
+// RemoveSessionDeviceTokens unregisters the push tokens registered by a session, so a logged out
+// device stops receiving notifications.
+func (n *NotificationService) RemoveSessionDeviceTokens(userID int, sessionID string) error {
+	deviceTokens, err := n.UserDeviceTokenDao.GetDeviceTokensBySessionId(userID, sessionID)
 	if err != nil {
-		zapLogger.Logger.Error("Failed to create session:", zap.Error(err))
-		return "", err
+		zapLogger.Logger.Error("error getting session device tokens from DB", zap.Error(err))
+		return err
+	}
+
+	return n.removeDeviceTokens(deviceTokens)
+}
+
+func (n *NotificationService) RemoveAllDeviceTokens(userID int) error {
+	deviceTokens, err := n.UserDeviceTokenDao.GetDeviceTokensByUserId(userID)
+	if err != nil {
+		zapLogger.Logger.Error("error getting user device tokens from DB", zap.Error(err))
+		return err
+	}
+
+	return n.removeDeviceTokens(deviceTokens)
+}
+
+func (n *NotificationService) removeDeviceTokens(deviceTokens []model.UserDeviceToken) error {
+	if len(deviceTokens) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(deviceTokens))
+	for _, deviceToken := range deviceTokens {
+		err := n.deletePlatformEndpoint(deviceToken.EndpointARN)
+		if err != nil {
+			zapLogger.Logger.Error("error deleting platform endpoint", zap.Error(err))
+		}
+		ids = append(ids, deviceToken.ID)
 	}
 
-	svc := sns.New(sess)
+	err := n.UserDeviceTokenDao.Delete(ids)
+	if err != nil {
+		zapLogger.Logger.Error("error deleting user device tokens from DB", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (n *NotificationService) createPlatformEndpoint(deviceToken string) (string, error) {
+	svc, err := n.snsClient()
+	if err != nil {
+		return "", err
+	}
 
 	resp, err := svc.CreatePlatformEndpoint(&sns.CreatePlatformEndpointInput{
 		PlatformApplicationArn: aws.String(config.AppConfig.AWSConfig.PlatformApplicationArn),
@@ -124,6 +165,35 @@ func createPlatformEndpoint(deviceToken string) (string, error) {
 	return *resp.EndpointArn, nil
 }
 
+func (n *NotificationService) deletePlatformEndpoint(endpointARN string) error {
+	svc, err := n.snsClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.DeleteEndpoint(&sns.DeleteEndpointInput{
+		EndpointArn: aws.String(endpointARN),
+	})
+	return err
+}
+
+func (n *NotificationService) snsClient() (snsiface.SNSAPI, error) {
+	if n.SNS != nil {
+		return n.SNS, nil
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(config.AppConfig.AWSConfig.Region),
+		Credentials: credentials.NewStaticCredentials(config.AppConfig.AWSConfig.AccessKeyID, config.AppConfig.AWSConfig.AccessKeySecret, ""),
+	})
+	if err != nil {
+		zapLogger.Logger.Error("Failed to create session:", zap.Error(err))
+		return nil, err
+	}
+
+	return sns.New(sess), nil
+}
+
 func convertMessageToPayload(message model.NotificationData) string {
 	notificationObject := model.Notification{
 		Notification: model.NotificationData{
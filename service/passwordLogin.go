@@ -0,0 +1,344 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/mail"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	passwordMinLength = 8
+	// bcrypt ignores everything after the first 72 bytes
+	passwordMaxLength = 72
+
+	passwordResetTTL       = time.Hour
+	passwordResetWindow    = time.Hour
+	passwordResetLimit     = 3
+	loginFailureWindow     = 15 * time.Minute
+	loginMaxFailedAttempts = 10
+	passwordResetKey       = "password:reset:"
+	loginFailedAttemptsKey = "login:failed:"
+)
+
+var (
+	ErrWeakPassword       = errors.New("password is too weak")
+	ErrInvalidEmail       = errors.New("invalid email address")
+	ErrUserAlreadyExists  = errors.New("user already exist")
+	ErrInvalidCredentials = errors.New("username or password is wrong")
+	ErrInvalidResetToken  = errors.New("password reset link is invalid or expired")
+
+	commonPasswords = map[string]bool{
+		"password1": true, "password123": true, "12345678a": true, "qwerty123": true, "abc12345": true, "iloveyou1": true,
+	}
+	// hash compared against when the email is unknown, so failed logins take the same time either way
+	dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password 1"), bcrypt.DefaultCost)
+)
+
+// ValidatePasswordStrength enforces the password policy: 8 to 72 characters, at least one letter
+// and one digit, and not a common password or the account's email address.
+func ValidatePasswordStrength(password string, email string) error {
+	if len(password) < passwordMinLength {
+		return fmt.Errorf("%w: use at least %d characters", ErrWeakPassword, passwordMinLength)
+	}
+	if len(password) > passwordMaxLength {
+		return fmt.Errorf("%w: use at most %d characters", ErrWeakPassword, passwordMaxLength)
+	}
+
+	hasLetter, hasDigit := false, false
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return fmt.Errorf("%w: use both letters and digits", ErrWeakPassword)
+	}
+
+	lower, email := strings.ToLower(password), strings.ToLower(email)
+	if commonPasswords[lower] || (email != "" && (strings.Contains(lower, email) || lower == strings.Split(email, "@")[0])) {
+		return fmt.Errorf("%w: do not use a common password or your email address", ErrWeakPassword)
+	}
+
+	return nil
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	address, err := netmail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
+
+// PasswordSignUp creates an email and password account and signs it in.
+func (l *LoginService) PasswordSignUp(registration dto.UserRegistrationDTO, device dto.SessionDevice) (int, dto.AuthToken, error) {
+	email, err := normalizeEmail(registration.Email)
+	if err != nil {
+		return 0, dto.AuthToken{}, err
+	}
+
+	if err := ValidatePasswordStrength(registration.Password, email); err != nil {
+		return 0, dto.AuthToken{}, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(registration.Password), bcrypt.DefaultCost)
+	if err != nil {
+		zapLogger.Logger.Error("error in hashing password", zap.Error(err))
+		return 0, dto.AuthToken{}, err
+	}
+
+	user := model.User{
+		FirstName:  registration.FirstName,
+		LastName:   registration.LastName,
+		Mobile:     registration.Mobile,
+		Code:       registration.Code,
+		Email:      email,
+		Password:   string(hashedPassword),
+		IsActive:   true,
+		SignUpType: "password",
+	}
+
+	identity := model.UserIdentity{
+		Provider: model.IdentityProviderPassword,
+		Subject:  email,
+		Email:    email,
+	}
+
+	user, authToken, err := NewIdentityService().SignUp(identity, user, device)
+	if errors.Is(err, ErrIdentityAlreadyLinked) {
+		return 0, dto.AuthToken{}, ErrUserAlreadyExists
+	} else if err != nil {
+		return 0, dto.AuthToken{}, err
+	}
+
+	if err := l.SendVerificationEmail(email); err != nil {
+		zapLogger.Logger.Error("error in sending verification email after sign up", zap.Error(err))
+	}
+
+	return int(user.ID), authToken, nil
+}
+
+// PasswordSignIn checks the email and password of an account and signs it in. Accounts are locked
+// out for a while after too many failed attempts.
+func (l *LoginService) PasswordSignIn(email string, password string, device dto.SessionDevice) (model.User, dto.AuthToken, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	failedAttemptsKey := loginFailedAttemptsKey + email
+
+	failures, ttl, err := l.RateLimiter.Count(failedAttemptsKey)
+	if err != nil {
+		return model.User{}, dto.AuthToken{}, err
+	}
+	if failures >= loginMaxFailedAttempts {
+		return model.User{}, dto.AuthToken{}, &RateLimitError{RetryAfter: ttl, Locked: true}
+	}
+
+	user, err := l.findPasswordUser(email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.User{}, dto.AuthToken{}, err
+	}
+
+	passwordHash := dummyPasswordHash
+	if err == nil {
+		passwordHash = []byte(user.Password)
+	}
+
+	if bcrypt.CompareHashAndPassword(passwordHash, []byte(password)) != nil || err != nil {
+		if _, _, err := l.RateLimiter.Increment(failedAttemptsKey, loginFailureWindow); err != nil {
+			zapLogger.Logger.Error("error in counting failed login attempt", zap.Error(err))
+		}
+		return model.User{}, dto.AuthToken{}, ErrInvalidCredentials
+	}
+
+	if err := l.RateLimiter.Reset(failedAttemptsKey); err != nil {
+		zapLogger.Logger.Error("error in resetting failed login attempts", zap.Error(err))
+	}
+
+	authToken, err := l.UserSignIN(user, device)
+	if err != nil {
+		return model.User{}, dto.AuthToken{}, err
+	}
+
+	return user, authToken, nil
+}
+
+// findPasswordUser returns the account the email and password sign in method is linked to.
+func (l *LoginService) findPasswordUser(email string) (model.User, error) {
+	identity, err := l.UserIdentityDao.FindByProviderSubject(model.IdentityProviderPassword, email)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			zapLogger.Logger.Error("error in finding password identity", zap.Error(err))
+		}
+		return model.User{}, err
+	}
+
+	user, err := l.UserDao.FindById(identity.UserId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		zapLogger.Logger.Error("error in finding user of password identity", zap.Error(err))
+	}
+	return user, err
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// passwordResetLink adds the reset token to the password reset page or deep link of the app.
+func passwordResetLink(resetURL string, resetToken string) (string, error) {
+	link, err := url.Parse(resetURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := link.Query()
+	query.Set("token", resetToken)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// ForgotPassword mails a single use password reset link to a password account. It succeeds for
+// unknown emails too, so it cannot be used to find out which accounts exist.
+func (l *LoginService) ForgotPassword(email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	count, _, err := l.RateLimiter.Increment(passwordResetKey+email, passwordResetWindow)
+	if err != nil {
+		return err
+	}
+	if count > passwordResetLimit {
+		zapLogger.Logger.Info("password reset limit reached", zap.String("email", email))
+		return nil
+	}
+
+	user, err := l.findPasswordUser(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	resetToken := base64.RawURLEncoding.EncodeToString(b)
+
+	// a new link replaces the ones sent before
+	err = l.PasswordResetDao.ConsumeAllByUserId(int(user.ID))
+	if err != nil {
+		zapLogger.Logger.Error("error in invalidating previous password resets", zap.Error(err))
+		return err
+	}
+
+	err = l.PasswordResetDao.Insert(model.PasswordReset{
+		UserId:    int(user.ID),
+		TokenHash: hashResetToken(resetToken),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	resetUrl, err := passwordResetLink(config.ConfigValue.MailConfig.PasswordResetURL, resetToken)
+	if err != nil {
+		zapLogger.Logger.Error("error in building password reset link", zap.Error(err))
+		return err
+	}
+	message, err := mail.Render(mail.PasswordResetTemplate, email, mail.PasswordResetData{
+		AppName:          config.ConfigValue.MailConfig.AppName,
+		Name:             user.FirstName,
+		ResetURL:         resetUrl,
+		ExpiresInMinutes: int(passwordResetTTL.Minutes()),
+	})
+	if err != nil {
+		zapLogger.Logger.Error("error in rendering password reset email", zap.Error(err))
+		return err
+	}
+
+	_, err = l.Mailer.Send(message)
+	if err != nil {
+		zapLogger.Logger.Error(fmt.Sprintf("Error sending password reset email to account: %s", email), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token and signs the account out everywhere.
+func (l *LoginService) ResetPassword(resetToken string, password string) error {
+	passwordReset, err := l.PasswordResetDao.FindByTokenHash(hashResetToken(resetToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidResetToken
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding password reset", zap.Error(err))
+		return err
+	}
+
+	if passwordReset.UsedAt != nil || time.Now().After(passwordReset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := l.UserDao.FindById(passwordReset.UserId)
+	if err != nil {
+		zapLogger.Logger.Error("error in finding user of password reset", zap.Error(err))
+		return ErrInvalidResetToken
+	}
+
+	if err := ValidatePasswordStrength(password, user.Email); err != nil {
+		return err
+	}
+
+	consumed, err := l.PasswordResetDao.Consume(passwordReset.ID)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		zapLogger.Logger.Error("error in hashing password", zap.Error(err))
+		return err
+	}
+
+	err = l.UserDao.UpdatePassword(passwordReset.UserId, string(hashedPassword))
+	if err != nil {
+		return err
+	}
+
+	err = l.PasswordResetDao.ConsumeAllByUserId(passwordReset.UserId)
+	if err != nil {
+		zapLogger.Logger.Error("error in invalidating other password resets", zap.Error(err))
+	}
+
+	err = NewSessionService().RevokeAllSessions(passwordReset.UserId)
+	if err != nil {
+		zapLogger.Logger.Error("error in revoking sessions after password reset", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
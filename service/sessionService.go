@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/pkg/db/dao"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type SessionServiceInterface interface {
+	GetActiveSessions(userID int, currentSessionID string) ([]dto.Session, error)
+	RevokeSession(userID int, sessionID string) error
+	RevokeAllSessions(userID int) error
+}
+
+type SessionService struct {
+	UserTokenDao        dao.UserTokenRepository
+	NotificationService NotificationServiceInterface
+}
+
+func NewSessionService() *SessionService {
+	return &SessionService{
+		UserTokenDao:        &dao.UserTokenDao{Connection: *db.GlobalOrm},
+		NotificationService: NewNotificationService(),
+	}
+}
+
+func (s *SessionService) GetActiveSessions(userID int, currentSessionID string) ([]dto.Session, error) {
+	userTokens, err := s.UserTokenDao.FindByUserId(context.Background(), userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in getting user sessions from DB", zap.Error(err))
+		return nil, err
+	}
+
+	sessions := make([]dto.Session, 0, len(userTokens))
+	for _, userToken := range userTokens {
+		sessions = append(sessions, dto.Session{
+			SessionID:        userToken.FamilyID,
+			UserAgent:        userToken.UserAgent,
+			IPAddress:        userToken.IPAddress,
+			LastUsedAt:       userToken.LastUsedAt,
+			RefreshExpiresAt: userToken.RefreshExpiresAt,
+			Current:          userToken.FamilyID == currentSessionID,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession logs out a single session of the user and unregisters the push tokens of its device.
+func (s *SessionService) RevokeSession(userID int, sessionID string) error {
+	if sessionID == "" {
+		return ErrSessionNotFound
+	}
+
+	revoked, err := s.UserTokenDao.RemoveSessionByFamily(context.Background(), userID, sessionID)
+	if err != nil {
+		zapLogger.Logger.Error("error in revoking user session", zap.Error(err))
+		return err
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+
+	err = s.NotificationService.RemoveSessionDeviceTokens(userID, sessionID)
+	if err != nil {
+		zapLogger.Logger.Error("error in removing device tokens of session", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RevokeAllSessions logs the user out everywhere and unregisters all of their push tokens.
+func (s *SessionService) RevokeAllSessions(userID int) error {
+	err := s.UserTokenDao.RemoveSession(context.Background(), int64(userID))
+	if err != nil {
+		zapLogger.Logger.Error("error in revoking user sessions", zap.Error(err))
+		return err
+	}
+
+	err = s.NotificationService.RemoveAllDeviceTokens(userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in removing device tokens of user", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model/dto"
+	"github.com/golang-jwt/jwt"
+)
+
+// SigningKeys is the key set used to sign and verify auth tokens, loaded at startup by LoadSigningKeys.
+var SigningKeys *KeySet
+
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+type KeySet struct {
+	signingKey *signingKey
+	keys       map[string]*signingKey
+}
+
+func LoadSigningKeys(conf config.JWTConfig) error {
+	keySet, err := NewKeySet(conf)
+	if err != nil {
+		return err
+	}
+
+	SigningKeys = keySet
+	return nil
+}
+
+// NewKeySet parses the configured keys. When no SigningKeyID is configured the first key signs new tokens.
+func NewKeySet(conf config.JWTConfig) (*KeySet, error) {
+	if len(conf.Keys) == 0 {
+		return nil, errors.New("no jwt keys configured")
+	}
+
+	keySet := &KeySet{
+		keys: make(map[string]*signingKey),
+	}
+
+	for _, keyConfig := range conf.Keys {
+		if keyConfig.ID == "" {
+			return nil, errors.New("jwt key id is empty")
+		}
+		if _, ok := keySet.keys[keyConfig.ID]; ok {
+			return nil, fmt.Errorf("duplicate jwt key id %s", keyConfig.ID)
+		}
+
+		key, err := parseSigningKey(keyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", keyConfig.ID, err)
+		}
+		keySet.keys[key.id] = key
+	}
+
+	signingKeyID := conf.SigningKeyID
+	if signingKeyID == "" {
+		signingKeyID = conf.Keys[0].ID
+	}
+
+	key, ok := keySet.keys[signingKeyID]
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not configured", signingKeyID)
+	}
+	if key.signKey == nil {
+		return nil, fmt.Errorf("signing key %s has no private key", signingKeyID)
+	}
+	keySet.signingKey = key
+
+	return keySet, nil
+}
+
+func parseSigningKey(keyConfig config.JWTKey) (*signingKey, error) {
+	key := &signingKey{id: keyConfig.ID}
+
+	switch keyConfig.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		if keyConfig.Key == "" {
+			return nil, errors.New("secret is empty")
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(keyConfig.Key)
+		key.verifyKey = []byte(keyConfig.Key)
+
+	case jwt.SigningMethodRS256.Alg():
+		pemBytes, err := readPEM(keyConfig.Key)
+		if err != nil {
+			return nil, err
+		}
+		key.method = jwt.SigningMethodRS256
+		if privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes); err == nil {
+			key.signKey = privateKey
+			key.verifyKey = &privateKey.PublicKey
+		} else if publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes); err == nil {
+			key.verifyKey = publicKey
+		} else {
+			return nil, errors.New("invalid RSA key")
+		}
+
+	case jwt.SigningMethodES256.Alg():
+		pemBytes, err := readPEM(keyConfig.Key)
+		if err != nil {
+			return nil, err
+		}
+		key.method = jwt.SigningMethodES256
+		var publicKey *ecdsa.PublicKey
+		if privateKey, err := jwt.ParseECPrivateKeyFromPEM(pemBytes); err == nil {
+			key.signKey = privateKey
+			publicKey = &privateKey.PublicKey
+		} else if publicKey, err = jwt.ParseECPublicKeyFromPEM(pemBytes); err != nil {
+			return nil, errors.New("invalid EC key")
+		}
+		if publicKey.Curve != elliptic.P256() {
+			return nil, errors.New("ES256 requires a P-256 key")
+		}
+		key.verifyKey = publicKey
+
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", keyConfig.Algorithm)
+	}
+
+	return key, nil
+}
+
+// readPEM accepts either inline PEM or the path of a PEM file.
+func readPEM(key string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN") {
+		return []byte(key), nil
+	}
+	return os.ReadFile(key)
+}
+
+// Sign signs the claims with the current signing key and sets its kid header.
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.signingKey.method, claims)
+	token.Header["kid"] = k.signingKey.id
+
+	return token.SignedString(k.signingKey.signKey)
+}
+
+// KeyFunc resolves the verification key of a token from its kid header.
+func (k *KeySet) KeyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("invalid token")
+	}
+
+	return key.verifyKey, nil
+}
+
+// JWKS returns the public keys of the set. HS256 keys are shared secrets and are never published.
+func (k *KeySet) JWKS() dto.JWKS {
+	jwks := dto.JWKS{Keys: []dto.JWK{}}
+
+	for _, key := range k.keys {
+		switch publicKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, dto.JWK{
+				Kty: "RSA",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			jwks.Keys = append(jwks.Keys, dto.JWK{
+				Kty: "EC",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				Crv: publicKey.Curve.Params().Name,
+				X:   base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size))),
+				Y:   base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size))),
+			})
+		}
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+	return jwks
+}
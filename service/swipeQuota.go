@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/utilities"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+)
+
+const (
+	likeQuotaKey      = "quota:like:"
+	superLikeQuotaKey = "quota:superlike:"
+)
+
+// QuotaExceededError is returned when a daily swipe quota is used up.
+type QuotaExceededError struct {
+	Quota   string
+	Limit   int
+	ResetAt time.Time
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("daily %s limit of %d reached, it resets at %s", e.Quota, e.Limit, e.ResetAt.Format(time.RFC3339))
+}
+
+// useSwipeQuota counts a like or super like against the daily quota of the plan of the user. Quotas
+// reset at midnight in the time zone of the user. The likes left today are set on result.
+func (s *SwipeService) useSwipeQuota(userID int, swipeType int, result *dto.SwipeResult) error {
+	var quota, key string
+	var limit, premiumLimit int
+	switch swipeType {
+	case model.SwipeLike:
+		if s.SwipeConfig.LikesPerDay == 0 && s.SwipeConfig.PremiumLikesPerDay == 0 {
+			return nil
+		}
+		quota, key, limit, premiumLimit = "like", likeQuotaKey, s.SwipeConfig.LikesPerDay, s.SwipeConfig.PremiumLikesPerDay
+	case model.SwipeSuperLike:
+		quota, key, limit, premiumLimit = "super like", superLikeQuotaKey, s.SwipeConfig.SuperLikesPerDay, s.SwipeConfig.PremiumSuperLikesPerDay
+	default:
+		return nil
+	}
+
+	profile, err := s.UserProfileDao.FindByUserId(context.Background(), userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in getting user profile for "+quota+" quota", zap.Error(err))
+		return err
+	}
+	if profile.IsPremium {
+		limit = premiumLimit
+	}
+	if swipeType == model.SwipeLike && limit == 0 {
+		return nil
+	}
+
+	now := time.Now().In(userLocation(profile))
+	resetAt := nextDailyReset(now)
+	count, _, err := s.RateLimiter.Increment(key+utilities.ConvertIntToString(userID), resetAt.Sub(now))
+	if err != nil {
+		return err
+	}
+	if count > int64(limit) {
+		// a rejected swipe is not counted, or a refund later would not give back a usable one
+		s.refundSwipeQuota(userID, swipeType)
+		return &QuotaExceededError{Quota: quota, Limit: limit, ResetAt: resetAt}
+	}
+
+	if swipeType == model.SwipeLike {
+		remaining := limit - int(count)
+		result.RemainingLikes = &remaining
+		result.LikesResetAt = &resetAt
+	}
+	return nil
+}
+
+// refundSwipeQuota gives back a like or super like counted by useSwipeQuota, for a swipe that was
+// rejected, not recorded or undone. A quota that reset since is left alone.
+func (s *SwipeService) refundSwipeQuota(userID int, swipeType int) {
+	var key string
+	switch swipeType {
+	case model.SwipeLike:
+		key = likeQuotaKey
+	case model.SwipeSuperLike:
+		key = superLikeQuotaKey
+	default:
+		return
+	}
+
+	if err := s.RateLimiter.Decrement(key + utilities.ConvertIntToString(userID)); err != nil {
+		zapLogger.Logger.Warn("error in refunding swipe quota", zap.Int("user_id", userID), zap.Error(err))
+	}
+}
+
+// userLocation returns the time zone of the user, or UTC when it is not set.
+func userLocation(profile model.UserProfile) *time.Location {
+	if profile.TimeZone == nil {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(*profile.TimeZone)
+	if err != nil {
+		zapLogger.Logger.Warn("unknown time zone of user profile", zap.Int("user_id", profile.UserId), zap.Error(err))
+		return time.UTC
+	}
+	return location
+}
+
+// nextDailyReset returns the next midnight after now, in the location of now.
+func nextDailyReset(now time.Time) time.Time {
+	year, month, day := now.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+}
@@ -0,0 +1,208 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/model"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	mockindex "github.com/SuperMatch/pkg/elasticSeach/mocks"
+	mockredis "github.com/SuperMatch/pkg/redis/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/SuperMatch/service/mocks"
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestScheduleDeletionRequiresRecentAuthentication(t *testing.T) {
+	accountDeletionService := &service.AccountDeletionService{}
+
+	for _, authenticatedAt := range []time.Time{{}, time.Now().Add(-time.Hour)} {
+		if _, err := accountDeletionService.ScheduleDeletion(1, authenticatedAt); !errors.Is(err, service.ErrReauthenticationRequired) {
+			t.Errorf("ScheduleDeletion after signing in at %v = %v, expected ErrReauthenticationRequired", authenticatedAt, err)
+		}
+	}
+}
+
+// A deletion is erased after the grace period, and the account is signed out everywhere right away.
+func TestScheduleDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeletion := mockdao.NewMockAccountDeletionRepository(ctrl)
+	revoker := &sessions{}
+	accountDeletionService := &service.AccountDeletionService{
+		AccountDeletionDao: mockDeletion,
+		SessionService:     revoker,
+		GracePeriod:        30 * 24 * time.Hour,
+	}
+	mockDeletion.EXPECT().FindScheduledByUserId(1).Return(model.AccountDeletion{}, gorm.ErrRecordNotFound)
+	mockDeletion.EXPECT().Insert(gomock.Any()).DoAndReturn(func(deletion model.AccountDeletion) (model.AccountDeletion, error) {
+		if deletion.UserId != 1 || deletion.Status != model.AccountDeletionScheduled {
+			t.Errorf("inserted deletion %+v, expected a scheduled deletion of user 1", deletion)
+		}
+		if grace := time.Until(deletion.PurgeAfter); grace < 29*24*time.Hour || grace > 30*24*time.Hour {
+			t.Errorf("deletion is purged after %v, expected the grace period", deletion.PurgeAfter)
+		}
+		deletion.ID = 4
+		return deletion, nil
+	})
+
+	deletion, err := accountDeletionService.ScheduleDeletion(1, time.Now())
+	if err != nil || deletion.ID != 4 {
+		t.Fatalf("ScheduleDeletion = %+v, %v", deletion, err)
+	}
+	if !reflect.DeepEqual(revoker.revoked, []int{1}) {
+		t.Errorf("revoked the sessions of %v, expected user 1", revoker.revoked)
+	}
+}
+
+// Asking again returns the deletion already scheduled.
+func TestScheduleDeletionTwice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeletion := mockdao.NewMockAccountDeletionRepository(ctrl)
+	accountDeletionService := &service.AccountDeletionService{AccountDeletionDao: mockDeletion}
+	scheduled := model.AccountDeletion{ID: 4, UserId: 1, Status: model.AccountDeletionScheduled}
+	mockDeletion.EXPECT().FindScheduledByUserId(1).Return(scheduled, nil)
+
+	if deletion, err := accountDeletionService.ScheduleDeletion(1, time.Now()); err != nil || deletion.ID != 4 {
+		t.Errorf("ScheduleDeletion = %+v, %v, expected the scheduled deletion", deletion, err)
+	}
+}
+
+func TestRestoreAccount(t *testing.T) {
+	scheduled := model.AccountDeletion{ID: 4, UserId: 1, Status: model.AccountDeletionScheduled}
+
+	t.Run("in the grace period", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDeletion := mockdao.NewMockAccountDeletionRepository(ctrl)
+		accountDeletionService := &service.AccountDeletionService{AccountDeletionDao: mockDeletion}
+		mockDeletion.EXPECT().FindScheduledByUserId(1).Return(scheduled, nil)
+		mockDeletion.EXPECT().Cancel(uint(4)).Return(true, nil)
+
+		if err := accountDeletionService.RestoreAccount(1); err != nil {
+			t.Errorf("RestoreAccount = %v", err)
+		}
+	})
+
+	// the worker claimed the deletion between the read and the cancel
+	t.Run("being erased", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDeletion := mockdao.NewMockAccountDeletionRepository(ctrl)
+		accountDeletionService := &service.AccountDeletionService{AccountDeletionDao: mockDeletion}
+		mockDeletion.EXPECT().FindScheduledByUserId(1).Return(scheduled, nil)
+		mockDeletion.EXPECT().Cancel(uint(4)).Return(false, nil)
+
+		if err := accountDeletionService.RestoreAccount(1); !errors.Is(err, service.ErrAccountDeletionInProgress) {
+			t.Errorf("RestoreAccount = %v, expected ErrAccountDeletionInProgress", err)
+		}
+	})
+
+	t.Run("not scheduled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDeletion := mockdao.NewMockAccountDeletionRepository(ctrl)
+		accountDeletionService := &service.AccountDeletionService{AccountDeletionDao: mockDeletion}
+		mockDeletion.EXPECT().FindScheduledByUserId(1).Return(model.AccountDeletion{}, gorm.ErrRecordNotFound)
+
+		if err := accountDeletionService.RestoreAccount(1); !errors.Is(err, service.ErrAccountDeletionNotFound) {
+			t.Errorf("RestoreAccount = %v, expected ErrAccountDeletionNotFound", err)
+		}
+	})
+}
+
+// The worker erases the account from search, redis, S3 and MySQL and records what it erased. A
+// deletion another worker claimed is skipped.
+func TestProcessDueDeletions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeletion := mockdao.NewMockAccountDeletionRepository(ctrl)
+	mockProfileIndex := mockindex.NewMockElasticSearchIndexer(ctrl)
+	mockStoriesIndex := mockindex.NewMockUserStoriesIndexer(ctrl)
+	mockEventIndex := mockindex.NewMockEventIndexer(ctrl)
+	mockCache := mockredis.NewMockLikeDislikeCacheInterface(ctrl)
+	mockS3 := mocks.NewMockS3ServiceInterface(ctrl)
+	deviceTokens := &notifications{}
+	accountDeletionService := &service.AccountDeletionService{
+		AccountDeletionDao:  mockDeletion,
+		ProfileIndex:        mockProfileIndex,
+		StoriesIndex:        mockStoriesIndex,
+		EventIndex:          mockEventIndex,
+		LikeDislikeCache:    mockCache,
+		S3Service:           mockS3,
+		NotificationService: deviceTokens,
+	}
+	due := []model.AccountDeletion{{ID: 4, UserId: 1}, {ID: 5, UserId: 2}}
+	mockDeletion.EXPECT().FindDue(gomock.Any(), gomock.Any()).Return(due, nil)
+	mockDeletion.EXPECT().Claim(uint(4)).Return(true, nil)
+	mockDeletion.EXPECT().Claim(uint(5)).Return(false, nil)
+
+	mockDeletion.EXPECT().FindProfileIds(1).Return([]int{7}, nil)
+	mockProfileIndex.EXPECT().DeleteUserProfile(7).Return(true, nil)
+	mockStoriesIndex.EXPECT().DeleteUserStories(7).Return(int64(2), nil)
+	mockEventIndex.EXPECT().DeleteUserEvents(1).Return(int64(1), nil)
+	mockCache.EXPECT().DeleteUserData("1").Return(int64(3), nil)
+	mockS3.EXPECT().DeleteFolder(gomock.Any(), "1/").Return(int64(4), nil).Times(2)
+	mockDeletion.EXPECT().EraseUserData(1).Return(map[string]int64{"users": 1}, nil)
+	mockDeletion.EXPECT().Complete(uint(4), gomock.Any()).DoAndReturn(func(_ uint, receiptJSON string) error {
+		var receipt model.ErasureReceipt
+		if err := json.Unmarshal([]byte(receiptJSON), &receipt); err != nil {
+			t.Fatalf("receipt is not json: %v", err)
+		}
+		if receipt.UserID != 1 || receipt.Rows["users"] != 1 || receipt.RedisKeys != 3 || len(receipt.S3Objects) != 2 {
+			t.Errorf("receipt %+v does not list what was erased", receipt)
+		}
+		if receipt.SearchDocuments["user_profile"] != 1 || receipt.SearchDocuments["user_stories"] != 2 || receipt.SearchDocuments["events"] != 1 {
+			t.Errorf("receipt search documents %v, expected the profile, stories and events", receipt.SearchDocuments)
+		}
+		return nil
+	})
+
+	if erased, err := accountDeletionService.ProcessDueDeletions(); err != nil || erased != 1 {
+		t.Errorf("ProcessDueDeletions = %d, %v, expected one account erased", erased, err)
+	}
+	if !reflect.DeepEqual(deviceTokens.cleared, []int{1}) {
+		t.Errorf("removed the device tokens of %v, expected user 1", deviceTokens.cleared)
+	}
+}
+
+// A failed erasure is recorded and left for the next run.
+func TestProcessDueDeletionsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeletion := mockdao.NewMockAccountDeletionRepository(ctrl)
+	mockEventIndex := mockindex.NewMockEventIndexer(ctrl)
+	mockCache := mockredis.NewMockLikeDislikeCacheInterface(ctrl)
+	mockS3 := mocks.NewMockS3ServiceInterface(ctrl)
+	accountDeletionService := &service.AccountDeletionService{
+		AccountDeletionDao:  mockDeletion,
+		EventIndex:          mockEventIndex,
+		LikeDislikeCache:    mockCache,
+		S3Service:           mockS3,
+		NotificationService: &notifications{},
+	}
+	mockDeletion.EXPECT().FindDue(gomock.Any(), gomock.Any()).Return([]model.AccountDeletion{{ID: 4, UserId: 1}}, nil)
+	mockDeletion.EXPECT().Claim(uint(4)).Return(true, nil)
+	mockDeletion.EXPECT().FindProfileIds(1).Return(nil, nil)
+	mockEventIndex.EXPECT().DeleteUserEvents(1).Return(int64(0), nil)
+	mockCache.EXPECT().DeleteUserData("1").Return(int64(0), nil)
+	mockS3.EXPECT().DeleteFolder(gomock.Any(), "1/").Return(int64(0), nil).Times(2)
+	mockDeletion.EXPECT().EraseUserData(1).Return(nil, errors.New("lock wait timeout"))
+	mockDeletion.EXPECT().Fail(uint(4), "lock wait timeout").Return(nil)
+
+	if erased, err := accountDeletionService.ProcessDueDeletions(); err != nil || erased != 0 {
+		t.Errorf("ProcessDueDeletions = %d, %v, expected nothing erased", erased, err)
+	}
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/model"
+	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	mockindex "github.com/SuperMatch/pkg/elasticSeach/mocks"
+	mockredis "github.com/SuperMatch/pkg/redis/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/SuperMatch/utilities"
+	"github.com/golang/mock/gomock"
+)
+
+func TestPauseAccountRequiresFutureResume(t *testing.T) {
+	userSearchProfileService := &service.UserSearchProfileService{}
+
+	past := time.Now().Add(-time.Minute)
+	if _, err := userSearchProfileService.PauseAccount(context.Background(), model.UserProfile{}, &past); !errors.Is(err, service.ErrInvalidPauseUntil) {
+		t.Errorf("PauseAccount until %v = %v, expected ErrInvalidPauseUntil", past, err)
+	}
+}
+
+func TestExcludeInts(t *testing.T) {
+	result := utilities.ExcludeInts([]int{4, 1, 3, 2}, []int{3, 5})
+	if !reflect.DeepEqual(result, []int{4, 1, 2}) {
+		t.Errorf("ExcludeInts = %v, expected [4 1 2]", result)
+	}
+
+	if result := utilities.ExcludeInts(nil, []int{1}); len(result) != 0 {
+		t.Errorf("ExcludeInts of nothing = %v", result)
+	}
+}
+
+// The search leaves out paused users and users whose snooze is not over, next to the blocked users.
+func TestSearchProfileExcludesPaused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIndex := mockindex.NewMockElasticSearchIndexer(ctrl)
+	mockBlockDao := mockdao.NewMockUserBlockRepository(ctrl)
+	mockBlockDao.EXPECT().FindBlockedUserIds(1).Return([]int{3}, nil)
+
+	var mustNot []map[string]interface{}
+	mockIndex.EXPECT().SearchProfile(gomock.Any()).DoAndReturn(func(query map[string]interface{}) ([]elasticsearchPkg.UserProfile, error) {
+		boolQuery := query["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		mustNot = boolQuery["must_not"].([]map[string]interface{})
+		return nil, nil
+	})
+
+	userProfileService := service.NewUserProfileSearch(mockIndex, mockBlockDao)
+	if _, err := userProfileService.SearchProfile(elasticsearchPkg.UserProfile{UserId: 1, Location: []float64{11.55, 65.24}}); err != nil {
+		t.Fatalf("error in searching profiles: %v", err)
+	}
+
+	expected := []map[string]interface{}{
+		{"term": map[string]interface{}{"userSearchProfile.paused": true}},
+		{"range": map[string]interface{}{"userSearchProfile.snooze": map[string]interface{}{"gt": "now"}}},
+		{"terms": map[string]interface{}{"user_id": []int{3}}},
+	}
+	if !reflect.DeepEqual(mustNot, expected) {
+		t.Errorf("search leaves out %v, expected %v", mustNot, expected)
+	}
+}
+
+// Likes of a paused user are not shown until they resume.
+func TestGetUserLikesExcludesPaused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mockredis.NewMockLikeDislikeCacheInterface(ctrl)
+	mockSearchProfile := mockdao.NewMockUserSearchProfileRepository(ctrl)
+	mockSwipeDao := mockdao.NewMockUserSwipeRepository(ctrl)
+	mockBlockDao := mockdao.NewMockUserBlockRepository(ctrl)
+
+	mockCache.EXPECT().HasUserLikes("1").Return(true, nil)
+	mockCache.EXPECT().GetUserLikes("1").Return([]int{2, 3, 4}, nil)
+	mockSearchProfile.EXPECT().FindPausedUserIds(gomock.Any(), []int{2, 3, 4}).Return([]int{2}, nil)
+	mockBlockDao.EXPECT().FindBlockedUserIds(1).Return([]int{}, nil)
+	mockSwipeDao.EXPECT().FindSuperLikers(1, []int{3, 4}).Return([]int{}, nil)
+
+	swipeService := &service.SwipeService{
+		LikeDislikeCache:    mockCache,
+		SearchProfileDao:    mockSearchProfile,
+		SwipeDao:            mockSwipeDao,
+		BlockDao:            mockBlockDao,
+		UserMediaRepository: likersMedia{t: t, likers: []int{3, 4}},
+	}
+
+	if _, err := swipeService.GetUserLikes(1); err != nil {
+		t.Fatalf("error in getting user likes: %v", err)
+	}
+}
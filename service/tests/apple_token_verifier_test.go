@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/jwks"
+	"github.com/SuperMatch/service"
+	"github.com/golang-jwt/jwt"
+)
+
+const appleClientID = "com.example.flicker"
+
+func appleIdentityToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "apple-test"
+	signedToken, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("error in signing apple identity token: %v", err)
+	}
+	return signedToken
+}
+
+func appleClaims(nonce string) jwt.MapClaims {
+	sum := sha256.Sum256([]byte(nonce))
+	return jwt.MapClaims{
+		"iss":              "https://appleid.apple.com",
+		"aud":              appleClientID,
+		"sub":              "001234.abcdef",
+		"iat":              time.Now().Unix(),
+		"exp":              time.Now().Add(time.Hour).Unix(),
+		"email":            "abc123@privaterelay.appleid.com",
+		"email_verified":   "true",
+		"is_private_email": "true",
+		"nonce":            hex.EncodeToString(sum[:]),
+	}
+}
+
+func TestAppleTokenVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error in generating rsa key: %v", err)
+	}
+	keySource := jwks.NewStaticKeySource(map[string]interface{}{"apple-test": &key.PublicKey})
+	verifier := service.NewAppleTokenVerifier(keySource, []string{"com.example.web", appleClientID})
+
+	tokenInfo, err := verifier.Verify(appleIdentityToken(t, key, appleClaims("nonce")), "nonce")
+	if err != nil {
+		t.Fatalf("valid apple identity token is rejected: %v", err)
+	}
+	if !tokenInfo.IsPrivateEmail || !tokenInfo.EmailVerified {
+		t.Errorf("private relay email flags are not parsed: %+v", tokenInfo)
+	}
+
+	loginService := &service.LoginService{}
+	user := loginService.GetUserDetailsFromApple(tokenInfo, dto.AppleLoginRequest{FirstName: "John"})
+	if user.SignUpType != "apple" || !user.PrivateEmail || user.FirstName != "John" {
+		t.Errorf("apple user details are wrong: %+v", user)
+	}
+
+	if _, err := verifier.Verify(appleIdentityToken(t, key, appleClaims("nonce")), "other nonce"); err == nil {
+		t.Errorf("apple identity token with a wrong nonce is accepted")
+	}
+
+	wrongAudience := appleClaims("nonce")
+	wrongAudience["aud"] = "com.example.other"
+	if _, err := verifier.Verify(appleIdentityToken(t, key, wrongAudience), ""); err == nil {
+		t.Errorf("apple identity token for another client is accepted")
+	}
+
+	expired := appleClaims("nonce")
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	if _, err := verifier.Verify(appleIdentityToken(t, key, expired), ""); err == nil {
+		t.Errorf("expired apple identity token is accepted")
+	}
+}
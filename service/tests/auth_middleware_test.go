@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/model"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	"github.com/SuperMatch/server/middleware"
+	"github.com/SuperMatch/service"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// newAuthRouter serves /me behind the auth middleware, answering with the user and session of the request.
+func newAuthRouter(authService *service.JWTImpl) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Authenticate(authService))
+	router.GET("/me", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": middleware.GetUserID(c), "session_id": middleware.GetSessionID(c)})
+	})
+	return router
+}
+
+func serveMe(router *gin.Engine, header, value string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthMiddlewareRejectsToken(t *testing.T) {
+	expired, err := service.SigningKeys.Sign(service.AuthClaims{
+		StandardClaims: jwt.StandardClaims{Subject: "test@example.com", ExpiresAt: time.Now().Add(-time.Minute).Unix()},
+		UserID:         8,
+	})
+	if err != nil {
+		t.Fatalf("error in signing expired token: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		body   string
+	}{
+		{"missing", "", "", `{"message":"token is missing"}`},
+		{"not a bearer token", "Authorization", "Basic dXNlcjpwYXNz", `{"message":"token is missing"}`},
+		{"malformed", "token", "not-a-jwt", `{"message":"invalid token"}`},
+		{"expired", "token", expired, `{"message":"invalid token"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// the mocks expect nothing, so a token reaching the database fails the test
+			router := newAuthRouter(&service.JWTImpl{UserDao: mockdao.NewMockUserRepository(ctrl), TokenDao: mockdao.NewMockUserTokenRepository(ctrl)})
+
+			w := serveMe(router, tt.header, tt.value)
+			if w.Code != http.StatusUnauthorized || w.Body.String() != tt.body {
+				t.Errorf("response is %d %s, expected 401 %s", w.Code, w.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+// A token signed out of, or revoked with its session, is rejected although its signature is valid.
+func TestAuthMiddlewareRejectsRevokedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockToken := mockdao.NewMockUserTokenRepository(ctrl)
+	router := newAuthRouter(&service.JWTImpl{UserDao: mockUser, TokenDao: mockToken})
+	token, _, err := (&service.JWTImpl{}).GenerateToken(8, "test@example.com", model.RoleUser)
+	if err != nil {
+		t.Fatalf("error in generating token: %v", err)
+	}
+	mockUser.EXPECT().FindById(8).Return(model.User{Model: gorm.Model{ID: 8}}, nil)
+	mockToken.EXPECT().FindByTokenAndUserId(gomock.Any(), token, 8).Return(model.UserToken{UserId: 8, Token: token, IsActive: false}, nil)
+
+	w := serveMe(router, "token", token)
+	if w.Code != http.StatusUnauthorized || w.Body.String() != `{"message":"invalid token"}` {
+		t.Errorf("response is %d %s, expected 401 invalid token", w.Code, w.Body.String())
+	}
+}
+
+func TestAuthMiddlewareSetsUser(t *testing.T) {
+	token, _, err := (&service.JWTImpl{}).GenerateToken(8, "test@example.com", model.RoleUser)
+	if err != nil {
+		t.Fatalf("error in generating token: %v", err)
+	}
+
+	for _, header := range []struct{ name, value string }{{"token", token}, {"Authorization", "Bearer " + token}} {
+		t.Run(header.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUser := mockdao.NewMockUserRepository(ctrl)
+			mockToken := mockdao.NewMockUserTokenRepository(ctrl)
+			router := newAuthRouter(&service.JWTImpl{UserDao: mockUser, TokenDao: mockToken})
+			mockUser.EXPECT().FindById(8).Return(model.User{Model: gorm.Model{ID: 8}}, nil)
+			mockToken.EXPECT().FindByTokenAndUserId(gomock.Any(), token, 8).Return(model.UserToken{Model: gorm.Model{ID: 3}, UserId: 8, Token: token, IsActive: true, FamilyID: "family"}, nil)
+			mockToken.EXPECT().TouchToken(gomock.Any(), uint(3), gomock.Any()).Return(nil)
+
+			w := serveMe(router, header.name, header.value)
+			if w.Code != http.StatusOK || w.Body.String() != `{"session_id":"family","user_id":8}` {
+				t.Errorf("response is %d %s, expected the user and session of the token", w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SuperMatch/model"
+	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
+	"github.com/SuperMatch/pkg/db/dao"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	mockindex "github.com/SuperMatch/pkg/elasticSeach/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/SuperMatch/service/mocks"
+	"github.com/golang/mock/gomock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestDataExportKeyIsErasedWithTheAccount(t *testing.T) {
+	key := service.DataExportKey(42, 7)
+
+	// account erasure deletes every object under "<user id>/"
+	if !strings.HasPrefix(key, "42/") {
+		t.Errorf("data export key %q is not in the folder of the user", key)
+	}
+	if !strings.HasSuffix(key, "/7.zip") {
+		t.Errorf("data export key %q is not named after the export", key)
+	}
+	if service.DataExportKey(42, 8) == key || service.DataExportKey(43, 7) == key {
+		t.Errorf("data export key %q is not unique", key)
+	}
+}
+
+// The archive holds the data of the user, their stories and their media, but not their earlier exports.
+func TestBuildDataExportArchive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataExport := mockdao.NewMockDataExportRepository(ctrl)
+	mockStoriesIndex := mockindex.NewMockUserStoriesIndexer(ctrl)
+	mockS3 := mocks.NewMockS3ServiceInterface(ctrl)
+	dataExportService := &service.DataExportService{
+		DataExportDao: mockDataExport,
+		StoriesIndex:  mockStoriesIndex,
+		S3Service:     mockS3,
+	}
+	export := model.DataExport{ID: 7, UserId: 42, Status: model.DataExportPending}
+	mockDataExport.EXPECT().FindPending(gomock.Any()).Return([]model.DataExport{export}, nil)
+	mockDataExport.EXPECT().Claim(uint(7)).Return(true, nil)
+	mockDataExport.EXPECT().FindUserData(42).Return(map[string][]map[string]interface{}{"users": {{"ID": 42, "email": "test@example.com"}}}, nil)
+	mockDataExport.EXPECT().FindProfileIds(42).Return([]int{3}, nil)
+	mockStoriesIndex.EXPECT().GetUserStoriesByProfileID(3).Return([]elasticsearchPkg.UserStories{{ID: "s1", UserProfileID: 3}}, nil)
+	gomock.InOrder(
+		mockS3.EXPECT().ListFiles(gomock.Any(), "42/").Return([]string{"42/", "42/photo.jpg", "42/data_export/6.zip"}, nil),
+		mockS3.EXPECT().ListFiles(gomock.Any(), "42/").Return(nil, nil),
+	)
+	mockS3.EXPECT().DownloadFile(gomock.Any(), "42/photo.jpg", gomock.Any()).DoAndReturn(func(_, _ string, w io.Writer) error {
+		_, err := w.Write([]byte("jpeg"))
+		return err
+	})
+
+	var entries []string
+	mockS3.EXPECT().UploadFileToS3(gomock.Any(), "42/data_export/7.zip", gomock.Any(), "7.zip").DoAndReturn(
+		func(_, _ string, file multipart.File, _ string) (string, error) {
+			content, err := io.ReadAll(file)
+			if err != nil {
+				t.Fatalf("error in reading archive: %v", err)
+			}
+			archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			if err != nil {
+				t.Fatalf("archive is not a zip: %v", err)
+			}
+			for _, f := range archive.File {
+				entries = append(entries, f.Name)
+			}
+			return "", nil
+		})
+	mockDataExport.EXPECT().Complete(uint(7), "42/data_export/7.zip", gomock.Any()).Return(nil)
+
+	if built, err := dataExportService.ProcessPendingExports(); err != nil || built != 1 {
+		t.Fatalf("ProcessPendingExports = %d, %v, expected one export built", built, err)
+	}
+
+	sort.Strings(entries)
+	if len(entries) != 3 || entries[0] != "data/user_stories.json" || entries[1] != "data/users.json" ||
+		!strings.HasPrefix(entries[2], "media/") || !strings.HasSuffix(entries[2], "/photo.jpg") {
+		t.Errorf("archive holds %v, expected the data, the stories and the photo", entries)
+	}
+}
+
+// A failed export is retried until it has been attempted dataExportMaxAttempts times.
+func TestDataExportRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataExport := mockdao.NewMockDataExportRepository(ctrl)
+	dataExportService := &service.DataExportService{DataExportDao: mockDataExport}
+	for attempts, retry := range map[int]bool{0: true, 1: true, 2: false} {
+		export := model.DataExport{ID: 7, UserId: 42, Status: model.DataExportPending, Attempts: attempts}
+		mockDataExport.EXPECT().FindPending(gomock.Any()).Return([]model.DataExport{export}, nil)
+		mockDataExport.EXPECT().Claim(uint(7)).Return(true, nil)
+		mockDataExport.EXPECT().FindUserData(42).Return(nil, errors.New("connection reset"))
+		mockDataExport.EXPECT().Fail(uint(7), "connection reset", retry).Return(nil)
+
+		if built, err := dataExportService.ProcessPendingExports(); err != nil || built != 0 {
+			t.Errorf("ProcessPendingExports after %d attempts = %d, %v, expected nothing built", attempts, built, err)
+		}
+	}
+}
+
+// Passwords and tokens are credentials, not data about the user, and are left out of the export.
+func TestFindUserDataOmitsHiddenColumns(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error in creating sql mock: %v", err)
+	}
+	defer sqlDB.Close()
+	orm, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("error in opening gorm: %v", err)
+	}
+
+	sqlMock.MatchExpectationsInOrder(false)
+	sqlMock.ExpectQuery("FROM `user_profile`").WithArgs(42).WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+	sqlMock.ExpectQuery("FROM `users`").WillReturnRows(sqlmock.NewRows([]string{"ID", "email", "password"}).AddRow(42, "test@example.com", "hash"))
+	sqlMock.ExpectQuery("FROM `user_token`").WillReturnRows(sqlmock.NewRows([]string{"ID", "token", "refresh_token", "user_agent"}).AddRow(1, "jwt", "refresh", "curl"))
+	for i := 0; i < 14; i++ {
+		sqlMock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+	}
+
+	data, err := (&dao.DataExportDao{Connection: *orm}).FindUserData(42)
+	if err != nil {
+		t.Fatalf("FindUserData = %v", err)
+	}
+
+	users, tokens := data["users"], data["user_token"]
+	if len(users) != 1 || len(tokens) != 1 {
+		t.Fatalf("exported users %v and tokens %v, expected one row each", users, tokens)
+	}
+	if _, ok := users[0]["password"]; ok || users[0]["email"] != "test@example.com" {
+		t.Errorf("exported user %v, expected the email without the password", users[0])
+	}
+	if _, ok := tokens[0]["token"]; ok {
+		t.Errorf("exported token %v, expected the token to be left out", tokens[0])
+	}
+	if _, ok := tokens[0]["refresh_token"]; ok || tokens[0]["user_agent"] != "curl" {
+		t.Errorf("exported token %v, expected the user agent without the refresh token", tokens[0])
+	}
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("not every table was exported: %v", err)
+	}
+}
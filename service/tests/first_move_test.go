@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
+	"github.com/SuperMatch/pkg/db/dao"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	"github.com/SuperMatch/pkg/redis"
+	"github.com/SuperMatch/service"
+	"github.com/SuperMatch/service/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func profileOf(userID int, gender elasticsearchPkg.Gender, orientation *elasticsearchPkg.SexualOrientation) model.UserProfile {
+	return model.UserProfile{UserId: userID, Gender: &gender, SexualOrientation: orientation}
+}
+
+func TestFirstMoveBy(t *testing.T) {
+	heterosexual := elasticsearchPkg.Heterosexual
+	bisexual := elasticsearchPkg.Bisexual
+
+	tests := []struct {
+		name         string
+		mode         string
+		profile      model.UserProfile
+		matchProfile model.UserProfile
+		expected     int
+	}{
+		{"woman and man", config.FirstMoveWomen, profileOf(1, elasticsearchPkg.FEMALE, nil), profileOf(2, elasticsearchPkg.MALE, &heterosexual), 1},
+		{"man and woman", config.FirstMoveWomen, profileOf(1, elasticsearchPkg.MALE, nil), profileOf(2, elasticsearchPkg.FEMALE, nil), 2},
+		{"two women", config.FirstMoveWomen, profileOf(1, elasticsearchPkg.FEMALE, nil), profileOf(2, elasticsearchPkg.FEMALE, nil), 0},
+		{"binary", config.FirstMoveWomen, profileOf(1, elasticsearchPkg.BINARY, nil), profileOf(2, elasticsearchPkg.FEMALE, nil), 0},
+		{"bisexual", config.FirstMoveWomen, profileOf(1, elasticsearchPkg.FEMALE, &bisexual), profileOf(2, elasticsearchPkg.MALE, nil), 0},
+		{"mode off", config.FirstMoveAnyone, profileOf(1, elasticsearchPkg.FEMALE, nil), profileOf(2, elasticsearchPkg.MALE, nil), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firstMoveBy := service.FirstMoveBy(tt.mode, tt.profile, tt.matchProfile)
+			if tt.expected == 0 && firstMoveBy != nil {
+				t.Errorf("first move by %d, expected either user", *firstMoveBy)
+			} else if tt.expected != 0 && (firstMoveBy == nil || *firstMoveBy != tt.expected) {
+				t.Errorf("first move by %v, expected %d", firstMoveBy, tt.expected)
+			}
+		})
+	}
+}
+
+// Both sides of a new match between a man and a woman keep that the woman moves first.
+func TestNewMatchKeepsFirstMove(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	m.likedBack()
+	swipeService.FirstMoveConfig = config.FirstMoveConfig{Mode: config.FirstMoveWomen}
+
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(profileOf(1, elasticsearchPkg.MALE, nil), nil)
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 2).Return(profileOf(2, elasticsearchPkg.FEMALE, nil), nil)
+	m.cache.EXPECT().RecordSwipe("1", "2", model.SwipeLike).Return(redis.SwipeResult{Matched: true, LikersCached: true}, nil)
+	m.userMatch.EXPECT().InsertPair(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, match model.UserMatch, reverse model.UserMatch) (bool, error) {
+			for _, userMatch := range []model.UserMatch{match, reverse} {
+				if userMatch.FirstMoveBy == nil || *userMatch.FirstMoveBy != 2 {
+					t.Errorf("match row %+v does not have the first move by 2", userMatch)
+				}
+			}
+			return true, nil
+		})
+
+	if _, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike}); err != nil {
+		t.Fatalf("error in swiping: %v", err)
+	}
+}
+
+// The match list only reads the match rows of the user, and shows whose turn it is from their side.
+func TestMatchListTurn(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	swipeService.FirstMoveConfig = config.FirstMoveConfig{Mode: config.FirstMoveWomen}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userMedia := mockdao.NewMockUserMediaRepository(ctrl)
+	s3Service := mocks.NewMockS3ServiceInterface(ctrl)
+	swipeService.UserMediaRepository = userMedia
+	swipeService.S3Service = s3Service
+
+	woman := 2
+	m.userMatch.EXPECT().FindByUserId(gomock.Any(), 1).Return([]model.UserMatch{{UserID: 1, MatchID: 2}, {UserID: 1, MatchID: 3}}, nil)
+	userMedia.EXPECT().FindFirstGroupByUserID(gomock.Any(), 1, []int{2, 3}).Return([]dao.UserMatchUserMediaDTO{
+		{ID: 10, UserId: 1, MatchId: 2, URL: "https://bucket/2/profile/photo.webp", FirstMoveBy: &woman},
+		{ID: 11, UserId: 1, MatchId: 3, URL: "https://bucket/3/profile/photo.webp"},
+	}, nil)
+	s3Service.EXPECT().SignS3FilesUrl(gomock.Any(), gomock.Any()).Return("https://signed", nil).Times(2)
+
+	matches, err := swipeService.GetUserMatchListFromDB(1)
+	if err != nil {
+		t.Fatalf("error in getting match list: %v", err)
+	}
+	if len(matches) != 2 || matches[0].Turn != service.TurnThem || matches[1].Turn != service.TurnAnyone {
+		t.Errorf("match list %+v, expected it to be their turn in the first match and anyone's in the second", matches)
+	}
+}
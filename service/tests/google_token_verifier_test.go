@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/jwks"
+	"github.com/SuperMatch/service"
+	"github.com/golang-jwt/jwt"
+)
+
+const googleClientID = "test-client.apps.googleusercontent.com"
+
+func googleIDToken(t *testing.T, key *rsa.PrivateKey, tokenInfo dto.TokenInfo) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, tokenInfo)
+	token.Header["kid"] = "google-test"
+	signedToken, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("error in signing google id token: %v", err)
+	}
+	return signedToken
+}
+
+func googleTokenInfo() dto.TokenInfo {
+	return dto.TokenInfo{
+		Iss:           "https://accounts.google.com",
+		Sub:           "1234567890",
+		Aud:           googleClientID,
+		Iat:           time.Now().Unix(),
+		Exp:           time.Now().Add(time.Hour).Unix(),
+		Email:         "test@example.com",
+		EmailVerified: true,
+	}
+}
+
+func TestGoogleTokenVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error in generating rsa key: %v", err)
+	}
+	verifier := service.NewGoogleTokenVerifier(jwks.NewStaticKeySource(map[string]interface{}{"google-test": &key.PublicKey}), googleClientID)
+
+	tokenInfo, err := verifier.Verify(googleIDToken(t, key, googleTokenInfo()))
+	if err != nil || tokenInfo.Email != "test@example.com" {
+		t.Errorf("valid google id token is rejected: %v", err)
+	}
+
+	wrongAudience := googleTokenInfo()
+	wrongAudience.Aud = "other-client"
+	expired := googleTokenInfo()
+	expired.Exp = time.Now().Add(-time.Hour).Unix()
+	wrongIssuer := googleTokenInfo()
+	wrongIssuer.Iss = "https://example.com"
+	emailNotVerified := googleTokenInfo()
+	emailNotVerified.EmailVerified = false
+
+	for name, invalid := range map[string]dto.TokenInfo{
+		"wrong audience":     wrongAudience,
+		"expired":            expired,
+		"wrong issuer":       wrongIssuer,
+		"email not verified": emailNotVerified,
+	} {
+		if _, err := verifier.Verify(googleIDToken(t, key, invalid)); err == nil {
+			t.Errorf("google id token with %s is accepted", name)
+		}
+	}
+
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := verifier.Verify(googleIDToken(t, otherKey, googleTokenInfo())); err == nil {
+		t.Errorf("google id token signed with an unknown key is accepted")
+	}
+}
+
+func TestGoogleLoginVerifiesIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error in generating rsa key: %v", err)
+	}
+	loginService := &service.LoginService{
+		GoogleVerifier: service.NewGoogleTokenVerifier(jwks.NewStaticKeySource(map[string]interface{}{"google-test": &key.PublicKey}), googleClientID),
+	}
+
+	tokenInfo, err := loginService.GoogleLogin(googleIDToken(t, key, googleTokenInfo()))
+	if err != nil || tokenInfo.Sub != "1234567890" {
+		t.Errorf("GoogleLogin = %+v, %v, expected the token of the google account", tokenInfo, err)
+	}
+}
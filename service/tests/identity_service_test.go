@@ -0,0 +1,298 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/db/dao"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/SuperMatch/service/mocks"
+	"github.com/golang/mock/gomock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func googleIdentity() model.UserIdentity {
+	return model.UserIdentity{Provider: model.IdentityProviderGoogle, Subject: "google-sub", Email: "test@example.com"}
+}
+
+func TestLinkIdentityRequiresRecentAuthentication(t *testing.T) {
+	identityService := &service.IdentityService{}
+	identity := model.UserIdentity{Provider: model.IdentityProviderPhone, Subject: "+919874563210"}
+
+	for _, authenticatedAt := range []time.Time{{}, time.Now().Add(-time.Hour)} {
+		if _, err := identityService.LinkIdentity(1, authenticatedAt, identity); !errors.Is(err, service.ErrReauthenticationRequired) {
+			t.Errorf("LinkIdentity after signing in at %v = %v, expected ErrReauthenticationRequired", authenticatedAt, err)
+		}
+		if err := identityService.UnlinkIdentity(1, authenticatedAt, 1); !errors.Is(err, service.ErrReauthenticationRequired) {
+			t.Errorf("UnlinkIdentity after signing in at %v = %v, expected ErrReauthenticationRequired", authenticatedAt, err)
+		}
+	}
+}
+
+func TestAccountExistsError(t *testing.T) {
+	err := error(&service.AccountExistsError{Providers: []string{model.IdentityProviderGoogle, model.IdentityProviderPhone}})
+	if !strings.Contains(err.Error(), "google or phone") {
+		t.Errorf("AccountExistsError does not name the sign in methods: %q", err.Error())
+	}
+}
+
+func TestSignInLinkedIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockLogin := mocks.NewMockLoginInterface(ctrl)
+	identityService := &service.IdentityService{
+		UserIdentityDao: mockIdentity,
+		UserDao:         mockUser,
+		LoginService:    mockLogin,
+	}
+	linked := googleIdentity()
+	linked.UserId = 1
+	mockIdentity.EXPECT().FindByProviderSubject(model.IdentityProviderGoogle, "google-sub").Return(linked, nil)
+	account := user()
+	mockUser.EXPECT().FindById(1).Return(account, nil)
+	mockLogin.EXPECT().UserSignIN(account, gomock.Any()).Return(dto.AuthToken{Token: "token"}, nil)
+
+	signedIn, authToken, err := identityService.SignIn(googleIdentity(), model.User{}, dto.SessionDevice{})
+	if err != nil || signedIn.ID != 1 || authToken.Token != "token" {
+		t.Errorf("SignIn = %v, %+v, %v, expected user 1 to be signed in", signedIn.ID, authToken, err)
+	}
+}
+
+// An identity stored before subjects were, under the email, gets its subject on the first sign in.
+func TestSignInUpgradesEmailSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockLogin := mocks.NewMockLoginInterface(ctrl)
+	identityService := &service.IdentityService{
+		UserIdentityDao: mockIdentity,
+		UserDao:         mockUser,
+		LoginService:    mockLogin,
+	}
+	legacy := model.UserIdentity{Model: gorm.Model{ID: 4}, UserId: 1, Provider: model.IdentityProviderGoogle, Subject: "test@example.com", Email: "test@example.com"}
+	gomock.InOrder(
+		mockIdentity.EXPECT().FindByProviderSubject(model.IdentityProviderGoogle, "google-sub").Return(model.UserIdentity{}, gorm.ErrRecordNotFound),
+		mockIdentity.EXPECT().FindByProviderSubject(model.IdentityProviderGoogle, "test@example.com").Return(legacy, nil),
+		mockIdentity.EXPECT().UpdateSubject(uint(4), "google-sub").Return(nil),
+	)
+	mockUser.EXPECT().FindById(1).Return(user(), nil)
+	mockLogin.EXPECT().UserSignIN(gomock.Any(), gomock.Any()).Return(dto.AuthToken{}, nil)
+
+	if signedIn, _, err := identityService.SignIn(googleIdentity(), model.User{}, dto.SessionDevice{}); err != nil || signedIn.ID != 1 {
+		t.Errorf("SignIn = %v, %v, expected the account of the legacy identity", signedIn.ID, err)
+	}
+}
+
+// A new identity signs up a new account, linked to it.
+func TestSignInSignsUpNewIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockLogin := mocks.NewMockLoginInterface(ctrl)
+	identityService := &service.IdentityService{
+		UserIdentityDao: mockIdentity,
+		UserDao:         mockUser,
+		LoginService:    mockLogin,
+	}
+	newUser := model.User{Email: "new@example.com", SignUpType: "social"}
+	mockIdentity.EXPECT().FindByProviderSubject(model.IdentityProviderGoogle, gomock.Any()).Return(model.UserIdentity{}, gorm.ErrRecordNotFound).AnyTimes()
+	mockUser.EXPECT().FindByEmail("new@example.com").Return(model.User{}, gorm.ErrRecordNotFound)
+	created := newUser
+	created.ID = 9
+	mockIdentity.EXPECT().InsertWithUser(newUser, googleIdentity()).Return(created, true, nil)
+	mockLogin.EXPECT().UserSignIN(created, gomock.Any()).Return(dto.AuthToken{}, nil)
+
+	if signedUp, _, err := identityService.SignIn(googleIdentity(), newUser, dto.SessionDevice{}); err != nil || signedUp.ID != 9 {
+		t.Errorf("SignIn = %v, %v, expected the new account", signedUp.ID, err)
+	}
+}
+
+// Of two sign ups with the same new identity at the same moment, the second finds it linked and
+// leaves no account behind.
+func TestSignUpIdentityLinkedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	identityService := &service.IdentityService{
+		UserIdentityDao: mockIdentity,
+		UserDao:         mockUser,
+	}
+	newUser := model.User{Email: "new@example.com", SignUpType: "social"}
+	mockIdentity.EXPECT().FindByProviderSubject(model.IdentityProviderGoogle, gomock.Any()).Return(model.UserIdentity{}, gorm.ErrRecordNotFound).AnyTimes()
+	mockUser.EXPECT().FindByEmail("new@example.com").Return(model.User{}, gorm.ErrRecordNotFound)
+	mockIdentity.EXPECT().InsertWithUser(newUser, googleIdentity()).Return(model.User{}, false, nil)
+
+	if _, _, err := identityService.SignUp(googleIdentity(), newUser, dto.SessionDevice{}); !errors.Is(err, service.ErrIdentityAlreadyLinked) {
+		t.Errorf("SignUp = %v, expected ErrIdentityAlreadyLinked", err)
+	}
+}
+
+// The user is rolled back when its identity turns out to be linked already.
+func TestInsertWithUserRollsBack(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error in creating sql mock: %v", err)
+	}
+	defer sqlDB.Close()
+	orm, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("error in opening gorm: %v", err)
+	}
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("INSERT INTO `users`").WillReturnResult(sqlmock.NewResult(9, 1))
+	sqlMock.ExpectExec("INSERT INTO `user_identity`").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	userIdentityDao := &dao.UserIdentityDao{Connection: *orm}
+	if _, created, err := userIdentityDao.InsertWithUser(model.User{Email: "new@example.com"}, googleIdentity()); err != nil || created {
+		t.Errorf("InsertWithUser = %v, %v, expected nothing created", created, err)
+	}
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("user was not rolled back: %v", err)
+	}
+}
+
+// Signing up with the email of an existing account names the sign in methods of that account.
+func TestSignUpAccountExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	identityService := &service.IdentityService{
+		UserIdentityDao: mockIdentity,
+		UserDao:         mockUser,
+	}
+	phone := model.UserIdentity{Provider: model.IdentityProviderPhone, Subject: "+919874563210"}
+	mockIdentity.EXPECT().FindByProviderSubject(model.IdentityProviderPhone, "+919874563210").Return(model.UserIdentity{}, gorm.ErrRecordNotFound)
+	mockUser.EXPECT().FindByEmail("test@example.com").Return(model.User{}, gorm.ErrRecordNotFound)
+	mockUser.EXPECT().FindByMobile("9874563210").Return(user(), nil)
+	mockIdentity.EXPECT().FindByUserId(1).Return([]model.UserIdentity{{UserId: 1, Provider: model.IdentityProviderGoogle}}, nil)
+
+	_, _, err := identityService.SignUp(phone, model.User{Email: "test@example.com", Mobile: "9874563210"}, dto.SessionDevice{})
+	var accountExists *service.AccountExistsError
+	if !errors.As(err, &accountExists) || !reflect.DeepEqual(accountExists.Providers, []string{model.IdentityProviderGoogle}) {
+		t.Errorf("SignUp = %v, expected AccountExistsError naming google", err)
+	}
+}
+
+func TestLinkIdentity(t *testing.T) {
+	phone := model.UserIdentity{Provider: model.IdentityProviderPhone, Subject: "+919874563210"}
+
+	t.Run("linked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+		identityService := &service.IdentityService{UserIdentityDao: mockIdentity}
+		mockIdentity.EXPECT().FindByProviderSubject(model.IdentityProviderPhone, "+919874563210").Return(model.UserIdentity{}, gorm.ErrRecordNotFound)
+		mockIdentity.EXPECT().FindByUserId(1).Return([]model.UserIdentity{{UserId: 1, Provider: model.IdentityProviderGoogle}}, nil)
+		mockIdentity.EXPECT().Insert(model.UserIdentity{UserId: 1, Provider: model.IdentityProviderPhone, Subject: "+919874563210"}).
+			DoAndReturn(func(identity model.UserIdentity) (model.UserIdentity, error) {
+				identity.ID = 5
+				return identity, nil
+			})
+
+		linked, err := identityService.LinkIdentity(1, time.Now(), phone)
+		if err != nil || linked.ID != 5 || linked.PhoneNumber != "+919874563210" {
+			t.Errorf("LinkIdentity = %+v, %v, expected the phone number to be linked", linked, err)
+		}
+	})
+
+	t.Run("linked to another account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+		identityService := &service.IdentityService{UserIdentityDao: mockIdentity}
+		other := phone
+		other.UserId = 2
+		mockIdentity.EXPECT().FindByProviderSubject(model.IdentityProviderPhone, "+919874563210").Return(other, nil)
+
+		if _, err := identityService.LinkIdentity(1, time.Now(), phone); !errors.Is(err, service.ErrIdentityAlreadyLinked) {
+			t.Errorf("LinkIdentity = %v, expected ErrIdentityAlreadyLinked", err)
+		}
+	})
+
+	t.Run("provider already linked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+		identityService := &service.IdentityService{UserIdentityDao: mockIdentity}
+		mockIdentity.EXPECT().FindByProviderSubject(model.IdentityProviderPhone, "+919874563210").Return(model.UserIdentity{}, gorm.ErrRecordNotFound)
+		mockIdentity.EXPECT().FindByUserId(1).Return([]model.UserIdentity{{UserId: 1, Provider: model.IdentityProviderPhone, Subject: "+911234567890"}}, nil)
+
+		if _, err := identityService.LinkIdentity(1, time.Now(), phone); !errors.Is(err, service.ErrProviderAlreadyLinked) {
+			t.Errorf("LinkIdentity = %v, expected ErrProviderAlreadyLinked", err)
+		}
+	})
+}
+
+func TestUnlinkIdentity(t *testing.T) {
+	password := model.UserIdentity{Model: gorm.Model{ID: 3}, UserId: 1, Provider: model.IdentityProviderPassword, Subject: "test@example.com"}
+	google := model.UserIdentity{Model: gorm.Model{ID: 4}, UserId: 1, Provider: model.IdentityProviderGoogle, Subject: "google-sub"}
+
+	t.Run("last sign in method", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+		identityService := &service.IdentityService{UserIdentityDao: mockIdentity}
+		mockIdentity.EXPECT().FindByUserId(1).Return([]model.UserIdentity{google}, nil)
+
+		if err := identityService.UnlinkIdentity(1, time.Now(), 4); !errors.Is(err, service.ErrLastIdentity) {
+			t.Errorf("UnlinkIdentity = %v, expected ErrLastIdentity", err)
+		}
+	})
+
+	t.Run("password clears the password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+		mockUser := mockdao.NewMockUserRepository(ctrl)
+		identityService := &service.IdentityService{
+			UserIdentityDao: mockIdentity,
+			UserDao:         mockUser,
+		}
+		mockIdentity.EXPECT().FindByUserId(1).Return([]model.UserIdentity{password, google}, nil)
+		mockIdentity.EXPECT().Delete(1, uint(3)).Return(true, nil)
+		mockUser.EXPECT().UpdatePassword(1, "").Return(nil)
+
+		if err := identityService.UnlinkIdentity(1, time.Now(), 3); err != nil {
+			t.Errorf("UnlinkIdentity = %v", err)
+		}
+	})
+
+	t.Run("not the user's", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockIdentity := mockdao.NewMockUserIdentityRepository(ctrl)
+		identityService := &service.IdentityService{UserIdentityDao: mockIdentity}
+		mockIdentity.EXPECT().FindByUserId(1).Return([]model.UserIdentity{password, google}, nil)
+
+		if err := identityService.UnlinkIdentity(1, time.Now(), 8); !errors.Is(err, service.ErrIdentityNotFound) {
+			t.Errorf("UnlinkIdentity = %v, expected ErrIdentityNotFound", err)
+		}
+	})
+}
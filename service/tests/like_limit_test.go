@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/redis"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// The likes left are reported with the next midnight in the time zone of the user.
+func TestLikeLimitRemaining(t *testing.T) {
+	timeZone := "Asia/Kolkata"
+	swipeService, m := newSwipeService(t)
+	swipeService.SwipeConfig = config.SwipeConfig{LikesPerDay: 2}
+	m.cache.EXPECT().GetLikeDislike(gomock.Any()).Return(nil, nil).AnyTimes()
+	m.swipeDao.EXPECT().FindByUserIdTargetId(gomock.Any(), gomock.Any()).Return(model.UserSwipe{}, gorm.ErrRecordNotFound).AnyTimes()
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, TimeZone: &timeZone}, nil)
+	m.rateLimiter.EXPECT().Increment("quota:like:1", gomock.Any()).Return(int64(2), time.Hour, nil)
+	m.swipeDao.EXPECT().Upsert(gomock.Any()).Return(nil)
+	m.cache.EXPECT().RecordSwipe("1", "2", model.SwipeLike).Return(redis.SwipeResult{LikersCached: true}, nil)
+
+	result, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike})
+	if err != nil {
+		t.Fatalf("error in swiping: %v", err)
+	}
+	if result.RemainingLikes == nil || *result.RemainingLikes != 0 {
+		t.Fatalf("remaining likes = %v, expected 0", result.RemainingLikes)
+	}
+
+	location, _ := time.LoadLocation(timeZone)
+	resetAt := result.LikesResetAt.In(location)
+	if resetAt.Hour() != 0 || resetAt.Minute() != 0 || !resetAt.After(time.Now()) || resetAt.Sub(time.Now()) > 24*time.Hour {
+		t.Errorf("likes reset at %v, expected the next midnight in %s", resetAt, timeZone)
+	}
+}
+
+func TestLikeLimitExceeded(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	swipeService.SwipeConfig = config.SwipeConfig{LikesPerDay: 2}
+	m.cache.EXPECT().GetLikeDislike(gomock.Any()).Return(nil, nil).AnyTimes()
+	m.swipeDao.EXPECT().FindByUserIdTargetId(gomock.Any(), gomock.Any()).Return(model.UserSwipe{}, gorm.ErrRecordNotFound).AnyTimes()
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1}, nil)
+	gomock.InOrder(
+		m.rateLimiter.EXPECT().Increment("quota:like:1", gomock.Any()).Return(int64(3), time.Hour, nil),
+		m.rateLimiter.EXPECT().Decrement("quota:like:1").Return(nil),
+	)
+
+	_, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike})
+	var quotaErr *service.QuotaExceededError
+	if !errors.As(err, &quotaErr) || quotaErr.Limit != 2 {
+		t.Errorf("third like of a free user today = %v, expected QuotaExceededError", err)
+	}
+}
+
+// Premium likes are unlimited, so they are not counted.
+func TestPremiumLikesUnlimited(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	swipeService.SwipeConfig = config.SwipeConfig{LikesPerDay: 2}
+	m.cache.EXPECT().GetLikeDislike(gomock.Any()).Return(nil, nil).AnyTimes()
+	m.swipeDao.EXPECT().FindByUserIdTargetId(gomock.Any(), gomock.Any()).Return(model.UserSwipe{}, gorm.ErrRecordNotFound).AnyTimes()
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+	m.swipeDao.EXPECT().Upsert(gomock.Any()).Return(nil)
+	m.cache.EXPECT().RecordSwipe("1", "2", model.SwipeLike).Return(redis.SwipeResult{LikersCached: true}, nil)
+
+	result, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike})
+	if err != nil {
+		t.Fatalf("error in swiping: %v", err)
+	}
+	if result.RemainingLikes != nil {
+		t.Errorf("remaining likes = %d, expected none for unlimited likes", *result.RemainingLikes)
+	}
+}
+
+// A like the ledger failed to record is given back.
+func TestLikeLimitRefundedWhenNotRecorded(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	swipeService.SwipeConfig = config.SwipeConfig{LikesPerDay: 2}
+	m.cache.EXPECT().GetLikeDislike(gomock.Any()).Return(nil, nil).AnyTimes()
+	m.swipeDao.EXPECT().FindByUserIdTargetId(gomock.Any(), gomock.Any()).Return(model.UserSwipe{}, gorm.ErrRecordNotFound).AnyTimes()
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1}, nil)
+	gomock.InOrder(
+		m.rateLimiter.EXPECT().Increment("quota:like:1", gomock.Any()).Return(int64(1), time.Hour, nil),
+		m.swipeDao.EXPECT().Upsert(gomock.Any()).Return(errors.New("connection reset")),
+		m.rateLimiter.EXPECT().Decrement("quota:like:1").Return(nil),
+	)
+
+	if _, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike}); err == nil {
+		t.Error("Swipe succeeded, expected the ledger error")
+	}
+}
+
+// A rewound like no longer counts against the quota.
+func TestLikeLimitRefundedOnUndo(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+	swipe := model.UserSwipe{ID: 7, UserId: 1, TargetId: 2, SwipeType: model.SwipeLike, SwipedAt: time.Now()}
+	m.swipeDao.EXPECT().FindLatestByUserId(1).Return(swipe, nil)
+	m.userMatch.EXPECT().FindByUserIdMatchId(gomock.Any(), 1, 2).Return(model.UserMatch{}, gorm.ErrRecordNotFound)
+	m.swipeDao.EXPECT().Delete(swipe).Return(true, nil)
+	m.cache.EXPECT().RemoveSwipe("1", "2").Return(nil)
+	m.rateLimiter.EXPECT().Decrement("quota:like:1").Return(nil)
+	m.cache.EXPECT().GetLikeDislike("2:1").Return(nil, nil)
+	m.swipeDao.EXPECT().FindByUserIdTargetId(2, 1).Return(model.UserSwipe{}, gorm.ErrRecordNotFound)
+
+	if _, err := swipeService.UndoLastSwipe(1); err != nil {
+		t.Fatalf("error in undoing swipe: %v", err)
+	}
+}
+
+// Likes rejected at the limit are not counted, so a rewound like can be used again.
+func TestLikeLimitAfterRejectedLikes(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	newSwipeCache(t)
+	swipeService.RateLimiter = redis.RateLimiterConstructor()
+	// rewinding is a premium feature, so the premium plan is the one with a like limit here
+	swipeService.SwipeConfig = config.SwipeConfig{LikesPerDay: 1, PremiumLikesPerDay: 1}
+	m.cache.EXPECT().GetLikeDislike(gomock.Any()).Return(nil, nil).AnyTimes()
+	m.swipeDao.EXPECT().FindByUserIdTargetId(gomock.Any(), gomock.Any()).Return(model.UserSwipe{}, gorm.ErrRecordNotFound).AnyTimes()
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil).AnyTimes()
+	m.swipeDao.EXPECT().Upsert(gomock.Any()).Return(nil).Times(2)
+	m.cache.EXPECT().RecordSwipe("1", gomock.Any(), model.SwipeLike).Return(redis.SwipeResult{LikersCached: true}, nil).Times(2)
+
+	if _, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike}); err != nil {
+		t.Fatalf("error in the first like: %v", err)
+	}
+	for retry := 0; retry < 2; retry++ {
+		var quotaErr *service.QuotaExceededError
+		if _, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 3, Type: model.SwipeLike}); !errors.As(err, &quotaErr) {
+			t.Fatalf("like over the limit = %v, expected QuotaExceededError", err)
+		}
+	}
+
+	swipe := model.UserSwipe{ID: 7, UserId: 1, TargetId: 2, SwipeType: model.SwipeLike, SwipedAt: time.Now()}
+	m.swipeDao.EXPECT().FindLatestByUserId(1).Return(swipe, nil)
+	m.userMatch.EXPECT().FindByUserIdMatchId(gomock.Any(), 1, 2).Return(model.UserMatch{}, gorm.ErrRecordNotFound)
+	m.swipeDao.EXPECT().Delete(swipe).Return(true, nil)
+	m.cache.EXPECT().RemoveSwipe("1", "2").Return(nil)
+	if _, err := swipeService.UndoLastSwipe(1); err != nil {
+		t.Fatalf("error in undoing the first like: %v", err)
+	}
+
+	result, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 3, Type: model.SwipeLike})
+	if err != nil {
+		t.Fatalf("like after the rewind = %v, expected it to go through", err)
+	}
+	if result.RemainingLikes == nil || *result.RemainingLikes != 0 {
+		t.Errorf("remaining likes = %v, expected 0", result.RemainingLikes)
+	}
+}
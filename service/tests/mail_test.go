@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SuperMatch/pkg/mail"
+)
+
+func TestRenderMailTemplates(t *testing.T) {
+	templates := map[mail.Template]interface{}{
+		mail.VerifyEmailTemplate:   mail.VerifyEmailData{AppName: "Flicker", Name: "Jane", VerifyURL: "https://example.com/verify?code=1&x=<y>"},
+		mail.PasswordResetTemplate: mail.PasswordResetData{AppName: "Flicker", Name: "Jane", ResetURL: "https://example.com/reset", ExpiresInMinutes: 60},
+		mail.DigestTemplate:        mail.DigestData{AppName: "Flicker", Name: "Jane", NewLikes: 3, NewMatches: 1, UnreadMessages: 2, AppURL: "https://example.com"},
+	}
+
+	for tmpl, data := range templates {
+		message, err := mail.Render(tmpl, "jane@example.com", data)
+		if err != nil {
+			t.Errorf("error in rendering %s v%d: %v", tmpl.Name, tmpl.Version, err)
+			continue
+		}
+
+		if !strings.Contains(message.Subject, "Flicker") || strings.Contains(message.Subject, "\n") {
+			t.Errorf("%s has subject %q", tmpl.Name, message.Subject)
+		}
+		if !strings.Contains(message.Text, "Jane") || !strings.Contains(message.HTML, "Jane") {
+			t.Errorf("%s is missing the name of the user", tmpl.Name)
+		}
+		if strings.Contains(message.HTML, "<y>") {
+			t.Errorf("%s does not escape its HTML body", tmpl.Name)
+		}
+	}
+}
+
+func TestDirMailer(t *testing.T) {
+	dir := t.TempDir()
+	mailer := mail.NewDirMailer(dir, netmail.Address{Name: "Flicker", Address: "no-reply@example.com"})
+
+	_, err := mailer.Send(mail.Message{To: "jane@example.com", Subject: "Grüße", HTML: "<p>hello</p>", Text: "hello"})
+	if err != nil {
+		t.Fatalf("error in sending mail: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("expected one .eml file, found %v", files)
+	}
+
+	file, _ := os.Open(files[0])
+	defer file.Close()
+
+	message, err := netmail.ReadMessage(file)
+	if err != nil {
+		t.Fatalf("error in parsing .eml file: %v", err)
+	}
+
+	subject, _ := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if subject != "Grüße" || message.Header.Get("To") != "jane@example.com" {
+		t.Errorf("unexpected headers %v", message.Header)
+	}
+
+	_, params, _ := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	var contentTypes []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("error in reading mime part: %v", err)
+		}
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+	}
+	if len(contentTypes) != 2 || !strings.HasPrefix(contentTypes[0], "text/plain") || !strings.HasPrefix(contentTypes[1], "text/html") {
+		t.Errorf("unexpected mime parts %v", contentTypes)
+	}
+}
+
+// The weekly digest is one of the emails the mailer sends, next to verification and password reset.
+func TestSendDigest(t *testing.T) {
+	dir := t.TempDir()
+	mailer := mail.NewDirMailer(dir, netmail.Address{Name: "Flicker", Address: "no-reply@example.com"})
+
+	message, err := mail.Render(mail.DigestTemplate, "jane@example.com", mail.DigestData{AppName: "Flicker", Name: "Jane", NewLikes: 3, NewMatches: 1, UnreadMessages: 2, AppURL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("error in rendering digest: %v", err)
+	}
+	for _, count := range []string{"3 new likes", "1 new matches", "2 unread messages"} {
+		if !strings.Contains(message.Text, count) || !strings.Contains(message.HTML, count) {
+			t.Errorf("digest is missing %q", count)
+		}
+	}
+
+	if _, err := mailer.Send(message); err != nil {
+		t.Fatalf("error in sending digest: %v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.eml")); len(files) != 1 {
+		t.Errorf("expected one .eml file, found %v", files)
+	}
+}
@@ -150,7 +150,7 @@ func TestGetUserMatchListFromDB(t *testing.T) {
 	}
 
 	mockUserMedia := mockdao.NewMockUserMediaRepository(ctrl)
-	mockUserMedia.EXPECT().FindFirstGroupByUserID(gomock.Any(), gomock.Eq(matchIDs)).
+	mockUserMedia.EXPECT().FindFirstGroupByUserID(gomock.Any(), gomock.Eq(userID), gomock.Eq(matchIDs)).
 		Return(userMatchUserMedia, nil)
 
 	userMatches := make([]dto.UserMatchDTO, 0)
@@ -0,0 +1,220 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	"github.com/SuperMatch/pkg/redis"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+)
+
+// notifications stands in for the notification service, which has no mock.
+type notifications struct {
+	service.NotificationServiceInterface
+	sent    []int
+	cleared []int
+}
+
+func (n *notifications) SendNotificationToUser(userID int, message model.NotificationData) error {
+	n.sent = append(n.sent, userID)
+	return nil
+}
+
+func (n *notifications) RemoveAllDeviceTokens(userID int) error {
+	n.cleared = append(n.cleared, userID)
+	return nil
+}
+
+// Both sides of a new match expire together at the end of the window.
+func TestNewMatchExpires(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	m.likedBack()
+	swipeService.MatchExpiryConfig = config.MatchExpiryConfig{Window: 72 * time.Hour}
+
+	before := time.Now()
+	m.cache.EXPECT().RecordSwipe("1", "2", model.SwipeLike).Return(redis.SwipeResult{Matched: true, LikersCached: true}, nil)
+	m.userMatch.EXPECT().InsertPair(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, match model.UserMatch, reverse model.UserMatch) (bool, error) {
+			if match.ExpiresAt == nil || reverse.ExpiresAt == nil || !match.ExpiresAt.Equal(*reverse.ExpiresAt) {
+				t.Fatalf("match rows expire at %v and %v, expected the same expiry", match.ExpiresAt, reverse.ExpiresAt)
+			}
+			if match.ExpiresAt.Before(before.Add(72*time.Hour)) || match.ExpiresAt.After(time.Now().Add(72*time.Hour)) {
+				t.Errorf("match expires at %v, expected 72h after it was made", match.ExpiresAt)
+			}
+			return true, nil
+		})
+
+	if _, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike}); err != nil {
+		t.Fatalf("error in swiping: %v", err)
+	}
+}
+
+func TestExtendMatchIsPremium(t *testing.T) {
+	if !service.HasEntitlement(model.UserProfile{IsPremium: true}, service.EntitlementMatchExtension) {
+		t.Errorf("premium users cannot extend matches")
+	}
+	if service.HasEntitlement(model.UserProfile{}, service.EntitlementMatchExtension) {
+		t.Errorf("free users can extend matches")
+	}
+}
+
+func TestExtendMatchOnce(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	t.Run("extended", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserMatch := mockdao.NewMockUserMatchDao(ctrl)
+		mockUserProfile := mockdao.NewMockUserProfileRepository(ctrl)
+		matchExpiryService := &service.MatchExpiryService{
+			UserMatchDao:      mockUserMatch,
+			UserProfileDao:    mockUserProfile,
+			MatchExpiryConfig: config.MatchExpiryConfig{Extension: 24 * time.Hour},
+		}
+		mockUserProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+		mockUserMatch.EXPECT().FindByUserIdMatchId(gomock.Any(), 1, 2).Return(model.UserMatch{UserID: 1, MatchID: 2, ExpiresAt: &expiresAt}, nil)
+		mockUserMatch.EXPECT().Extend(gomock.Any(), 1, 2, 24*time.Hour, gomock.Any()).Return(true, nil)
+
+		extended, err := matchExpiryService.ExtendMatch(1, 2)
+		if err != nil || !extended.Equal(expiresAt.Add(24*time.Hour)) {
+			t.Errorf("ExtendMatch = %v, %v, expected the match to expire a day later", extended, err)
+		}
+	})
+
+	t.Run("extended again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserMatch := mockdao.NewMockUserMatchDao(ctrl)
+		mockUserProfile := mockdao.NewMockUserProfileRepository(ctrl)
+		matchExpiryService := &service.MatchExpiryService{
+			UserMatchDao:      mockUserMatch,
+			UserProfileDao:    mockUserProfile,
+			MatchExpiryConfig: config.MatchExpiryConfig{Extension: 24 * time.Hour},
+		}
+		extendedBy := 2
+		mockUserProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+		mockUserMatch.EXPECT().FindByUserIdMatchId(gomock.Any(), 1, 2).Return(model.UserMatch{UserID: 1, MatchID: 2, ExpiresAt: &expiresAt, ExtendedBy: &extendedBy}, nil)
+
+		if _, err := matchExpiryService.ExtendMatch(1, 2); !errors.Is(err, service.ErrMatchAlreadyExtended) {
+			t.Errorf("ExtendMatch = %v, expected ErrMatchAlreadyExtended", err)
+		}
+	})
+
+	// the other user extended the match between the read and the update
+	t.Run("extended concurrently", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserMatch := mockdao.NewMockUserMatchDao(ctrl)
+		mockUserProfile := mockdao.NewMockUserProfileRepository(ctrl)
+		matchExpiryService := &service.MatchExpiryService{
+			UserMatchDao:      mockUserMatch,
+			UserProfileDao:    mockUserProfile,
+			MatchExpiryConfig: config.MatchExpiryConfig{Extension: 24 * time.Hour},
+		}
+		mockUserProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+		mockUserMatch.EXPECT().FindByUserIdMatchId(gomock.Any(), 1, 2).Return(model.UserMatch{UserID: 1, MatchID: 2, ExpiresAt: &expiresAt}, nil)
+		mockUserMatch.EXPECT().Extend(gomock.Any(), 1, 2, 24*time.Hour, gomock.Any()).Return(false, nil)
+
+		if _, err := matchExpiryService.ExtendMatch(1, 2); !errors.Is(err, service.ErrMatchAlreadyExtended) {
+			t.Errorf("ExtendMatch = %v, expected ErrMatchAlreadyExtended", err)
+		}
+	})
+}
+
+func TestExtendExpiredMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserMatch := mockdao.NewMockUserMatchDao(ctrl)
+	mockUserProfile := mockdao.NewMockUserProfileRepository(ctrl)
+	matchExpiryService := &service.MatchExpiryService{
+		UserMatchDao:      mockUserMatch,
+		UserProfileDao:    mockUserProfile,
+		MatchExpiryConfig: config.MatchExpiryConfig{Extension: 24 * time.Hour},
+	}
+	expiresAt := time.Now().Add(-time.Minute)
+	mockUserProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+	mockUserMatch.EXPECT().FindByUserIdMatchId(gomock.Any(), 1, 2).Return(model.UserMatch{UserID: 1, MatchID: 2, ExpiresAt: &expiresAt}, nil)
+
+	if _, err := matchExpiryService.ExtendMatch(1, 2); !errors.Is(err, service.ErrMatchExpired) {
+		t.Errorf("ExtendMatch = %v, expected ErrMatchExpired", err)
+	}
+}
+
+// Each side of a match is reminded once, also when another worker found the same matches.
+func TestExpiryReminderSentOncePerSide(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserMatch := mockdao.NewMockUserMatchDao(ctrl)
+	notifier := &notifications{}
+	matchExpiryService := &service.MatchExpiryService{
+		UserMatchDao:        mockUserMatch,
+		NotificationService: notifier,
+		MatchExpiryConfig:   config.MatchExpiryConfig{Window: 72 * time.Hour, ReminderBefore: 6 * time.Hour},
+	}
+	expiring := []model.UserMatch{{ID: 1, UserID: 1, MatchID: 2}, {ID: 2, UserID: 2, MatchID: 1}}
+	mockUserMatch.EXPECT().FindExpiringUnnotified(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(expiring, nil).Times(2)
+	mockUserMatch.EXPECT().MarkExpiryNotified(gomock.Any(), 1).Return(true, nil)
+	mockUserMatch.EXPECT().MarkExpiryNotified(gomock.Any(), 2).Return(true, nil)
+	mockUserMatch.EXPECT().MarkExpiryNotified(gomock.Any(), 1).Return(false, nil)
+	mockUserMatch.EXPECT().MarkExpiryNotified(gomock.Any(), 2).Return(false, nil)
+
+	for run, expected := range []int{2, 0} {
+		if reminded, err := matchExpiryService.SendExpiryReminders(); err != nil || reminded != expected {
+			t.Errorf("run %d of SendExpiryReminders = %d, %v, expected %d", run+1, reminded, err, expected)
+		}
+	}
+	if len(notifier.sent) != 2 || notifier.sent[0] != 1 || notifier.sent[1] != 2 {
+		t.Errorf("reminded users %v, expected each side once", notifier.sent)
+	}
+}
+
+// Expired matches are removed in batches until a batch is not full.
+func TestExpireMatchesInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserMatch := mockdao.NewMockUserMatchDao(ctrl)
+	matchExpiryService := &service.MatchExpiryService{UserMatchDao: mockUserMatch}
+	gomock.InOrder(
+		mockUserMatch.EXPECT().ExpireDue(gomock.Any(), gomock.Any(), 100).Return(make([]model.UserMatch, 100), nil),
+		mockUserMatch.EXPECT().ExpireDue(gomock.Any(), gomock.Any(), 100).Return(make([]model.UserMatch, 3), nil),
+	)
+
+	if expired, err := matchExpiryService.ExpireMatches(); err != nil || expired != 103 {
+		t.Errorf("ExpireMatches = %d, %v, expected 103", expired, err)
+	}
+}
+
+// Matches made before matches expired get the configured window, not a fixed one.
+func TestBackfillExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserMatch := mockdao.NewMockUserMatchDao(ctrl)
+	matchExpiryService := &service.MatchExpiryService{
+		UserMatchDao:      mockUserMatch,
+		MatchExpiryConfig: config.MatchExpiryConfig{Window: 72 * time.Hour},
+	}
+	before := time.Now()
+	mockUserMatch.EXPECT().BackfillExpiry(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, expiresAt time.Time) (int64, error) {
+		if expiresAt.Before(before.Add(72*time.Hour)) || expiresAt.After(time.Now().Add(72*time.Hour)) {
+			t.Errorf("matches expire at %v, expected 72h from now", expiresAt)
+		}
+		return 4, nil
+	})
+
+	if backfilled, err := matchExpiryService.BackfillExpiry(); err != nil || backfilled != 4 {
+		t.Errorf("BackfillExpiry = %d, %v, expected 4", backfilled, err)
+	}
+}
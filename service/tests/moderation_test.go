@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// photoReport is an open report by the reporter of photo 5 of user 2.
+func photoReport(id uint, reporterID int) model.UserReport {
+	return model.UserReport{ID: id, ReporterId: reporterID, ReportedId: 2, ContentType: model.ReportContentPhoto, ContentId: "5", Reason: model.ReportReasonSpam, Status: model.ReportOpen}
+}
+
+func TestReportContentValidation(t *testing.T) {
+	moderationService := service.ModerationService{}
+
+	tests := []struct {
+		name   string
+		report dto.CreateReport
+	}{
+		{"unknown content", dto.CreateReport{ContentType: "comment", ContentID: "1", Reason: model.ReportReasonSpam}},
+		{"unknown reason", dto.CreateReport{ContentType: model.ReportContentProfile, ContentID: "1", Reason: "rude"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := moderationService.ReportContent(2, tt.report); !errors.Is(err, service.ErrInvalidReport) {
+				t.Errorf("ReportContent = %v, expected ErrInvalidReport", err)
+			}
+		})
+	}
+}
+
+func TestGetReportsInvalidStatus(t *testing.T) {
+	moderationService := service.ModerationService{}
+
+	if _, err := moderationService.GetReports("closed", 0); !errors.Is(err, service.ErrInvalidReportQueueStatus) {
+		t.Errorf("GetReports = %v, expected ErrInvalidReportQueueStatus", err)
+	}
+}
+
+func TestIsValidModerationAction(t *testing.T) {
+	tests := []struct {
+		action      string
+		contentType string
+		valid       bool
+	}{
+		{model.ModerationDismiss, model.ReportContentMessage, true},
+		{model.ModerationSuspendAccount, model.ReportContentProfile, true},
+		{model.ModerationHideMedia, model.ReportContentPhoto, true},
+		{model.ModerationHideMedia, model.ReportContentStory, false},
+		{model.ModerationDeleteStory, model.ReportContentStory, true},
+		{model.ModerationDeleteEvent, model.ReportContentNudge, false},
+		{"ban", model.ReportContentProfile, false},
+	}
+
+	for _, tt := range tests {
+		if valid := model.IsValidModerationAction(tt.action, tt.contentType); valid != tt.valid {
+			t.Errorf("IsValidModerationAction(%q, %q) = %v, expected %v", tt.action, tt.contentType, valid, tt.valid)
+		}
+	}
+}
+
+// Hiding a photo closes every open report of it and tells each reporter.
+func TestResolveReportClosesReportsOfContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReport := mockdao.NewMockUserReportRepository(ctrl)
+	mockUserMedia := mockdao.NewMockUserMediaRepository(ctrl)
+	notifier := &notifications{}
+	moderationService := &service.ModerationService{
+		ReportDao:           mockReport,
+		UserMedia:           mockUserMedia,
+		NotificationService: notifier,
+	}
+	resolved := photoReport(1, 3)
+	resolved.Status = model.ReportActioned
+	gomock.InOrder(
+		mockReport.EXPECT().FindById(uint(1)).Return(photoReport(1, 3), nil),
+		mockUserMedia.EXPECT().DeleteById(gomock.Any(), 5, 2).Return(nil),
+		mockReport.EXPECT().FindOpenByContent(model.ReportContentPhoto, "5").Return([]model.UserReport{photoReport(1, 3), photoReport(2, 4)}, nil),
+		mockReport.EXPECT().Resolve([]uint{1, 2}, model.ReportActioned, model.ModerationHideMedia, 9, "nudity").Return([]uint{1, 2}, nil),
+		mockReport.EXPECT().FindById(uint(1)).Return(resolved, nil),
+	)
+
+	report, err := moderationService.ResolveReport(9, 1, dto.ResolveReport{Action: model.ModerationHideMedia, Note: "nudity"})
+	if err != nil || report.Status != model.ReportActioned {
+		t.Fatalf("ResolveReport = %+v, %v, expected the report to be actioned", report, err)
+	}
+	if !reflect.DeepEqual(notifier.sent, []int{3, 4}) {
+		t.Errorf("notified reporters %v, expected both reporters of the photo", notifier.sent)
+	}
+}
+
+// A report another moderator resolved at the same time is not resolved twice, and nobody is notified again.
+func TestResolveReportConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReport := mockdao.NewMockUserReportRepository(ctrl)
+	notifier := &notifications{}
+	moderationService := &service.ModerationService{
+		ReportDao:           mockReport,
+		NotificationService: notifier,
+	}
+	mockReport.EXPECT().FindById(uint(1)).Return(photoReport(1, 3), nil)
+	mockReport.EXPECT().Resolve([]uint{1}, model.ReportDismissed, model.ModerationDismiss, 9, "").Return([]uint{}, nil)
+
+	if _, err := moderationService.ResolveReport(9, 1, dto.ResolveReport{Action: model.ModerationDismiss}); !errors.Is(err, service.ErrReportResolved) {
+		t.Errorf("ResolveReport = %v, expected ErrReportResolved", err)
+	}
+	if len(notifier.sent) != 0 {
+		t.Errorf("notified reporters %v, expected nobody", notifier.sent)
+	}
+}
+
+func TestResolveResolvedReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReport := mockdao.NewMockUserReportRepository(ctrl)
+	moderationService := &service.ModerationService{ReportDao: mockReport}
+	report := photoReport(1, 3)
+	report.Status = model.ReportDismissed
+	mockReport.EXPECT().FindById(uint(1)).Return(report, nil)
+
+	if _, err := moderationService.ResolveReport(9, 1, dto.ResolveReport{Action: model.ModerationHideMedia}); !errors.Is(err, service.ErrReportResolved) {
+		t.Errorf("ResolveReport = %v, expected ErrReportResolved", err)
+	}
+}
+
+// Suspending an account deactivates it, signs it out everywhere and hides its profile.
+func TestResolveReportSuspendsAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReport := mockdao.NewMockUserReportRepository(ctrl)
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockUserProfile := mockdao.NewMockUserProfileRepository(ctrl)
+	mockUserSearchProfile := mockdao.NewMockUserSearchProfileRepository(ctrl)
+	revoker := &sessions{}
+	notifier := &notifications{}
+	moderationService := &service.ModerationService{
+		ReportDao:            mockReport,
+		UserDao:              mockUser,
+		UserProfileDao:       mockUserProfile,
+		UserSearchProfileDao: mockUserSearchProfile,
+		SessionService:       revoker,
+		NotificationService:  notifier,
+	}
+	report := model.UserReport{ID: 1, ReporterId: 3, ReportedId: 2, ContentType: model.ReportContentProfile, ContentId: "2", Reason: model.ReportReasonSpam, Status: model.ReportOpen}
+	mockReport.EXPECT().FindById(uint(1)).Return(report, nil).Times(2)
+	gomock.InOrder(
+		mockUser.EXPECT().Suspend(2).Return(true, nil),
+		mockUserProfile.EXPECT().FindByUserId(gomock.Any(), 2).Return(model.UserProfile{Model: gorm.Model{ID: 7}, UserId: 2}, nil),
+		mockUserSearchProfile.EXPECT().UpdatePause(gomock.Any(), 7, true, nil).Return(nil),
+		mockReport.EXPECT().FindOpenByContent(model.ReportContentProfile, "2").Return([]model.UserReport{report}, nil),
+		mockReport.EXPECT().Resolve([]uint{1}, model.ReportActioned, model.ModerationSuspendAccount, 9, "").Return([]uint{1}, nil),
+	)
+
+	if _, err := moderationService.ResolveReport(9, 1, dto.ResolveReport{Action: model.ModerationSuspendAccount}); err != nil {
+		t.Fatalf("ResolveReport = %v", err)
+	}
+	if !reflect.DeepEqual(revoker.revoked, []int{2}) {
+		t.Errorf("revoked the sessions of %v, expected the suspended user", revoker.revoked)
+	}
+	if !reflect.DeepEqual(notifier.sent, []int{3}) {
+		t.Errorf("notified reporters %v, expected the reporter", notifier.sent)
+	}
+}
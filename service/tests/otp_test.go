@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/model"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	mockredis "github.com/SuperMatch/pkg/redis/mocks"
+	mocksms "github.com/SuperMatch/pkg/sms/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const otpPhone = "+919874563210"
+
+func sentOTP(t *testing.T, code string) model.UserVerificationOTP {
+	hashedOTP, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("error in hashing otp: %v", err)
+	}
+	return model.UserVerificationOTP{
+		Model:       gorm.Model{ID: 7},
+		PhoneNumber: otpPhone,
+		OTP:         string(hashedOTP),
+		ExpiresAt:   time.Now().Add(10 * time.Minute),
+	}
+}
+
+// The code texted to the user is the one stored, hashed, and it replaces the codes sent before.
+func TestSendOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+	mockSMS := mocksms.NewMockSMSSender(ctrl)
+	mockOTP := mockdao.NewMockUserVerificationOTPRepository(ctrl)
+	loginService := &service.LoginService{
+		RateLimiter: mockRateLimiter,
+		SMSSender:   mockSMS,
+		OTPDao:      mockOTP,
+	}
+
+	mockRateLimiter.EXPECT().Count("otp:failed:"+otpPhone).Return(int64(0), time.Duration(0), nil)
+	mockRateLimiter.EXPECT().Increment("otp:resend:"+otpPhone, 30*time.Second).Return(int64(1), 30*time.Second, nil)
+	mockRateLimiter.EXPECT().Increment("otp:send:phone:"+otpPhone, time.Hour).Return(int64(1), time.Hour, nil)
+	mockRateLimiter.EXPECT().Increment("otp:send:ip:10.0.0.1", time.Hour).Return(int64(1), time.Hour, nil)
+
+	var code string
+	mockSMS.EXPECT().Send(otpPhone, gomock.Any()).DoAndReturn(func(_ string, body string) (string, error) {
+		code = regexp.MustCompile(`\d{6}`).FindString(body)
+		return "SM1", nil
+	})
+	gomock.InOrder(
+		mockOTP.EXPECT().ConsumeAllByPhone(otpPhone).Return(nil),
+		mockOTP.EXPECT().Insert(gomock.Any()).DoAndReturn(func(userOtp model.UserVerificationOTP) error {
+			if code == "" || bcrypt.CompareHashAndPassword([]byte(userOtp.OTP), []byte(code)) != nil {
+				t.Errorf("stored otp does not match the code %q that was texted", code)
+			}
+			return nil
+		}),
+	)
+
+	messageID, err := loginService.SendOTPService(otpPhone, "10.0.0.1")
+	if err != nil || messageID != "SM1" {
+		t.Fatalf("SendOTPService = %q, %v, expected the message id", messageID, err)
+	}
+}
+
+func TestSendOTPThrottled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+	loginService := &service.LoginService{RateLimiter: mockRateLimiter}
+
+	mockRateLimiter.EXPECT().Count("otp:failed:"+otpPhone).Return(int64(0), time.Duration(0), nil)
+	mockRateLimiter.EXPECT().Increment("otp:resend:"+otpPhone, 30*time.Second).Return(int64(2), 20*time.Second, nil)
+	mockRateLimiter.EXPECT().Decrement("otp:resend:" + otpPhone).Return(nil)
+
+	_, err := loginService.SendOTPService(otpPhone, "10.0.0.1")
+	var rateLimitErr *service.RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.Locked || rateLimitErr.RetryAfter != 20*time.Second {
+		t.Errorf("SendOTPService = %v, expected to wait 20s before resending", err)
+	}
+}
+
+// A code not sent because the address sent too many does not use up the limits of the phone number.
+func TestSendOTPThrottledByIP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+	loginService := &service.LoginService{RateLimiter: mockRateLimiter}
+
+	mockRateLimiter.EXPECT().Count("otp:failed:"+otpPhone).Return(int64(0), time.Duration(0), nil)
+	gomock.InOrder(
+		mockRateLimiter.EXPECT().Increment("otp:resend:"+otpPhone, 30*time.Second).Return(int64(1), 30*time.Second, nil),
+		mockRateLimiter.EXPECT().Increment("otp:send:phone:"+otpPhone, time.Hour).Return(int64(1), time.Hour, nil),
+		mockRateLimiter.EXPECT().Increment("otp:send:ip:10.0.0.1", time.Hour).Return(int64(21), 40*time.Minute, nil),
+	)
+	mockRateLimiter.EXPECT().Decrement("otp:resend:" + otpPhone).Return(nil)
+	mockRateLimiter.EXPECT().Decrement("otp:send:phone:" + otpPhone).Return(nil)
+	mockRateLimiter.EXPECT().Decrement("otp:send:ip:10.0.0.1").Return(nil)
+
+	_, err := loginService.SendOTPService(otpPhone, "10.0.0.1")
+	var rateLimitErr *service.RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter != 40*time.Minute {
+		t.Errorf("SendOTPService = %v, expected to wait 40m before sending from the address", err)
+	}
+}
+
+func TestSendOTPLockedOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+	loginService := &service.LoginService{RateLimiter: mockRateLimiter}
+
+	mockRateLimiter.EXPECT().Count("otp:failed:"+otpPhone).Return(int64(5), 10*time.Minute, nil)
+
+	_, err := loginService.SendOTPService(otpPhone, "10.0.0.1")
+	var rateLimitErr *service.RateLimitError
+	if !errors.As(err, &rateLimitErr) || !rateLimitErr.Locked {
+		t.Errorf("SendOTPService = %v, expected the phone number to be locked out", err)
+	}
+}
+
+// The failed attempt that reaches the limit locks the phone number out and burns its code.
+func TestVerifyOTPLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+	mockOTP := mockdao.NewMockUserVerificationOTPRepository(ctrl)
+	loginService := &service.LoginService{
+		RateLimiter: mockRateLimiter,
+		OTPDao:      mockOTP,
+	}
+
+	mockRateLimiter.EXPECT().Count("otp:failed:"+otpPhone).Return(int64(4), 30*time.Minute, nil)
+	mockRateLimiter.EXPECT().Increment("otp:verify:ip:10.0.0.1", time.Hour).Return(int64(1), time.Hour, nil)
+	mockOTP.EXPECT().FindLatestByPhone(otpPhone).Return(sentOTP(t, "123456"), nil)
+	mockRateLimiter.EXPECT().Increment("otp:failed:"+otpPhone, 30*time.Minute).Return(int64(5), 30*time.Minute, nil)
+	mockOTP.EXPECT().ConsumeAllByPhone(otpPhone).Return(nil)
+
+	verified, err := loginService.VerifyOTPService(otpPhone, "654321", "10.0.0.1")
+	var rateLimitErr *service.RateLimitError
+	if verified || !errors.As(err, &rateLimitErr) || !rateLimitErr.Locked {
+		t.Errorf("VerifyOTPService = %v, %v, expected the phone number to be locked out", verified, err)
+	}
+}
+
+func TestVerifyOTPSingleUse(t *testing.T) {
+	otp := sentOTP(t, "123456")
+
+	t.Run("first use", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+		mockOTP := mockdao.NewMockUserVerificationOTPRepository(ctrl)
+		loginService := &service.LoginService{
+			RateLimiter: mockRateLimiter,
+			OTPDao:      mockOTP,
+		}
+		mockRateLimiter.EXPECT().Count("otp:failed:"+otpPhone).Return(int64(1), 30*time.Minute, nil)
+		mockRateLimiter.EXPECT().Increment("otp:verify:ip:10.0.0.1", time.Hour).Return(int64(1), time.Hour, nil)
+		mockOTP.EXPECT().FindLatestByPhone(otpPhone).Return(otp, nil)
+		mockOTP.EXPECT().Consume(otp.ID).Return(true, nil)
+		mockRateLimiter.EXPECT().Reset("otp:failed:" + otpPhone).Return(nil)
+
+		if verified, err := loginService.VerifyOTPService(otpPhone, "123456", "10.0.0.1"); !verified || err != nil {
+			t.Errorf("VerifyOTPService = %v, %v, expected the code to verify", verified, err)
+		}
+	})
+
+	t.Run("used again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+		mockOTP := mockdao.NewMockUserVerificationOTPRepository(ctrl)
+		loginService := &service.LoginService{
+			RateLimiter: mockRateLimiter,
+			OTPDao:      mockOTP,
+		}
+		mockRateLimiter.EXPECT().Count("otp:failed:"+otpPhone).Return(int64(0), time.Duration(0), nil)
+		mockRateLimiter.EXPECT().Increment("otp:verify:ip:10.0.0.1", time.Hour).Return(int64(2), time.Hour, nil)
+		mockOTP.EXPECT().FindLatestByPhone(otpPhone).Return(otp, nil)
+		mockOTP.EXPECT().Consume(otp.ID).Return(false, nil)
+
+		if verified, err := loginService.VerifyOTPService(otpPhone, "123456", "10.0.0.1"); verified || err == nil {
+			t.Errorf("VerifyOTPService = %v, %v, expected a used code to be rejected", verified, err)
+		}
+	})
+}
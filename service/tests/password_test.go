@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	"github.com/SuperMatch/pkg/mail"
+	mockmail "github.com/SuperMatch/pkg/mail/mocks"
+	mockredis "github.com/SuperMatch/pkg/redis/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestValidatePasswordStrength(t *testing.T) {
+	valid := []string{"correct horse 9", "s3cretPass", strings.Repeat("a1", 36)}
+	for _, password := range valid {
+		if err := service.ValidatePasswordStrength(password, "jane@example.com"); err != nil {
+			t.Errorf("ValidatePasswordStrength(%q) = %v, expected nil", password, err)
+		}
+	}
+
+	weak := map[string]string{
+		"short1":                 "jane@example.com",
+		"onlyletters":            "jane@example.com",
+		"1234567890":             "jane@example.com",
+		strings.Repeat("a1", 37): "jane@example.com",
+		"Password1":              "jane@example.com",
+		"Jane@Example.com1":      "jane@example.com",
+		"jane1234":               "jane1234@example.com",
+	}
+	for password, email := range weak {
+		if err := service.ValidatePasswordStrength(password, email); !errors.Is(err, service.ErrWeakPassword) {
+			t.Errorf("ValidatePasswordStrength(%q) = %v, expected ErrWeakPassword", password, err)
+		}
+	}
+}
+
+// The emailed link opens the reset page of the app with a token that matches the stored reset.
+func TestForgotPasswordLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mailConfig := config.ConfigValue.MailConfig
+	config.ConfigValue.MailConfig.PasswordResetURL = "pluto://reset-password?source=email"
+	t.Cleanup(func() { config.ConfigValue.MailConfig = mailConfig })
+
+	rateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+	userIdentityDao := mockdao.NewMockUserIdentityRepository(ctrl)
+	userDao := mockdao.NewMockUserRepository(ctrl)
+	passwordResetDao := mockdao.NewMockPasswordResetRepository(ctrl)
+	mailer := mockmail.NewMockMailer(ctrl)
+	loginService := &service.LoginService{
+		RateLimiter:      rateLimiter,
+		Mailer:           mailer,
+		UserDao:          userDao,
+		UserIdentityDao:  userIdentityDao,
+		PasswordResetDao: passwordResetDao,
+	}
+
+	rateLimiter.EXPECT().Increment("password:reset:jane@example.com", time.Hour).Return(int64(1), time.Hour, nil)
+	userIdentityDao.EXPECT().FindByProviderSubject(model.IdentityProviderPassword, "jane@example.com").Return(model.UserIdentity{UserId: 3}, nil)
+	userDao.EXPECT().FindById(3).Return(model.User{Model: gorm.Model{ID: 3}, FirstName: "Jane", Email: "jane@example.com"}, nil)
+
+	var tokenHash string
+	gomock.InOrder(
+		passwordResetDao.EXPECT().ConsumeAllByUserId(3).Return(nil),
+		passwordResetDao.EXPECT().Insert(gomock.Any()).DoAndReturn(func(passwordReset model.PasswordReset) error {
+			tokenHash = passwordReset.TokenHash
+			return nil
+		}),
+	)
+	mailer.EXPECT().Send(gomock.Any()).DoAndReturn(func(message mail.Message) (string, error) {
+		start := strings.Index(message.Text, "pluto://reset-password?")
+		if start < 0 {
+			t.Fatalf("password reset email does not link to the reset page: %s", message.Text)
+		}
+		link, err := url.Parse(strings.Fields(message.Text[start:])[0])
+		if err != nil {
+			t.Fatalf("error in parsing password reset link: %v", err)
+		}
+
+		sum := sha256.Sum256([]byte(link.Query().Get("token")))
+		if link.Query().Get("source") != "email" || hex.EncodeToString(sum[:]) != tokenHash {
+			t.Errorf("password reset link %s does not carry the stored reset token", link)
+		}
+		return "message-id", nil
+	})
+
+	if err := loginService.ForgotPassword("Jane@example.com "); err != nil {
+		t.Fatalf("error in sending password reset email: %v", err)
+	}
+}
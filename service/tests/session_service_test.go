@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SuperMatch/model"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// platformEndpoints stands in for SNS, recording the endpoints deleted and failing for those in failing.
+type platformEndpoints struct {
+	snsiface.SNSAPI
+	deleted []string
+	failing map[string]bool
+}
+
+func (p *platformEndpoints) DeleteEndpoint(input *sns.DeleteEndpointInput) (*sns.DeleteEndpointOutput, error) {
+	if p.failing[*input.EndpointArn] {
+		return nil, errors.New("endpoint not found")
+	}
+	p.deleted = append(p.deleted, *input.EndpointArn)
+	return &sns.DeleteEndpointOutput{}, nil
+}
+
+func deviceToken(id uint, endpointARN string) model.UserDeviceToken {
+	return model.UserDeviceToken{Model: gorm.Model{ID: id}, UserID: 1, EndpointARN: endpointARN, SessionID: "family-1"}
+}
+
+func TestGetActiveSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockToken := mockdao.NewMockUserTokenRepository(ctrl)
+	sessionService := &service.SessionService{UserTokenDao: mockToken}
+	mockToken.EXPECT().FindByUserId(gomock.Any(), 1).Return([]model.UserToken{
+		{UserId: 1, FamilyID: "family-1", UserAgent: "iPhone"},
+		{UserId: 1, FamilyID: "family-2", UserAgent: "Android"},
+	}, nil)
+
+	sessions, err := sessionService.GetActiveSessions(1, "family-2")
+	if err != nil {
+		t.Fatalf("GetActiveSessions = %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].SessionID != "family-1" || sessions[0].Current || !sessions[1].Current {
+		t.Errorf("sessions are %+v, expected the second one to be current", sessions)
+	}
+}
+
+// Revoking a session logs it out and unregisters the push tokens of its device only.
+func TestRevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockToken := mockdao.NewMockUserTokenRepository(ctrl)
+	mockDeviceToken := mockdao.NewMockUserDeviceTokenDao(ctrl)
+	endpoints := &platformEndpoints{}
+	sessionService := &service.SessionService{
+		UserTokenDao: mockToken,
+		NotificationService: &service.NotificationService{
+			UserDeviceTokenDao: mockDeviceToken,
+			SNS:                endpoints,
+		},
+	}
+	gomock.InOrder(
+		mockToken.EXPECT().RemoveSessionByFamily(gomock.Any(), 1, "family-1").Return(true, nil),
+		mockDeviceToken.EXPECT().GetDeviceTokensBySessionId(1, "family-1").Return([]model.UserDeviceToken{deviceToken(5, "arn:5"), deviceToken(6, "arn:6")}, nil),
+		mockDeviceToken.EXPECT().Delete([]uint{5, 6}).Return(nil),
+	)
+
+	if err := sessionService.RevokeSession(1, "family-1"); err != nil {
+		t.Fatalf("RevokeSession = %v", err)
+	}
+	if !reflect.DeepEqual(endpoints.deleted, []string{"arn:5", "arn:6"}) {
+		t.Errorf("deleted platform endpoints %v, expected those of the session", endpoints.deleted)
+	}
+}
+
+func TestRevokeSessionNotFound(t *testing.T) {
+	t.Run("no session id", func(t *testing.T) {
+		sessionService := &service.SessionService{}
+
+		if err := sessionService.RevokeSession(1, ""); !errors.Is(err, service.ErrSessionNotFound) {
+			t.Errorf("RevokeSession = %v, expected ErrSessionNotFound", err)
+		}
+	})
+
+	// the session is not the user's, or was revoked already
+	t.Run("no session revoked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockToken := mockdao.NewMockUserTokenRepository(ctrl)
+		sessionService := &service.SessionService{UserTokenDao: mockToken}
+		mockToken.EXPECT().RemoveSessionByFamily(gomock.Any(), 1, "family-2").Return(false, nil)
+
+		if err := sessionService.RevokeSession(1, "family-2"); !errors.Is(err, service.ErrSessionNotFound) {
+			t.Errorf("RevokeSession = %v, expected ErrSessionNotFound", err)
+		}
+	})
+}
+
+// Revoking all sessions logs the user out everywhere and unregisters every push token, also when SNS
+// fails to delete an endpoint.
+func TestRevokeAllSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockToken := mockdao.NewMockUserTokenRepository(ctrl)
+	mockDeviceToken := mockdao.NewMockUserDeviceTokenDao(ctrl)
+	endpoints := &platformEndpoints{}
+	sessionService := &service.SessionService{
+		UserTokenDao: mockToken,
+		NotificationService: &service.NotificationService{
+			UserDeviceTokenDao: mockDeviceToken,
+			SNS:                endpoints,
+		},
+	}
+	endpoints.failing = map[string]bool{"arn:5": true}
+	gomock.InOrder(
+		mockToken.EXPECT().RemoveSession(gomock.Any(), int64(1)).Return(nil),
+		mockDeviceToken.EXPECT().GetDeviceTokensByUserId(1).Return([]model.UserDeviceToken{deviceToken(5, "arn:5"), deviceToken(6, "arn:6")}, nil),
+		mockDeviceToken.EXPECT().Delete([]uint{5, 6}).Return(nil),
+	)
+
+	if err := sessionService.RevokeAllSessions(1); err != nil {
+		t.Fatalf("RevokeAllSessions = %v", err)
+	}
+	if !reflect.DeepEqual(endpoints.deleted, []string{"arn:6"}) {
+		t.Errorf("deleted platform endpoints %v, expected every endpoint but the failing one", endpoints.deleted)
+	}
+}
+
+func TestRevokeAllSessionsWithoutDevices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockToken := mockdao.NewMockUserTokenRepository(ctrl)
+	mockDeviceToken := mockdao.NewMockUserDeviceTokenDao(ctrl)
+	endpoints := &platformEndpoints{}
+	sessionService := &service.SessionService{
+		UserTokenDao: mockToken,
+		NotificationService: &service.NotificationService{
+			UserDeviceTokenDao: mockDeviceToken,
+			SNS:                endpoints,
+		},
+	}
+	mockToken.EXPECT().RemoveSession(gomock.Any(), int64(1)).Return(nil)
+	mockDeviceToken.EXPECT().GetDeviceTokensByUserId(1).Return(nil, nil)
+
+	if err := sessionService.RevokeAllSessions(1); err != nil {
+		t.Fatalf("RevokeAllSessions = %v", err)
+	}
+	if len(endpoints.deleted) != 0 {
+		t.Errorf("deleted platform endpoints %v, expected none", endpoints.deleted)
+	}
+}
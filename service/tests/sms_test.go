@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SuperMatch/pkg/sms"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	valid := map[string]string{
+		"+91 98745 63210":   "+919874563210",
+		"+1 (415) 555-2671": "+14155552671",
+		"0044 20 7946 0958": "+442079460958",
+	}
+	for input, expected := range valid {
+		phoneNumber, err := sms.ParsePhoneNumber(input)
+		if err != nil || phoneNumber != expected {
+			t.Errorf("ParsePhoneNumber(%q) = %q, %v, expected %q", input, phoneNumber, err, expected)
+		}
+	}
+
+	invalid := []string{"9874563210", "+0123456789", "+91987456321098765", "+91 98745x63210", "+12"}
+	for _, input := range invalid {
+		if _, err := sms.ParsePhoneNumber(input); err == nil {
+			t.Errorf("ParsePhoneNumber(%q) accepted an invalid number", input)
+		}
+	}
+}
+
+func TestRenderOTPMessage(t *testing.T) {
+	body, err := sms.RenderOTPMessage(sms.OTPMessage{AppName: "Flicker", Code: "123456", ExpiresInMinutes: 10})
+	if err != nil {
+		t.Errorf("error in rendering otp message: %v", err)
+	}
+
+	if !strings.Contains(body, "Flicker") || !strings.Contains(body, "123456") || !strings.Contains(body, "10 minutes") {
+		t.Errorf("otp message is missing details %q", body)
+	}
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/redis"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestSuperLikeQuota(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	swipeService.SwipeConfig = config.SwipeConfig{SuperLikesPerDay: 1, PremiumSuperLikesPerDay: 5}
+	m.cache.EXPECT().GetLikeDislike(gomock.Any()).Return(nil, nil).AnyTimes()
+	m.swipeDao.EXPECT().FindByUserIdTargetId(1, 2).Return(model.UserSwipe{}, gorm.ErrRecordNotFound)
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: false}, nil)
+	m.rateLimiter.EXPECT().Increment("quota:superlike:1", gomock.Any()).Return(int64(2), time.Hour, nil)
+	m.rateLimiter.EXPECT().Decrement("quota:superlike:1").Return(nil)
+	m.swipeDao.EXPECT().FindByUserIdTargetId(2, 1).Return(model.UserSwipe{}, gorm.ErrRecordNotFound)
+
+	_, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeSuperLike})
+	var quotaErr *service.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("second super like of a free user today = %v, expected QuotaExceededError", err)
+	}
+	if quotaErr.Limit != 1 || !quotaErr.ResetAt.After(time.Now()) {
+		t.Errorf("quota error %+v", quotaErr)
+	}
+}
+
+func TestSuperLikeMatchType(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	swipeService.SwipeConfig = config.SwipeConfig{SuperLikesPerDay: 1, PremiumSuperLikesPerDay: 5}
+	m.cache.EXPECT().GetLikeDislike(gomock.Any()).Return(nil, nil).AnyTimes()
+	m.swipeDao.EXPECT().FindByUserIdTargetId(1, 2).Return(model.UserSwipe{}, gorm.ErrRecordNotFound)
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+	m.rateLimiter.EXPECT().Increment("quota:superlike:1", gomock.Any()).Return(int64(2), time.Hour, nil)
+	m.swipeDao.EXPECT().FindByUserIdTargetId(2, 1).Return(model.UserSwipe{UserId: 2, TargetId: 1, SwipeType: model.SwipeLike}, nil)
+	m.cache.EXPECT().PutLikeDislike("2:1", "1").Return(nil)
+	m.swipeDao.EXPECT().Upsert(gomock.Any()).Return(nil)
+	m.cache.EXPECT().RecordSwipe("1", "2", model.SwipeSuperLike).Return(redis.SwipeResult{Matched: true, LikersCached: true}, nil)
+	m.userMatch.EXPECT().InsertPair(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, match model.UserMatch, reverse model.UserMatch) (bool, error) {
+			if match.Match_type != model.MatchTypeSuperLike || reverse.Match_type != model.MatchTypeSuperLike {
+				t.Errorf("match types are %d and %d, expected super like", match.Match_type, reverse.Match_type)
+			}
+			return true, nil
+		})
+
+	result, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeSuperLike})
+	if err != nil || !result.IsMatch {
+		t.Errorf("premium super like of a liker = %v, %v, expected a match", result.IsMatch, err)
+	}
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	"github.com/SuperMatch/pkg/redis"
+	mockredis "github.com/SuperMatch/pkg/redis/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/SuperMatch/zapLogger"
+	"github.com/golang/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func init() {
+	// the swipe service logs as it goes
+	if zapLogger.Logger == nil {
+		zapLogger.Logger = zap.NewNop()
+	}
+}
+
+// A like that expired from the cache still produces a match once it is returned.
+func TestSwipeMatchesLikeMissingFromCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mockredis.NewMockLikeDislikeCacheInterface(ctrl)
+	mockSwipeDao := mockdao.NewMockUserSwipeRepository(ctrl)
+	mockUserMatch := mockdao.NewMockUserMatchDao(ctrl)
+	mockBlockDao := mockdao.NewMockUserBlockRepository(ctrl)
+
+	mockBlockDao.EXPECT().IsBlocked(1, 2).Return(false, nil)
+
+	// 1 has not swiped on 2 yet
+	mockCache.EXPECT().GetLikeDislike("1:2").Return(nil, nil)
+	mockSwipeDao.EXPECT().FindByUserIdTargetId(1, 2).Return(model.UserSwipe{}, gorm.ErrRecordNotFound)
+
+	// 2 liked 1 a week ago, the like is read from the ledger and cached again
+	mockCache.EXPECT().GetLikeDislike("2:1").Return(nil, nil)
+	mockSwipeDao.EXPECT().FindByUserIdTargetId(2, 1).
+		Return(model.UserSwipe{UserId: 2, TargetId: 1, SwipeType: model.SwipeLike, SwipedAt: time.Now().AddDate(0, 0, -7)}, nil)
+	mockCache.EXPECT().PutLikeDislike("2:1", "1").Return(nil)
+
+	mockSwipeDao.EXPECT().Upsert(gomock.Any()).DoAndReturn(func(swipe model.UserSwipe) error {
+		if swipe.UserId != 1 || swipe.TargetId != 2 || swipe.SwipeType != model.SwipeLike {
+			t.Errorf("recorded swipe %+v, expected a like of 1 on 2", swipe)
+		}
+		return nil
+	})
+	mockCache.EXPECT().RecordSwipe("1", "2", model.SwipeLike).Return(redis.SwipeResult{Matched: true, LikersCached: true}, nil)
+	mockUserMatch.EXPECT().InsertPair(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+
+	swipeService := &service.SwipeService{
+		LikeDislikeCache: mockCache,
+		UserMatchDao:     mockUserMatch,
+		SwipeDao:         mockSwipeDao,
+		BlockDao:         mockBlockDao,
+	}
+
+	result, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike})
+	if err != nil {
+		t.Fatalf("error in swiping: %v", err)
+	}
+	if !result.IsMatch {
+		t.Errorf("reciprocal like did not match")
+	}
+}
+
+// A likers list missing from the cache is rebuilt from the ledger, not started over with the new like.
+func TestPutLikerRebuildsMissingList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mockredis.NewMockLikeDislikeCacheInterface(ctrl)
+	mockSwipeDao := mockdao.NewMockUserSwipeRepository(ctrl)
+
+	mockCache.EXPECT().HasUserLikes("1").Return(false, nil)
+	mockSwipeDao.EXPECT().FindPendingLikers(1).Return([]int{3, 2}, nil)
+	mockCache.EXPECT().SetUserLikes("1", []int{3, 2}).Return(nil)
+
+	swipeService := &service.SwipeService{
+		LikeDislikeCache: mockCache,
+		SwipeDao:         mockSwipeDao,
+	}
+
+	if err := swipeService.PutLiker(3, 1); err != nil {
+		t.Errorf("error in putting liker: %v", err)
+	}
+}
+
+// Swipes cached before the ledger existed are copied into it, skipping values that are not swipes.
+func TestBackfillSwipeLedger(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	swipedAt := time.Now().Add(-time.Hour)
+
+	m.cache.EXPECT().ScanSwipes(gomock.Any()).DoAndReturn(func(handle func([]redis.CachedSwipe) error) (int, error) {
+		cached := []redis.CachedSwipe{
+			{LikerID: 1, LikeeID: 2, SwipeType: model.SwipeLike, SwipedAt: swipedAt},
+			{LikerID: 1, LikeeID: 3, SwipeType: 7, SwipedAt: swipedAt},
+		}
+		return len(cached), handle(cached)
+	})
+	m.swipeDao.EXPECT().InsertMissing([]model.UserSwipe{
+		{UserId: 1, TargetId: 2, SwipeType: model.SwipeLike, SwipedAt: swipedAt},
+	}).Return(int64(1), nil)
+
+	added, err := swipeService.BackfillSwipeLedger()
+	if err != nil || added != 1 {
+		t.Errorf("BackfillSwipeLedger = %d, %v, expected 1 swipe added", added, err)
+	}
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/redis"
+	"github.com/golang/mock/gomock"
+)
+
+// A match that was already saved, by a concurrent like, is not reported again.
+func TestSwipeReportsOnlyNewMatch(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	m.likedBack()
+
+	m.cache.EXPECT().RecordSwipe("1", "2", model.SwipeLike).Return(redis.SwipeResult{Matched: true, LikersCached: true}, nil)
+	m.userMatch.EXPECT().InsertPair(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, match model.UserMatch, reverse model.UserMatch) (bool, error) {
+			if match.UserID != 1 || match.MatchID != 2 || reverse.UserID != 2 || reverse.MatchID != 1 {
+				t.Errorf("match rows %+v and %+v are not both sides of the match", match, reverse)
+			}
+			return false, nil
+		})
+
+	result, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike})
+	if err != nil {
+		t.Fatalf("error in swiping: %v", err)
+	}
+	if result.IsMatch {
+		t.Errorf("match that already existed is reported as new")
+	}
+}
+
+// When the cache is unavailable the match is decided from the ledger.
+func TestSwipeMatchesFromLedgerWithoutCache(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	m.likedBack()
+
+	m.cache.EXPECT().RecordSwipe("1", "2", model.SwipeLike).Return(redis.SwipeResult{}, errors.New("redis is down"))
+	m.userMatch.EXPECT().InsertPair(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+
+	result, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike})
+	if err != nil {
+		t.Fatalf("error in swiping: %v", err)
+	}
+	if !result.IsMatch {
+		t.Errorf("reciprocal like did not match")
+	}
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/SuperMatch/model"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	mockredis "github.com/SuperMatch/pkg/redis/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+)
+
+// swipeMocks are the mocks behind a swipe service made by newSwipeService.
+type swipeMocks struct {
+	cache       *mockredis.MockLikeDislikeCacheInterface
+	swipeDao    *mockdao.MockUserSwipeRepository
+	userMatch   *mockdao.MockUserMatchDao
+	userProfile *mockdao.MockUserProfileRepository
+	rateLimiter *mockredis.MockRateLimiterInterface
+	blockDao    *mockdao.MockUserBlockRepository
+}
+
+// newSwipeService returns a swipe service backed by mocks that expect nothing yet, except that
+// nobody blocked anyone. Quotas are off, and matches expire as soon as they are made, until a test sets the config it needs.
+func newSwipeService(t *testing.T) (*service.SwipeService, swipeMocks) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mocks := swipeMocks{
+		cache:       mockredis.NewMockLikeDislikeCacheInterface(ctrl),
+		swipeDao:    mockdao.NewMockUserSwipeRepository(ctrl),
+		userMatch:   mockdao.NewMockUserMatchDao(ctrl),
+		userProfile: mockdao.NewMockUserProfileRepository(ctrl),
+		rateLimiter: mockredis.NewMockRateLimiterInterface(ctrl),
+		blockDao:    mockdao.NewMockUserBlockRepository(ctrl),
+	}
+	mocks.blockDao.EXPECT().IsBlocked(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+
+	return &service.SwipeService{
+		LikeDislikeCache: mocks.cache,
+		SwipeDao:         mocks.swipeDao,
+		UserMatchDao:     mocks.userMatch,
+		UserProfileDao:   mocks.userProfile,
+		RateLimiter:      mocks.rateLimiter,
+		BlockDao:         mocks.blockDao,
+	}, mocks
+}
+
+// likedBack expects that 2 already liked 1 and 1 has not swiped on 2, and that the like of 1 is recorded.
+func (m swipeMocks) likedBack() {
+	m.cache.EXPECT().GetLikeDislike("1:2").Return(nil, nil)
+	m.swipeDao.EXPECT().FindByUserIdTargetId(1, 2).Return(model.UserSwipe{UserId: 1, TargetId: 2, SwipeType: model.SwipeDislike}, nil)
+	m.cache.EXPECT().PutLikeDislike("1:2", "0").Return(nil)
+	like := "1"
+	m.cache.EXPECT().GetLikeDislike("2:1").Return(&like, nil)
+	m.swipeDao.EXPECT().Upsert(gomock.Any()).Return(nil)
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/SuperMatch/pkg/redis"
+	"github.com/alicebob/miniredis"
+	Redis "github.com/redis/go-redis/v9"
+)
+
+func newSwipeCache(t *testing.T) (*redis.LikeDislikeCache, *miniredis.Miniredis) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("error in starting miniredis: %v", err)
+	}
+	t.Cleanup(server.Close)
+
+	client := Redis.NewClient(&Redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	previous := redis.RedisClient
+	redis.RedisClient = client
+	t.Cleanup(func() { redis.RedisClient = previous })
+	return redis.LikeDislikeCacheConstructor(), server
+}
+
+// Two users liking each other at the same moment make exactly one match, and neither is left in the
+// other's likers list.
+func TestRecordSwipeReciprocalLikes(t *testing.T) {
+	cache, server := newSwipeCache(t)
+	server.Lpush("1", "3")
+	server.Lpush("2", "3")
+
+	var wg sync.WaitGroup
+	results := make([]redis.SwipeResult, 2)
+	errs := make([]error, 2)
+	for i, ids := range [][2]string{{"1", "2"}, {"2", "1"}} {
+		wg.Add(1)
+		go func(i int, liker, likee string) {
+			defer wg.Done()
+			results[i], errs[i] = cache.RecordSwipe(liker, likee, 1)
+		}(i, ids[0], ids[1])
+	}
+	wg.Wait()
+
+	matches := 0
+	for i, result := range results {
+		if errs[i] != nil {
+			t.Fatalf("RecordSwipe = %v", errs[i])
+		}
+		if result.Matched {
+			matches++
+		}
+	}
+	if matches != 1 {
+		t.Errorf("got %d matches from the two likes, expected exactly one", matches)
+	}
+
+	for likee, liker := range map[string]string{"1": "2", "2": "1"} {
+		likers, err := server.List(likee)
+		if err != nil {
+			t.Fatalf("error in reading the likers of %s: %v", likee, err)
+		}
+		for _, id := range likers {
+			if id == liker {
+				t.Errorf("likers of %s = %v, expected %s to be removed after the match", likee, likers, liker)
+			}
+		}
+		if len(likers) != 1 || likers[0] != "3" {
+			t.Errorf("likers of %s = %v, expected the other likers to be kept", likee, likers)
+		}
+	}
+	for _, key := range []string{"1:2", "2:1"} {
+		if value, err := server.Get(key); err != nil || value != "1" {
+			t.Errorf("swipe %s = %q, %v, expected the like to be stored", key, value, err)
+		}
+	}
+}
+
+// A like the likee has not answered is added to their likers list, once.
+func TestRecordSwipeAddsLiker(t *testing.T) {
+	cache, server := newSwipeCache(t)
+	server.Lpush("2", "1")
+
+	result, err := cache.RecordSwipe("1", "2", 1)
+	if err != nil || result.Matched || !result.LikersCached {
+		t.Fatalf("RecordSwipe = %+v, %v, expected an unmatched like", result, err)
+	}
+	if likers, _ := server.List("2"); len(likers) != 1 || likers[0] != "1" {
+		t.Errorf("likers of 2 = %v, expected 1 to be listed once", likers)
+	}
+
+	if result, err := cache.RecordSwipe("3", "4", 1); err != nil || result.LikersCached {
+		t.Errorf("RecordSwipe = %+v, %v, expected the uncached likers list to be reported", result, err)
+	}
+	if server.Exists("4") {
+		t.Error("likers list of 4 was created, expected it to be left for the next full load")
+	}
+}
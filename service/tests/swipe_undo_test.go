@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+)
+
+func TestUndoSwipeRequiresPremium(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: false}, nil)
+
+	if _, err := swipeService.UndoLastSwipe(1); !errors.Is(err, service.ErrEntitlementRequired) {
+		t.Errorf("UndoLastSwipe of a free user = %v, expected ErrEntitlementRequired", err)
+	}
+}
+
+func TestUndoSwipeRefusesOldOrMatchedSwipe(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+	m.swipeDao.EXPECT().FindLatestByUserId(1).
+		Return(model.UserSwipe{ID: 7, UserId: 1, TargetId: 2, SwipeType: model.SwipeLike, SwipedAt: time.Now().Add(-time.Hour)}, nil)
+
+	if _, err := swipeService.UndoLastSwipe(1); !errors.Is(err, service.ErrSwipeUndoExpired) {
+		t.Errorf("UndoLastSwipe of an old swipe = %v, expected ErrSwipeUndoExpired", err)
+	}
+
+	swipeService, m = newSwipeService(t)
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+	m.swipeDao.EXPECT().FindLatestByUserId(1).
+		Return(model.UserSwipe{ID: 7, UserId: 1, TargetId: 2, SwipeType: model.SwipeLike, SwipedAt: time.Now()}, nil)
+	m.userMatch.EXPECT().FindByUserIdMatchId(gomock.Any(), 1, 2).Return(model.UserMatch{UserID: 1, MatchID: 2}, nil)
+
+	if _, err := swipeService.UndoLastSwipe(1); !errors.Is(err, service.ErrSwipeMatched) {
+		t.Errorf("UndoLastSwipe of a matching swipe = %v, expected ErrSwipeMatched", err)
+	}
+}
+
+// Undoing a dislike of someone who liked the user puts them back in the user's likes.
+func TestUndoDislikeRestoresLiker(t *testing.T) {
+	swipeService, m := newSwipeService(t)
+	m.userProfile.EXPECT().FindByUserId(gomock.Any(), 1).Return(model.UserProfile{UserId: 1, IsPremium: true}, nil)
+	swipe := model.UserSwipe{ID: 7, UserId: 1, TargetId: 2, SwipeType: model.SwipeDislike, SwipedAt: time.Now()}
+	m.swipeDao.EXPECT().FindLatestByUserId(1).Return(swipe, nil)
+	m.swipeDao.EXPECT().Delete(swipe).Return(true, nil)
+	m.cache.EXPECT().RemoveSwipe("1", "2").Return(nil)
+
+	like := "1"
+	m.cache.EXPECT().GetLikeDislike("2:1").Return(&like, nil)
+	m.swipeDao.EXPECT().FindPendingLikers(1).Return([]int{2}, nil)
+	m.cache.EXPECT().SetUserLikes("1", []int{2}).Return(nil)
+
+	undone, err := swipeService.UndoLastSwipe(1)
+	if err != nil {
+		t.Fatalf("error in undoing swipe: %v", err)
+	}
+	if undone.LikeeID != 2 || undone.Type != model.SwipeDislike {
+		t.Errorf("undone swipe is %+v", undone)
+	}
+}
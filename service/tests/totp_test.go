@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"encoding/base32"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/pkg/totp"
+	"github.com/SuperMatch/service"
+)
+
+// rfc6238Secret is the SHA1 test secret of RFC 6238 appendix B.
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode(t *testing.T) {
+	// the last six digits of the SHA1 test vectors of RFC 6238 appendix B
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range vectors {
+		code, err := totp.Code(rfc6238Secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Errorf("error in generating code: %v", err)
+		}
+		if code != expected {
+			t.Errorf("code at %d is %s, expected %s", unix, code, expected)
+		}
+	}
+
+	if _, err := totp.Code("not base32!", time.Now()); err != totp.ErrInvalidSecret {
+		t.Errorf("invalid secret gives error %v", err)
+	}
+}
+
+func TestTOTPValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, _ := totp.Code(rfc6238Secret, now)
+
+	for _, offset := range []time.Duration{-totp.Period, 0, totp.Period} {
+		step, ok, err := totp.Validate(rfc6238Secret, code, now.Add(offset))
+		if err != nil || !ok {
+			t.Errorf("code is not accepted %s from now", offset)
+		}
+		if step != totp.Step(now) {
+			t.Errorf("code matched step %d, expected %d", step, totp.Step(now))
+		}
+	}
+
+	for _, offset := range []time.Duration{-2 * totp.Period, 2 * totp.Period} {
+		if _, ok, _ := totp.Validate(rfc6238Secret, code, now.Add(offset)); ok {
+			t.Errorf("code is accepted %s from now", offset)
+		}
+	}
+
+	if _, ok, _ := totp.Validate(rfc6238Secret, "12345", now); ok {
+		t.Errorf("short code is accepted")
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("error in generating secret: %v", err)
+	}
+	if otherSecret, _ := totp.GenerateSecret(); otherSecret == secret {
+		t.Errorf("secret is not unique %q", secret)
+	}
+
+	uri, err := url.Parse(totp.ProvisioningURI(secret, "Super Match", "jane@example.com"))
+	if err != nil {
+		t.Fatalf("provisioning uri does not parse: %v", err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Super Match:jane@example.com" {
+		t.Errorf("provisioning uri has label %s://%s%s", uri.Scheme, uri.Host, uri.Path)
+	}
+	if uri.Query().Get("secret") != secret || uri.Query().Get("issuer") != "Super Match" {
+		t.Errorf("provisioning uri has query %s", uri.RawQuery)
+	}
+}
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	format := regexp.MustCompile(`^[a-z2-7]{4}-[a-z2-7]{4}$`)
+
+	seen := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		code, err := service.GenerateRecoveryCode()
+		if err != nil {
+			t.Fatalf("error in generating recovery code: %v", err)
+		}
+		if !format.MatchString(code) {
+			t.Errorf("recovery code %q is not formatted as two groups of four", code)
+		}
+		if seen[code] {
+			t.Errorf("recovery code %q is generated twice", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestIsTOTPRequired(t *testing.T) {
+	if !service.IsTOTPRequired(model.RoleAdmin) {
+		t.Errorf("admins are not required to use two factor authentication")
+	}
+	if service.IsTOTPRequired(model.RoleUser) || service.IsTOTPRequired(model.RoleModerator) {
+		t.Errorf("users are required to use two factor authentication")
+	}
+}
@@ -0,0 +1,310 @@
+package tests
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	mockredis "github.com/SuperMatch/pkg/redis/mocks"
+	"github.com/SuperMatch/pkg/totp"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// totpConfig encrypts the secrets of the tests with a fixed key.
+var totpConfig = config.TOTPConfig{
+	Issuer:        "Flicker",
+	EncryptionKey: base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")),
+}
+
+// enroll enrolls user 1 and returns the secret shown to the user and the row stored for it.
+func enroll(t *testing.T, twoFactorService *service.TwoFactorService, mockUserTOTP *mockdao.MockUserTOTPRepository, mockUser *mockdao.MockUserRepository) (string, model.UserTOTP) {
+	var stored model.UserTOTP
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(model.UserTOTP{}, gorm.ErrRecordNotFound)
+	mockUser.EXPECT().FindById(1).Return(model.User{Email: "jane@example.com"}, nil)
+	mockUserTOTP.EXPECT().Replace(gomock.Any()).DoAndReturn(func(userTOTP model.UserTOTP) (model.UserTOTP, error) {
+		userTOTP.ID = 5
+		stored = userTOTP
+		return userTOTP, nil
+	})
+
+	enrollment, err := twoFactorService.Enroll(1)
+	if err != nil {
+		t.Fatalf("error in enrolling: %v", err)
+	}
+	return enrollment.Secret, stored
+}
+
+func confirmed(userTOTP model.UserTOTP) model.UserTOTP {
+	now := time.Now()
+	userTOTP.ConfirmedAt = &now
+	return userTOTP
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockUserTOTP := mockdao.NewMockUserTOTPRepository(ctrl)
+	twoFactorService := &service.TwoFactorService{
+		UserDao:     mockUser,
+		UserTOTPDao: mockUserTOTP,
+		TOTPConfig:  totpConfig,
+	}
+
+	secret, stored := enroll(t, twoFactorService, mockUserTOTP, mockUser)
+	if stored.UserId != 1 || stored.Secret == "" || strings.Contains(stored.Secret, secret) {
+		t.Errorf("stored totp %+v, expected the secret of user 1 encrypted", stored)
+	}
+
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(confirmed(stored), nil)
+	if _, err := twoFactorService.Enroll(1); !errors.Is(err, service.ErrTOTPAlreadyEnrolled) {
+		t.Errorf("Enroll = %v, expected ErrTOTPAlreadyEnrolled once confirmed", err)
+	}
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockUserTOTP := mockdao.NewMockUserTOTPRepository(ctrl)
+	mockRecoveryCode := mockdao.NewMockUserRecoveryCodeRepository(ctrl)
+	twoFactorService := &service.TwoFactorService{
+		UserDao:         mockUser,
+		UserTOTPDao:     mockUserTOTP,
+		RecoveryCodeDao: mockRecoveryCode,
+		TOTPConfig:      totpConfig,
+	}
+	secret, stored := enroll(t, twoFactorService, mockUserTOTP, mockUser)
+
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(stored, nil)
+	if _, err := twoFactorService.Confirm(1, "000000"); !errors.Is(err, service.ErrInvalidTOTPCode) {
+		t.Errorf("Confirm with a wrong code = %v, expected ErrInvalidTOTPCode", err)
+	}
+
+	code, _ := totp.Code(secret, time.Now())
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(stored, nil)
+	mockUserTOTP.EXPECT().Confirm(stored.ID, gomock.Any()).Return(true, nil)
+	mockRecoveryCode.EXPECT().ReplaceAll(1, gomock.Any()).DoAndReturn(func(_ int, codeHashes []string) error {
+		if len(codeHashes) != 10 {
+			t.Errorf("%d recovery codes stored, expected 10", len(codeHashes))
+		}
+		return nil
+	})
+
+	recoveryCodes, err := twoFactorService.Confirm(1, code)
+	if err != nil || len(recoveryCodes) != 10 {
+		t.Errorf("Confirm = %v, %v, expected 10 recovery codes", recoveryCodes, err)
+	}
+}
+
+// A code is only accepted once, even while it is still valid.
+func TestTOTPStepReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockUserTOTP := mockdao.NewMockUserTOTPRepository(ctrl)
+	twoFactorService := &service.TwoFactorService{
+		UserDao:     mockUser,
+		UserTOTPDao: mockUserTOTP,
+		TOTPConfig:  totpConfig,
+	}
+	secret, stored := enroll(t, twoFactorService, mockUserTOTP, mockUser)
+	code, _ := totp.Code(secret, time.Now())
+
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(confirmed(stored), nil).Times(2)
+	gomock.InOrder(
+		mockUserTOTP.EXPECT().UseStep(stored.ID, gomock.Any()).Return(true, nil),
+		mockUserTOTP.EXPECT().UseStep(stored.ID, gomock.Any()).Return(false, nil),
+	)
+
+	if err := twoFactorService.Verify(1, code); err != nil {
+		t.Errorf("Verify = %v, expected the code to be accepted", err)
+	}
+	if err := twoFactorService.Verify(1, code); !errors.Is(err, service.ErrInvalidTOTPCode) {
+		t.Errorf("Verify of a used code = %v, expected ErrInvalidTOTPCode", err)
+	}
+}
+
+// A recovery code is consumed by its first use, however it is typed.
+func TestRecoveryCodeSingleUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockUserTOTP := mockdao.NewMockUserTOTPRepository(ctrl)
+	mockRecoveryCode := mockdao.NewMockUserRecoveryCodeRepository(ctrl)
+	twoFactorService := &service.TwoFactorService{
+		UserDao:         mockUser,
+		UserTOTPDao:     mockUserTOTP,
+		RecoveryCodeDao: mockRecoveryCode,
+		TOTPConfig:      totpConfig,
+	}
+	_, stored := enroll(t, twoFactorService, mockUserTOTP, mockUser)
+
+	var codeHashes []string
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(confirmed(stored), nil).Times(2)
+	gomock.InOrder(
+		mockRecoveryCode.EXPECT().Consume(1, gomock.Any()).DoAndReturn(func(_ int, codeHash string) (bool, error) {
+			codeHashes = append(codeHashes, codeHash)
+			return true, nil
+		}),
+		mockRecoveryCode.EXPECT().Consume(1, gomock.Any()).DoAndReturn(func(_ int, codeHash string) (bool, error) {
+			codeHashes = append(codeHashes, codeHash)
+			return false, nil
+		}),
+	)
+
+	if err := twoFactorService.Verify(1, "abcd-efgh"); err != nil {
+		t.Errorf("Verify = %v, expected the recovery code to be accepted", err)
+	}
+	if err := twoFactorService.Verify(1, "ABCD EFGH"); !errors.Is(err, service.ErrInvalidTOTPCode) {
+		t.Errorf("Verify of a used recovery code = %v, expected ErrInvalidTOTPCode", err)
+	}
+	if len(codeHashes) != 2 || codeHashes[0] != codeHashes[1] {
+		t.Errorf("recovery code hashes %v, expected the same code to hash the same", codeHashes)
+	}
+}
+
+// startChallenge starts a sign in challenge for user 1 and returns its token and store key.
+func startChallenge(t *testing.T, twoFactorService *service.TwoFactorService, mockChallenge *mockredis.MockChallengeStoreInterface) (string, string) {
+	var key string
+	mockChallenge.EXPECT().Put(gomock.Any(), "1", 5*time.Minute).DoAndReturn(func(challengeKey string, _ string, _ time.Duration) error {
+		key = challengeKey
+		return nil
+	})
+
+	var mfaRequired *service.MFARequiredError
+	if err := twoFactorService.StartChallenge(1); !errors.As(err, &mfaRequired) {
+		t.Fatalf("StartChallenge = %v, expected MFARequiredError", err)
+	}
+	return mfaRequired.Token, key
+}
+
+// The wrong code that reaches the limit locks two factor sign in and burns the challenge.
+func TestCompleteChallengeLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockUserTOTP := mockdao.NewMockUserTOTPRepository(ctrl)
+	mockChallenge := mockredis.NewMockChallengeStoreInterface(ctrl)
+	mockRateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+	twoFactorService := &service.TwoFactorService{
+		UserDao:        mockUser,
+		UserTOTPDao:    mockUserTOTP,
+		ChallengeStore: mockChallenge,
+		RateLimiter:    mockRateLimiter,
+		TOTPConfig:     totpConfig,
+	}
+	_, stored := enroll(t, twoFactorService, mockUserTOTP, mockUser)
+	mfaToken, key := startChallenge(t, twoFactorService, mockChallenge)
+
+	mockChallenge.EXPECT().Get(key).Return("1", true, nil)
+	mockRateLimiter.EXPECT().Count("mfa:failed:1").Return(int64(4), 15*time.Minute, nil)
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(confirmed(stored), nil)
+	mockRateLimiter.EXPECT().Increment("mfa:failed:1", 15*time.Minute).Return(int64(5), 15*time.Minute, nil)
+	mockChallenge.EXPECT().Delete(key).Return(true, nil)
+
+	_, err := twoFactorService.CompleteChallenge(mfaToken, "000000")
+	var rateLimitErr *service.RateLimitError
+	if !errors.As(err, &rateLimitErr) || !rateLimitErr.Locked {
+		t.Errorf("CompleteChallenge = %v, expected the user to be locked out", err)
+	}
+
+	mockChallenge.EXPECT().Get(key).Return("1", true, nil)
+	mockRateLimiter.EXPECT().Count("mfa:failed:1").Return(int64(5), 10*time.Minute, nil)
+	if _, err := twoFactorService.CompleteChallenge(mfaToken, "000000"); !errors.As(err, &rateLimitErr) || !rateLimitErr.Locked {
+		t.Errorf("CompleteChallenge while locked = %v, expected the user to stay locked out", err)
+	}
+}
+
+// A challenge completed concurrently with the same code only signs in once.
+func TestCompleteChallengeSingleUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockUserTOTP := mockdao.NewMockUserTOTPRepository(ctrl)
+	mockChallenge := mockredis.NewMockChallengeStoreInterface(ctrl)
+	mockRateLimiter := mockredis.NewMockRateLimiterInterface(ctrl)
+	twoFactorService := &service.TwoFactorService{
+		UserDao:        mockUser,
+		UserTOTPDao:    mockUserTOTP,
+		ChallengeStore: mockChallenge,
+		RateLimiter:    mockRateLimiter,
+		TOTPConfig:     totpConfig,
+	}
+	secret, stored := enroll(t, twoFactorService, mockUserTOTP, mockUser)
+	mfaToken, key := startChallenge(t, twoFactorService, mockChallenge)
+	code, _ := totp.Code(secret, time.Now())
+
+	mockChallenge.EXPECT().Get(key).Return("1", true, nil)
+	mockRateLimiter.EXPECT().Count("mfa:failed:1").Return(int64(0), time.Duration(0), nil)
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(confirmed(stored), nil)
+	mockUserTOTP.EXPECT().UseStep(stored.ID, gomock.Any()).Return(true, nil)
+	mockChallenge.EXPECT().Delete(key).Return(false, nil)
+
+	if _, err := twoFactorService.CompleteChallenge(mfaToken, code); !errors.Is(err, service.ErrInvalidMFAToken) {
+		t.Errorf("CompleteChallenge of a completed challenge = %v, expected ErrInvalidMFAToken", err)
+	}
+}
+
+// sessions stands in for the session service, which has no mock.
+type sessions struct {
+	service.SessionServiceInterface
+	revoked []int
+}
+
+func (s *sessions) RevokeAllSessions(userID int) error {
+	s.revoked = append(s.revoked, userID)
+	return nil
+}
+
+func TestGrantAdminRequiresTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUser := mockdao.NewMockUserRepository(ctrl)
+	mockUserTOTP := mockdao.NewMockUserTOTPRepository(ctrl)
+	twoFactorService := &service.TwoFactorService{
+		UserDao:     mockUser,
+		UserTOTPDao: mockUserTOTP,
+		TOTPConfig:  totpConfig,
+	}
+	sessionService := &sessions{}
+	adminService := &service.AdminService{
+		UserDao:          mockUser,
+		SessionService:   sessionService,
+		TwoFactorService: twoFactorService,
+	}
+
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(model.UserTOTP{}, gorm.ErrRecordNotFound)
+	if err := adminService.UpdateUserRole(1, model.RoleAdmin); !errors.Is(err, service.ErrTOTPEnrollmentRequired) {
+		t.Errorf("UpdateUserRole without two factor = %v, expected ErrTOTPEnrollmentRequired", err)
+	}
+
+	mockUserTOTP.EXPECT().FindByUserId(1).Return(confirmed(model.UserTOTP{UserId: 1}), nil)
+	mockUser.EXPECT().UpdateRole(1, model.RoleAdmin).Return(true, nil)
+	if err := adminService.UpdateUserRole(1, model.RoleAdmin); err != nil {
+		t.Errorf("UpdateUserRole with two factor = %v, expected the role to be granted", err)
+	}
+
+	mockUser.EXPECT().UpdateRole(2, model.RoleModerator).Return(true, nil)
+	if err := adminService.UpdateUserRole(2, model.RoleModerator); err != nil {
+		t.Errorf("UpdateUserRole to moderator = %v, expected no two factor check", err)
+	}
+
+	if len(sessionService.revoked) != 2 {
+		t.Errorf("sessions of %v revoked, expected the users who got a new role", sessionService.revoked)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"github.com/SuperMatch/model"
 	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
 	"github.com/SuperMatch/pkg/db/dao/mocks"
-	esMocks "github.com/SuperMatch/pkg/elasticseach/mocks"
+	esMocks "github.com/SuperMatch/pkg/elasticSeach/mocks"
 	mockService "github.com/SuperMatch/service/mocks"
 	utils "github.com/SuperMatch/utilities"
 	"github.com/golang/mock/gomock"
@@ -256,7 +256,7 @@ func TestSaveProfileMedia(t *testing.T) {
 
 	mockUserMedia := mocks.NewMockUserMediaRepository(ctrl)
 	mockUserMedia.EXPECT().Insert(gomock.Any(), gomock.Eq(userMedia)).
-		Return(nil).
+		Return(userMedia, nil).
 		AnyTimes()
 }
 
@@ -5,7 +5,7 @@ import (
 	"github.com/SuperMatch/model"
 	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
 	"github.com/SuperMatch/pkg/db/dao/mocks"
-	esMocks "github.com/SuperMatch/pkg/elasticseach/mocks"
+	esMocks "github.com/SuperMatch/pkg/elasticSeach/mocks"
 	utils "github.com/SuperMatch/utilities"
 	"github.com/golang/mock/gomock"
 	"gorm.io/gorm"
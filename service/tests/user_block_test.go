@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/db/dao"
+	mockdao "github.com/SuperMatch/pkg/db/dao/mocks"
+	mockredis "github.com/SuperMatch/pkg/redis/mocks"
+	"github.com/SuperMatch/service"
+	"github.com/golang/mock/gomock"
+)
+
+func TestBlockSelf(t *testing.T) {
+	userBlockService := service.UserBlockService{}
+
+	if err := userBlockService.BlockUser(1, 1); !errors.Is(err, service.ErrCannotBlockSelf) {
+		t.Errorf("BlockUser of oneself = %v, expected ErrCannotBlockSelf", err)
+	}
+}
+
+// likersMedia returns no media, checking which likers it is asked for.
+type likersMedia struct {
+	dao.UserMediaRepository
+	t      *testing.T
+	likers []int
+}
+
+func (m likersMedia) FindByUserIDs(userIDs []int) ([]model.UserMedia, error) {
+	if !reflect.DeepEqual(userIDs, m.likers) {
+		m.t.Errorf("likers are %v, expected %v", userIDs, m.likers)
+	}
+	return nil, nil
+}
+
+// Likes of a user blocked by, or blocking, the user are not shown.
+func TestGetUserLikesExcludesBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mockredis.NewMockLikeDislikeCacheInterface(ctrl)
+	mockSearchProfile := mockdao.NewMockUserSearchProfileRepository(ctrl)
+	mockSwipeDao := mockdao.NewMockUserSwipeRepository(ctrl)
+	mockBlockDao := mockdao.NewMockUserBlockRepository(ctrl)
+
+	mockCache.EXPECT().HasUserLikes("1").Return(true, nil)
+	mockCache.EXPECT().GetUserLikes("1").Return([]int{2, 3, 4}, nil)
+	mockSearchProfile.EXPECT().FindPausedUserIds(gomock.Any(), []int{2, 3, 4}).Return([]int{}, nil)
+	mockBlockDao.EXPECT().FindBlockedUserIds(1).Return([]int{3}, nil)
+	mockSwipeDao.EXPECT().FindSuperLikers(1, []int{2, 4}).Return([]int{}, nil)
+
+	swipeService := &service.SwipeService{
+		LikeDislikeCache:    mockCache,
+		SearchProfileDao:    mockSearchProfile,
+		SwipeDao:            mockSwipeDao,
+		BlockDao:            mockBlockDao,
+		UserMediaRepository: likersMedia{t: t, likers: []int{2, 4}},
+	}
+
+	if _, err := swipeService.GetUserLikes(1); err != nil {
+		t.Fatalf("error in getting user likes: %v", err)
+	}
+}
+
+// A user cannot swipe on someone they blocked or who blocked them, so a like left from before the
+// block cannot make the match again.
+func TestSwipeOnBlockedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBlockDao := mockdao.NewMockUserBlockRepository(ctrl)
+	mockBlockDao.EXPECT().IsBlocked(1, 2).Return(true, nil)
+
+	// the ledger, quota and match mocks expect nothing, so any use of them fails the test
+	swipeService, _ := newSwipeService(t)
+	swipeService.BlockDao = mockBlockDao
+
+	if _, err := swipeService.Swipe(dto.UserLikeDTO{LikerID: 1, LikeeID: 2, Type: model.SwipeLike}); !errors.Is(err, service.ErrUserBlocked) {
+		t.Errorf("Swipe on a blocked user = %v, expected ErrUserBlocked", err)
+	}
+}
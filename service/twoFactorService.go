@@ -0,0 +1,442 @@
+package service
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SuperMatch/config"
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/db"
+	"github.com/SuperMatch/pkg/db/dao"
+	"github.com/SuperMatch/pkg/redis"
+	"github.com/SuperMatch/pkg/totp"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	recoveryCodeCount = 10
+	// mfaChallengeTTL is how long a user has to enter a code after the first sign in step.
+	mfaChallengeTTL         = 5 * time.Minute
+	mfaMaxFailedAttempts    = 5
+	mfaLockoutDuration      = 15 * time.Minute
+	mfaChallengeKey         = "mfa:challenge:"
+	mfaFailedAttemptsKey    = "mfa:failed:"
+	recoveryCodeEncodedSize = 8
+)
+
+var (
+	ErrTOTPNotEnrolled     = errors.New("two factor authentication is not enabled")
+	ErrTOTPAlreadyEnrolled = errors.New("two factor authentication is already enabled")
+	ErrInvalidTOTPCode     = errors.New("invalid two factor authentication code")
+	ErrInvalidMFAToken     = errors.New("invalid or expired mfa token")
+	ErrTOTPRequiredForRole = errors.New("two factor authentication cannot be disabled for staff accounts")
+)
+
+// MFARequiredError is returned instead of tokens when the user has two factor authentication
+// enabled. The sign in is completed by sending Token with a code to VerifyMFA before ExpiresAt.
+type MFARequiredError struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func (e *MFARequiredError) Error() string {
+	return "two factor authentication required"
+}
+
+type TwoFactorServiceInterface interface {
+	Enroll(userID int) (dto.TOTPEnrollment, error)
+	Confirm(userID int, code string) ([]string, error)
+	Disable(userID int, code string) error
+	RegenerateRecoveryCodes(userID int, code string) ([]string, error)
+	IsEnrolled(userID int) (bool, error)
+	Verify(userID int, code string) error
+	StartChallenge(userID int) error
+	CompleteChallenge(mfaToken string, code string) (int, error)
+}
+
+type TwoFactorService struct {
+	UserDao         dao.UserRepository
+	UserTOTPDao     dao.UserTOTPRepository
+	RecoveryCodeDao dao.UserRecoveryCodeRepository
+	ChallengeStore  redis.ChallengeStoreInterface
+	RateLimiter     redis.RateLimiterInterface
+	TOTPConfig      config.TOTPConfig
+}
+
+func NewTwoFactorService() *TwoFactorService {
+	return &TwoFactorService{
+		UserDao:         &dao.UserDao{Connection: *db.GlobalOrm},
+		UserTOTPDao:     &dao.UserTOTPDao{Connection: *db.GlobalOrm},
+		RecoveryCodeDao: &dao.UserRecoveryCodeDao{Connection: *db.GlobalOrm},
+		ChallengeStore:  redis.ChallengeStoreConstructor(),
+		RateLimiter:     redis.RateLimiterConstructor(),
+		TOTPConfig:      config.ConfigValue.TOTPConfig,
+	}
+}
+
+// Enroll creates a new secret for the user. Two factor authentication is enabled once Confirm is
+// called with a code generated from it; enrolling again before that replaces the secret.
+func (t *TwoFactorService) Enroll(userID int) (dto.TOTPEnrollment, error) {
+	userTOTP, err := t.UserTOTPDao.FindByUserId(userID)
+	if err == nil && userTOTP.ConfirmedAt != nil {
+		return dto.TOTPEnrollment{}, ErrTOTPAlreadyEnrolled
+	} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		zapLogger.Logger.Error("error in finding user totp", zap.Error(err))
+		return dto.TOTPEnrollment{}, err
+	}
+
+	user, err := t.UserDao.FindById(userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in finding user to enroll", zap.Error(err))
+		return dto.TOTPEnrollment{}, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		zapLogger.Logger.Error("error in generating totp secret", zap.Error(err))
+		return dto.TOTPEnrollment{}, err
+	}
+
+	encryptedSecret, err := t.encryptSecret(secret)
+	if err != nil {
+		return dto.TOTPEnrollment{}, err
+	}
+
+	_, err = t.UserTOTPDao.Replace(model.UserTOTP{UserId: userID, Secret: encryptedSecret})
+	if err != nil {
+		return dto.TOTPEnrollment{}, err
+	}
+
+	account := user.Email
+	if account == "" {
+		account = user.Mobile
+	}
+
+	return dto.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, t.TOTPConfig.Issuer, account),
+	}, nil
+}
+
+// Confirm enables two factor authentication with the first code of the enrolled secret and returns
+// the recovery codes of the account. They are only shown this once.
+func (t *TwoFactorService) Confirm(userID int, code string) ([]string, error) {
+	userTOTP, err := t.UserTOTPDao.FindByUserId(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTOTPNotEnrolled
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding user totp", zap.Error(err))
+		return nil, err
+	}
+	if userTOTP.ConfirmedAt != nil {
+		return nil, ErrTOTPAlreadyEnrolled
+	}
+
+	step, err := t.validateCode(userTOTP, code)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmed, err := t.UserTOTPDao.Confirm(userTOTP.ID, step)
+	if err != nil {
+		zapLogger.Logger.Error("error in confirming user totp", zap.Error(err))
+		return nil, err
+	}
+	if !confirmed {
+		return nil, ErrTOTPAlreadyEnrolled
+	}
+
+	return t.replaceRecoveryCodes(userID)
+}
+
+// Disable turns two factor authentication off after checking a code. Staff accounts have to keep it.
+func (t *TwoFactorService) Disable(userID int, code string) error {
+	user, err := t.UserDao.FindById(userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in finding user to disable totp", zap.Error(err))
+		return err
+	}
+	if IsTOTPRequired(user.Role) {
+		return ErrTOTPRequiredForRole
+	}
+
+	if err := t.Verify(userID, code); err != nil {
+		return err
+	}
+
+	if err := t.UserTOTPDao.DeleteByUserId(userID); err != nil {
+		return err
+	}
+
+	return t.RecoveryCodeDao.DeleteByUserId(userID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the account after checking a code.
+func (t *TwoFactorService) RegenerateRecoveryCodes(userID int, code string) ([]string, error) {
+	if err := t.Verify(userID, code); err != nil {
+		return nil, err
+	}
+
+	return t.replaceRecoveryCodes(userID)
+}
+
+// IsEnrolled reports whether the user has confirmed two factor authentication.
+func (t *TwoFactorService) IsEnrolled(userID int) (bool, error) {
+	userTOTP, err := t.UserTOTPDao.FindByUserId(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding user totp", zap.Error(err))
+		return false, err
+	}
+
+	return userTOTP.ConfirmedAt != nil, nil
+}
+
+// Verify checks a code of the authenticator app or an unused recovery code. Every code is only accepted once.
+func (t *TwoFactorService) Verify(userID int, code string) error {
+	userTOTP, err := t.UserTOTPDao.FindByUserId(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrTOTPNotEnrolled
+	} else if err != nil {
+		zapLogger.Logger.Error("error in finding user totp", zap.Error(err))
+		return err
+	}
+	if userTOTP.ConfirmedAt == nil {
+		return ErrTOTPNotEnrolled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) != totp.Digits {
+		return t.consumeRecoveryCode(userID, code)
+	}
+
+	step, err := t.validateCode(userTOTP, code)
+	if err != nil {
+		return err
+	}
+
+	used, err := t.UserTOTPDao.UseStep(userTOTP.ID, step)
+	if err != nil {
+		zapLogger.Logger.Error("error in recording used totp step", zap.Error(err))
+		return err
+	}
+	if !used {
+		return ErrInvalidTOTPCode
+	}
+
+	return nil
+}
+
+// StartChallenge is the first step of a sign in with two factor authentication. It returns a
+// MFARequiredError carrying the token the second step has to present.
+func (t *TwoFactorService) StartChallenge(userID int) error {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	mfaToken := base64.RawURLEncoding.EncodeToString(b)
+
+	err := t.ChallengeStore.Put(mfaChallengeKey+hashMFAToken(mfaToken), strconv.Itoa(userID), mfaChallengeTTL)
+	if err != nil {
+		return err
+	}
+
+	return &MFARequiredError{Token: mfaToken, ExpiresAt: time.Now().Add(mfaChallengeTTL)}
+}
+
+// CompleteChallenge checks the code for a challenge and returns the user it was started for. A
+// challenge can only be completed once, and too many wrong codes lock two factor sign in of the user.
+func (t *TwoFactorService) CompleteChallenge(mfaToken string, code string) (int, error) {
+	key := mfaChallengeKey + hashMFAToken(mfaToken)
+	value, found, err := t.ChallengeStore.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, ErrInvalidMFAToken
+	}
+
+	userID, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, ErrInvalidMFAToken
+	}
+
+	failedAttemptsKey := mfaFailedAttemptsKey + value
+	failures, ttl, err := t.RateLimiter.Count(failedAttemptsKey)
+	if err != nil {
+		return 0, err
+	}
+	if failures >= mfaMaxFailedAttempts {
+		return 0, &RateLimitError{RetryAfter: ttl, Locked: true}
+	}
+
+	err = t.Verify(userID, code)
+	if errors.Is(err, ErrInvalidTOTPCode) {
+		failures, ttl, countErr := t.RateLimiter.Increment(failedAttemptsKey, mfaLockoutDuration)
+		if countErr != nil {
+			return 0, countErr
+		}
+		if failures >= mfaMaxFailedAttempts {
+			zapLogger.Logger.Warn("too many failed two factor attempts, locking user", zap.Int("user_id", userID))
+			if _, err := t.ChallengeStore.Delete(key); err != nil {
+				zapLogger.Logger.Error("error in deleting mfa challenge of locked user", zap.Error(err))
+			}
+			return 0, &RateLimitError{RetryAfter: ttl, Locked: true}
+		}
+		return 0, err
+	} else if err != nil {
+		return 0, err
+	}
+
+	deleted, err := t.ChallengeStore.Delete(key)
+	if err != nil {
+		return 0, err
+	}
+	if !deleted {
+		return 0, ErrInvalidMFAToken
+	}
+
+	if err := t.RateLimiter.Reset(failedAttemptsKey); err != nil {
+		zapLogger.Logger.Error("error in resetting failed two factor attempts", zap.Error(err))
+	}
+
+	return userID, nil
+}
+
+// IsTOTPRequired reports whether accounts with role have to use two factor authentication.
+func IsTOTPRequired(role string) bool {
+	return model.HasRole(role, model.RoleAdmin)
+}
+
+func (t *TwoFactorService) validateCode(userTOTP model.UserTOTP, code string) (int64, error) {
+	secret, err := t.decryptSecret(userTOTP.Secret)
+	if err != nil {
+		return 0, err
+	}
+
+	step, ok, err := totp.Validate(secret, code, time.Now())
+	if err != nil {
+		zapLogger.Logger.Error("error in validating totp code", zap.Error(err))
+		return 0, err
+	}
+	if !ok {
+		return 0, ErrInvalidTOTPCode
+	}
+	return step, nil
+}
+
+func (t *TwoFactorService) consumeRecoveryCode(userID int, code string) error {
+	consumed, err := t.RecoveryCodeDao.Consume(userID, hashRecoveryCode(code))
+	if err != nil {
+		zapLogger.Logger.Error("error in consuming recovery code", zap.Error(err))
+		return err
+	}
+	if !consumed {
+		return ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+func (t *TwoFactorService) replaceRecoveryCodes(userID int) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	codeHashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := GenerateRecoveryCode()
+		if err != nil {
+			zapLogger.Logger.Error("error in generating recovery code", zap.Error(err))
+			return nil, err
+		}
+		codes = append(codes, code)
+		codeHashes = append(codeHashes, hashRecoveryCode(code))
+	}
+
+	if err := t.RecoveryCodeDao.ReplaceAll(userID, codeHashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// GenerateRecoveryCode returns a random recovery code formatted as two groups of four characters.
+func GenerateRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	encoded := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+	return encoded[:recoveryCodeEncodedSize/2] + "-" + encoded[recoveryCodeEncodedSize/2:], nil
+}
+
+// hashRecoveryCode hashes a recovery code the way it was entered, ignoring case, spaces and dashes.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashMFAToken(mfaToken string) string {
+	sum := sha256.Sum256([]byte(mfaToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// encryptSecret encrypts a totp secret with AES-GCM, storing the nonce in front of the ciphertext.
+func (t *TwoFactorService) encryptSecret(secret string) (string, error) {
+	gcm, err := t.secretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+func (t *TwoFactorService) decryptSecret(encryptedSecret string) (string, error) {
+	gcm, err := t.secretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedSecret)
+	if err != nil || len(ciphertext) < gcm.NonceSize() {
+		return "", totp.ErrInvalidSecret
+	}
+
+	secret, err := gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], nil)
+	if err != nil {
+		zapLogger.Logger.Error("error in decrypting totp secret", zap.Error(err))
+		return "", totp.ErrInvalidSecret
+	}
+	return string(secret), nil
+}
+
+func (t *TwoFactorService) secretCipher() (cipher.AEAD, error) {
+	key, err := base64.StdEncoding.DecodeString(t.TOTPConfig.EncryptionKey)
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("totp encryption key must be 32 base64 encoded bytes")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/SuperMatch/model"
+	"github.com/SuperMatch/model/dto"
+	"github.com/SuperMatch/pkg/db/dao"
+	"github.com/SuperMatch/zapLogger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCannotBlockSelf = errors.New("users cannot block themselves")
+	ErrBlockNotFound   = errors.New("user is not blocked")
+	ErrUserBlocked     = errors.New("user is blocked")
+)
+
+type UserBlockInterface interface {
+	BlockUser(userID, blockedID int) error
+	UnblockUser(userID, blockedID int) error
+	GetBlockedUsers(userID int) ([]model.UserBlock, error)
+}
+
+type UserBlockService struct {
+	userBlockDao dao.UserBlockRepository
+	swipeService SwipeServiceInterface
+}
+
+func NewUserBlockService() *UserBlockService {
+	return &UserBlockService{
+		userBlockDao: dao.NewUserBlockRepository(),
+		swipeService: NewSwipeService(),
+	}
+}
+
+// BlockUser blocks blockedID for the user and removes any match between them. Blocking twice is not
+// an error.
+func (u *UserBlockService) BlockUser(userID, blockedID int) error {
+	if userID == blockedID {
+		return ErrCannotBlockSelf
+	}
+
+	_, err := u.userBlockDao.Insert(model.UserBlock{UserId: userID, BlockedId: blockedID})
+	if err != nil {
+		return err
+	}
+
+	err = u.swipeService.RemoveMatch(dto.UserLikeDTO{LikerID: userID, LikeeID: blockedID})
+	if err != nil {
+		zapLogger.Logger.Error("error in removing match of blocked user", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UnblockUser lifts the block of the user on blockedID. A removed match is not restored.
+func (u *UserBlockService) UnblockUser(userID, blockedID int) error {
+	deleted, err := u.userBlockDao.Delete(userID, blockedID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBlockNotFound
+	}
+	return nil
+}
+
+func (u *UserBlockService) GetBlockedUsers(userID int) ([]model.UserBlock, error) {
+	blocks, err := u.userBlockDao.FindByUserId(userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in getting blocked users", zap.Error(err))
+		return nil, err
+	}
+	return blocks, nil
+}
@@ -32,6 +32,8 @@ const (
 	S3_BUCKET_PATH         = "https://" + user_profile_S3_bucket + ".s3.ap-south-1.amazonaws.com"
 )
 
+var ErrInvalidTimeZone = errors.New("time zone must be an IANA time zone name")
+
 type UserProfileInterface interface {
 	GetUserProfile(userProfileId int) (elasticsearchPkg.UserProfile, error)
 	GetUserProfileFromDB(userId int) (model.UserProfile, error)
@@ -53,7 +55,7 @@ type UserProfileInterface interface {
 	UpdateMediaProfile(user model.UserProfile, mediaDetails model.MediaOrderId) (model.UserMedia, error)
 	UploadNudgeMediaToS3(userId string, file *multipart.FileHeader, nudgeDetails model.NudgeDetail) (model.NudgeDetail, error)
 	CreateProfileIndex() error
-	DeleteUserNudge(nudgeID int) error
+	DeleteUserNudge(userID, nudgeID int) error
 	GetFiltersList() (model.Filters, error)
 	UpdateUserInterest(interests []model.InterestDetails, userID int) ([]model.UserInterests, error)
 	CheckAllowedVideoFileType(extension string) bool
@@ -70,6 +72,7 @@ type UserProfileService struct {
 	interestsDao         dao.InterestsDao
 	userNudgesDao        dao.UserNudgesDao
 	filtersDao           dao.FiltersDao
+	userBlockDao         dao.UserBlockRepository
 }
 
 func NewUserProfileService() *UserProfileService {
@@ -84,6 +87,16 @@ func NewUserProfileService() *UserProfileService {
 		interestsDao:         dao.NewInterestsDaoImpl(),
 		userNudgesDao:        dao.NewUserNudgesDaoImpl(),
 		filtersDao:           dao.NewFiltersDaoImpl(),
+		userBlockDao:         dao.NewUserBlockRepository(),
+	}
+}
+
+// NewUserProfileSearch returns a user profile service that only searches profiles in esIndex, leaving
+// out the users userBlockDao finds blocked.
+func NewUserProfileSearch(esIndex pkg.ElasticSearchIndexer, userBlockDao dao.UserBlockRepository) *UserProfileService {
+	return &UserProfileService{
+		esIndex:      esIndex,
+		userBlockDao: userBlockDao,
 	}
 }
 
@@ -229,6 +242,10 @@ func (u *UserProfileService) UpdateUserProfile(profile model.UserProfile, userPr
 	profileMap["smoke"] = userProfileDTO.Smoke
 	profileMap["about"] = userProfileDTO.About
 	profileMap["pronoun"] = userProfileDTO.Pronoun
+	if userProfileDTO.TimeZone != nil && !validTimeZone(*userProfileDTO.TimeZone) {
+		return userProfileDTO, ErrInvalidTimeZone
+	}
+	profileMap["time_zone"] = userProfileDTO.TimeZone
 
 	var err error
 	_, err = u.userProfileDao.UpdateProfileByMap(context.Background(), profileMap)
@@ -276,7 +293,13 @@ func (u *UserProfileService) SearchProfile(user elasticsearchPkg.UserProfile) ([
 		Sort:       "asc",
 	}
 
-	query := generateQuery(user, &pagination)
+	blockedIDs, err := u.userBlockDao.FindBlockedUserIds(user.UserId)
+	if err != nil {
+		zapLogger.Logger.Error("error in getting blocked users", zap.Error(err))
+		return nil, err
+	}
+
+	query := generateQuery(user, blockedIDs, &pagination)
 	tmp, err := u.esIndex.SearchProfile(query)
 	if err != nil {
 		zapLogger.Logger.Error("error in updating userSearchProfile", zap.Error(err))
@@ -357,7 +380,9 @@ func (u *UserProfileService) RemoveProfileMedia(userID int, mediaId int) error {
 	return nil
 }
 
-func generateQuery(user elasticsearchPkg.UserProfile, pagination *model.Pagination) map[string]interface{} {
+// generateQuery builds the search of profiles matching the search profile of the user, leaving out
+// paused users and the users in excludedUserIDs.
+func generateQuery(user elasticsearchPkg.UserProfile, excludedUserIDs []int, pagination *model.Pagination) map[string]interface{} {
 
 	userSearchProfile := user.UserSearchProfile
 	mustMap := []map[string]interface{}{}
@@ -497,10 +522,36 @@ func generateQuery(user elasticsearchPkg.UserProfile, pagination *model.Paginati
 		}
 	}
 
+	// paused users are hidden until they resume or their snooze is over
+	mustNotMap := []map[string]interface{}{
+		{
+			"term": map[string]interface{}{
+				"userSearchProfile.paused": true,
+			},
+		},
+		{
+			"range": map[string]interface{}{
+				"userSearchProfile.snooze": map[string]interface{}{
+					"gt": "now",
+				},
+			},
+		},
+	}
+
+	// blocked users never see each other
+	if len(excludedUserIDs) > 0 {
+		mustNotMap = append(mustNotMap, map[string]interface{}{
+			"terms": map[string]interface{}{
+				"user_id": excludedUserIDs,
+			},
+		})
+	}
+
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
 			"bool": map[string]interface{}{
-				"must": mustMap,
+				"must":     mustMap,
+				"must_not": mustNotMap,
 			},
 		},
 		"sort": []map[string]interface{}{
@@ -557,6 +608,15 @@ func (u *UserProfileService) CreateUserProfileES(userProfileDTO dto.UserProfile,
 	return userProfileES, nil
 }
 
+// validTimeZone reports whether name is an IANA time zone name, which daily swipe limits reset in.
+func validTimeZone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
 func getLocation(loc []float64) *model.Location {
 
 	return &model.Location{
@@ -914,13 +974,18 @@ func (u *UserProfileService) CreateProfileIndex() error {
 	return nil
 }
 
-func (u *UserProfileService) UpdateUserNudge(nudgeDetails model.NudgeDetail, nudgeID int) (model.UserNudge, error) {
+func (u *UserProfileService) UpdateUserNudge(nudgeDetails model.NudgeDetail, userID, nudgeID int) (model.UserNudge, error) {
 	userNudge, err := u.userNudgesDao.GetUserNudgeById(nudgeID)
 	if err != nil {
 		zapLogger.Logger.Error("error in getting user nudge from DB")
 		return userNudge, err
 	}
 
+	if userNudge.UserID != userID {
+		zapLogger.Logger.Error(fmt.Sprintf("user nudge %d does not belong to user %d", nudgeID, userID))
+		return userNudge, errors.New("user nudge not found")
+	}
+
 	if (nudgeDetails.MediaURL != "" && userNudge.MediaURL != "") || (nudgeDetails.Type == "text" && userNudge.Type != "text") {
 		key := strings.ReplaceAll(strings.TrimPrefix(userNudge.MediaURL, S3_BUCKET_PATH), "%3A", ":")
 		err := u.s3Service.DeleteFile(user_profile_S3_bucket, key)
@@ -943,13 +1008,18 @@ func (u *UserProfileService) UpdateUserNudge(nudgeDetails model.NudgeDetail, nud
 	return userNudge, nil
 }
 
-func (u *UserProfileService) DeleteUserNudge(nudgeID int) error {
+func (u *UserProfileService) DeleteUserNudge(userID, nudgeID int) error {
 	userNudge, err := u.userNudgesDao.GetUserNudgeById(nudgeID)
 	if err != nil {
 		zapLogger.Logger.Error("error in getting user nudge from DB")
 		return err
 	}
 
+	if userNudge.UserID != userID {
+		zapLogger.Logger.Error(fmt.Sprintf("user nudge %d does not belong to user %d", nudgeID, userID))
+		return errors.New("user nudge not found")
+	}
+
 	if userNudge.MediaURL != "" {
 		key := strings.ReplaceAll(strings.TrimPrefix(userNudge.MediaURL, S3_BUCKET_PATH), "%3A", ":")
 		err := u.s3Service.DeleteFile(user_profile_S3_bucket, key)
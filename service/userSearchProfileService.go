@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"time"
+
 	"github.com/SuperMatch/model"
 	"github.com/SuperMatch/model/dto"
 	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
@@ -19,8 +22,12 @@ type UserSearchProfileServiceInterface interface {
 	FindByProfileId(ctx context.Context, profileId int) (model.UserSearchProfile, error)
 	FindByUserId(ctx context.Context, userId int) (model.UserSearchProfile, error)
 	UpdateAdvancedFilters(advFil dto.AdvancedFilter, profile model.UserProfile) (dto.AdvancedFilter, error)
+	PauseAccount(ctx context.Context, profile model.UserProfile, until *time.Time) (dto.UserSearchProfile, error)
+	ResumeAccount(ctx context.Context, profile model.UserProfile) (dto.UserSearchProfile, error)
 }
 
+var ErrInvalidPauseUntil = errors.New("pause must end in the future")
+
 type UserSearchProfileService struct {
 	esIndex              pkg.ElasticSearchIndexer
 	userSearchProfileDao dao.UserSearchProfileRepository
@@ -121,8 +128,9 @@ func (u *UserSearchProfileService) UpdateUserSearchProfile(ctx context.Context,
 
 	userProfileES, err := u.esIndex.GetUserProfile(int(profile.ID))
 
-	//setting advanced filter in search profile
+	//setting advanced filter and pause in search profile
 	searchProfileES.AdvancedFilter = userProfileES.UserSearchProfile.AdvancedFilter
+	searchProfileES.Paused = userProfileES.UserSearchProfile.Paused
 	userProfileES.UserSearchProfile = searchProfileES
 
 	jsonString, err := json.Marshal(userProfileES)
@@ -155,6 +163,7 @@ func (u *UserSearchProfileService) FindByProfileId(ctx context.Context, profileI
 		Gender:     utils.ConvertStringToCustomGender(profile.Gender),
 		Snooze:     profile.Snooze,
 		HideMyName: profile.HideMyName,
+		Paused:     profile.Paused,
 	}
 	return userSearchProfile, nil
 }
@@ -172,6 +181,7 @@ func (u *UserSearchProfileService) FindByUserId(ctx context.Context, userId int)
 		Gender:     utils.ConvertStringToCustomGender(profile.Gender),
 		Snooze:     profile.Snooze,
 		HideMyName: profile.HideMyName,
+		Paused:     profile.Paused,
 	}
 	return userSearchProfile, nil
 }
@@ -246,3 +256,47 @@ func (u *UserSearchProfileService) UpdateAdvancedFilters(advFil dto.AdvancedFilt
 	}
 	return advFil, nil
 }
+
+// PauseAccount hides the user from search, likes and stories until the given time, or until the
+// account is resumed when until is nil. Matches and chats are kept.
+func (u *UserSearchProfileService) PauseAccount(ctx context.Context, profile model.UserProfile, until *time.Time) (dto.UserSearchProfile, error) {
+	if until != nil && !until.After(time.Now()) {
+		return dto.UserSearchProfile{}, ErrInvalidPauseUntil
+	}
+
+	return u.updatePause(ctx, profile, until == nil, until)
+}
+
+// ResumeAccount shows a paused user again.
+func (u *UserSearchProfileService) ResumeAccount(ctx context.Context, profile model.UserProfile) (dto.UserSearchProfile, error) {
+	return u.updatePause(ctx, profile, false, nil)
+}
+
+func (u *UserSearchProfileService) updatePause(ctx context.Context, profile model.UserProfile, paused bool, snooze *time.Time) (dto.UserSearchProfile, error) {
+	err := u.userSearchProfileDao.UpdatePause(ctx, int(profile.ID), paused, snooze)
+	if err != nil {
+		return dto.UserSearchProfile{}, err
+	}
+
+	userProfileES, err := u.esIndex.GetUserProfile(int(profile.ID))
+	if err != nil {
+		zapLogger.Logger.Error("error in getting user profile from elasticsearch:", zap.Error(err))
+		return dto.UserSearchProfile{}, err
+	}
+
+	userProfileES.Id = int(profile.ID)
+	userProfileES.UserSearchProfile.Paused = paused
+	userProfileES.UserSearchProfile.Snooze = snooze
+	jsonString, err := json.Marshal(userProfileES)
+	if err != nil {
+		return dto.UserSearchProfile{}, err
+	}
+
+	//update search Profile in elasticsearch
+	err = u.esIndex.UpdateSearchProfile(userProfileES, jsonString)
+	if err != nil {
+		return dto.UserSearchProfile{}, err
+	}
+
+	return u.FindByProfileId(ctx, int(profile.ID))
+}
@@ -1,11 +1,13 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/SuperMatch/model"
 	elasticsearchPkg "github.com/SuperMatch/model/elasticSearch"
+	"github.com/SuperMatch/pkg/db/dao"
 	pkg "github.com/SuperMatch/pkg/elasticSeach"
 	"github.com/SuperMatch/zapLogger"
 	"github.com/google/uuid"
@@ -27,13 +29,15 @@ type UserStoriesInterface interface {
 	UploadFileToS3(userId string, file *multipart.FileHeader) (string, string, error)
 	IndexUserStories(userStories elasticsearchPkg.UserStories) error
 	GetUserStoriesByProfileID(userProfileID int) ([]elasticsearchPkg.UserStories, error)
-	GetUserStoriesByLocation(location model.UserLocation) ([]elasticsearchPkg.UserStories, error)
+	GetUserStoriesByLocation(userID int, location model.UserLocation) ([]elasticsearchPkg.UserStories, error)
 }
 
 type UserStoriesService struct {
 	esIndex            pkg.UserStoriesIndexer
 	userProfileService UserProfileInterface
 	s3Service          S3ServiceInterface
+	searchProfileDao   dao.UserSearchProfileRepository
+	userBlockDao       dao.UserBlockRepository
 }
 
 func NewUserStoriesService() *UserStoriesService {
@@ -41,6 +45,8 @@ func NewUserStoriesService() *UserStoriesService {
 		esIndex:            pkg.NewUserStoriesIndexerImpl(),
 		userProfileService: NewUserProfileService(),
 		s3Service:          NewS3Service(),
+		searchProfileDao:   dao.NewUserSearchProfile(),
+		userBlockDao:       dao.NewUserBlockRepository(),
 	}
 }
 
@@ -122,13 +128,26 @@ func (u *UserStoriesService) GetUserStoriesByProfileID(userProfileID int) ([]ela
 	return userStories, nil
 }
 
-func (u *UserStoriesService) GetUserStoriesByLocation(location model.UserLocation) ([]elasticsearchPkg.UserStories, error) {
-	userStories, err := u.esIndex.GetUserStoriesByLocation(location)
+// GetUserStoriesByLocation returns the stories near the location that the user can see, which leaves
+// out users blocked by or blocking the user and paused users.
+func (u *UserStoriesService) GetUserStoriesByLocation(userID int, location model.UserLocation) ([]elasticsearchPkg.UserStories, error) {
+	blockedProfileIDs, err := u.userBlockDao.FindBlockedProfileIds(userID)
+	if err != nil {
+		zapLogger.Logger.Error("error in getting blocked profiles", zap.Error(err))
+		return nil, err
+	}
+
+	userStories, err := u.esIndex.GetUserStoriesByLocation(location, blockedProfileIDs)
 	if err != nil {
 		zapLogger.Logger.Error("error in getting user stories from elastic search based on location")
 		return userStories, err
 	}
 
+	userStories, err = u.excludePausedProfiles(userStories)
+	if err != nil {
+		return userStories, err
+	}
+
 	for idx := range userStories {
 		if userStories[idx].MediaURL != "" {
 			key := strings.ReplaceAll(strings.TrimPrefix(userStories[idx].MediaURL, user_Stories_S3_Bucket_Path), "%3A", ":")
@@ -142,3 +161,30 @@ func (u *UserStoriesService) GetUserStoriesByLocation(location model.UserLocatio
 	}
 	return userStories, nil
 }
+
+// excludePausedProfiles leaves out the stories of paused users.
+func (u *UserStoriesService) excludePausedProfiles(userStories []elasticsearchPkg.UserStories) ([]elasticsearchPkg.UserStories, error) {
+	profileIDs := make([]int, 0, len(userStories))
+	for _, story := range userStories {
+		profileIDs = append(profileIDs, story.UserProfileID)
+	}
+
+	pausedIDs, err := u.searchProfileDao.FindPausedProfileIds(context.Background(), profileIDs)
+	if err != nil {
+		zapLogger.Logger.Error("error in getting paused profiles", zap.Error(err))
+		return userStories, err
+	}
+
+	paused := make(map[int]bool, len(pausedIDs))
+	for _, id := range pausedIDs {
+		paused[id] = true
+	}
+
+	visible := make([]elasticsearchPkg.UserStories, 0, len(userStories))
+	for _, story := range userStories {
+		if !paused[story.UserProfileID] {
+			visible = append(visible, story)
+		}
+	}
+	return visible, nil
+}
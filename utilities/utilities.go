@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/SuperMatch/model"
 
@@ -472,3 +473,31 @@ func ConvertSliceOfStringToCommaSeparatedString(arr []string) string {
 	str := strings.Join(arr[:], ", ")
 	return str
 }
+
+// TruncateString cuts s to at most max bytes without splitting a multi-byte character.
+func TruncateString(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
+
+// ExcludeInts returns the values of arr that are not in exclude, keeping their order.
+func ExcludeInts(arr []int, exclude []int) []int {
+	excluded := make(map[int]bool, len(exclude))
+	for _, val := range exclude {
+		excluded[val] = true
+	}
+
+	result := make([]int, 0, len(arr))
+	for _, val := range arr {
+		if !excluded[val] {
+			result = append(result, val)
+		}
+	}
+	return result
+}